@@ -1,18 +1,33 @@
 package main
 
 import (
+	"context"
 	"log"
+	"path/filepath"
 
 	"book-management/internal/config"
 	"book-management/internal/controllers"
+	"book-management/internal/i18n"
+	"book-management/internal/jobs"
 	"book-management/internal/middleware"
+	"book-management/internal/openapi"
 	"book-management/internal/repositories"
 	"book-management/internal/services"
+	"book-management/internal/storage"
 	"book-management/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
+// @title Book Management API
+// @version 1.0
+// @description REST API for managing books, categories, library circulation and reading data.
+// @description Responses use the utils.Response envelope; errors can also be returned as RFC 7807 problem details.
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT obtained from /api/users/login, sent as "Bearer <token>".
 func main() {
 	// Load configuration
 	cfg, err := config.LoadConfig()
@@ -21,29 +36,118 @@ func main() {
 	}
 	defer cfg.DB.Close()
 
+	// Apply book validation rules from configuration
+	utils.SetValidationRules(cfg.Validation)
+
+	// Choose the error response format (envelope or RFC 7807 problem details)
+	utils.SetErrorConfig(cfg.Errors)
+
+	// Messages without a requested language use the default locale
+	i18n.SetDefaultLocale(cfg.Locale.Default)
+
 	// Initialize JWT manager
 	jwtManager := utils.NewJWTManager(cfg.JWTSecret, cfg.JWTExpire)
 
+	// Initialize file storage
+	fileStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatal("Failed to initialize storage:", err)
+	}
+
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(cfg.DB)
 	categoryRepo := repositories.NewCategoryRepository(cfg.DB)
 	bookRepo := repositories.NewBookRepository(cfg.DB)
+	tagRepo := repositories.NewTagRepository(cfg.DB)
+	coverRepo := repositories.NewCoverRepository(cfg.DB)
+	bookFileRepo := repositories.NewBookFileRepository(cfg.DB)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(cfg.DB)
+	priceRepo := repositories.NewPriceRepository(cfg.DB)
+	discountRepo := repositories.NewDiscountRepository(cfg.DB)
+	itemRepo := repositories.NewItemRepository(cfg.DB)
+	patronRepo := repositories.NewPatronRepository(cfg.DB)
+	loanRepo := repositories.NewLoanRepository(cfg.DB)
+	loanPolicyRepo := repositories.NewLoanPolicyRepository(cfg.DB)
+	holdRepo := repositories.NewHoldRepository(cfg.DB)
+	ledgerRepo := repositories.NewLedgerRepository(cfg.DB)
+	reviewRepo := repositories.NewReviewRepository(cfg.DB)
+	readingListRepo := repositories.NewReadingListRepository(cfg.DB)
+	readingProgressRepo := repositories.NewReadingProgressRepository(cfg.DB)
+	similarityRepo := repositories.NewSimilarityRepository(cfg.DB)
+	bookMergeRepo := repositories.NewBookMergeRepository(cfg.DB)
+	workRepo := repositories.NewWorkRepository(cfg.DB)
+	seriesRepo := repositories.NewSeriesRepository(cfg.DB)
+	translationRepo := repositories.NewTranslationRepository(cfg.DB)
 
 	// Initialize services
 	authService := services.NewAuthService(userRepo, jwtManager)
-	categoryService := services.NewCategoryService(categoryRepo)
-	bookService := services.NewBookService(bookRepo)
+	translationService := services.NewTranslationService(translationRepo, bookRepo, categoryRepo, cfg.Locale.Default, cfg.Locale.Supported)
+	categoryService := services.NewCategoryService(categoryRepo, translationService)
+	if err := categoryService.BackfillSlugs(); err != nil {
+		log.Fatal("Failed to generate category slugs:", err)
+	}
+	exchangeRateService := services.NewExchangeRateService(exchangeRateRepo, cfg.DefaultCurrency)
+	pricingService := services.NewPricingService(bookRepo, categoryRepo, priceRepo, discountRepo, exchangeRateService)
+	bookService := services.NewBookService(bookRepo, fileStorage, cfg.Thickness, exchangeRateService, pricingService, translationService)
+	tagService := services.NewTagService(tagRepo)
+	coverService := services.NewCoverService(bookRepo, coverRepo, fileStorage, cfg.Cover.MaxSizeBytes, cfg.Cover.ThumbnailWidths)
+	bookFileService := services.NewBookFileService(bookRepo, bookFileRepo, fileStorage, cfg.BookFileMaxSizeBytes)
+	metadataService := services.NewMetadataService(bookService, bookFileService, coverService)
+	itemService := services.NewItemService(itemRepo, bookRepo)
+	patronService := services.NewPatronService(patronRepo)
+	fineService := services.NewFineService(ledgerRepo, patronRepo, cfg.Loan.FineCurrency)
+	circulationService := services.NewCirculationService(loanRepo, loanPolicyRepo, holdRepo, itemRepo, patronRepo, bookRepo, categoryRepo, cfg.Loan)
+	reviewService := services.NewReviewService(reviewRepo, bookRepo, cfg.ReviewModerators)
+	readingService := services.NewReadingService(readingListRepo, readingProgressRepo, bookRepo)
+	similarityService := services.NewSimilarityService(similarityRepo, bookRepo, cfg.SimilarBooksPerBook)
+	bookMergeService := services.NewBookMergeService(bookMergeRepo, bookRepo, fileStorage, cfg.Loan.HoldPickupDays)
+	workService := services.NewWorkService(workRepo, bookRepo)
+	seriesService := services.NewSeriesService(seriesRepo, bookRepo)
 
 	// Initialize controllers
 	authController := controllers.NewAuthController(authService)
 	categoryController := controllers.NewCategoryController(categoryService)
 	bookController := controllers.NewBookController(bookService)
+	tagController := controllers.NewTagController(tagService)
+	coverController := controllers.NewCoverController(coverService)
+	bookFileController := controllers.NewBookFileController(bookFileService)
+	metadataController := controllers.NewMetadataController(metadataService)
+	exchangeRateController := controllers.NewExchangeRateController(exchangeRateService)
+	pricingController := controllers.NewPricingController(pricingService)
+	itemController := controllers.NewItemController(itemService)
+	patronController := controllers.NewPatronController(patronService, circulationService)
+	loanController := controllers.NewLoanController(circulationService)
+	holdController := controllers.NewHoldController(circulationService)
+	fineController := controllers.NewFineController(fineService)
+	reviewController := controllers.NewReviewController(reviewService)
+	readingController := controllers.NewReadingController(readingService)
+	similarityController := controllers.NewSimilarityController(similarityService)
+	bookMergeController := controllers.NewBookMergeController(bookMergeService)
+	workController := controllers.NewWorkController(workService)
+	seriesController := controllers.NewSeriesController(seriesService)
+	translationController := controllers.NewTranslationController(translationService)
+
+	// Start background jobs
+	jobRunner := jobs.NewRunner()
+	jobRunner.Add("scheduled-prices", cfg.Jobs.ScheduledPriceInterval, pricingService.ApplyScheduledPriceChanges)
+	jobRunner.Add("expire-holds", cfg.Jobs.HoldExpiryInterval, circulationService.ExpireHolds)
+	jobRunner.Add("accrue-fines", cfg.Jobs.FineAccrualInterval, fineService.AccrueFines)
+	jobRunner.Add("similar-books", cfg.Jobs.SimilarBooksInterval, similarityService.RefreshSimilarBooks)
+	jobRunner.Start(context.Background())
 
 	// Initialize Gin router
 	router := gin.Default()
 
 	// Add middleware
 	router.Use(middleware.CORSMiddleware())
+	router.Use(middleware.LocaleMiddleware(cfg.Locale.Supported, cfg.Locale.Default))
+	router.Use(middleware.ErrorHandler())
+
+	// Covers kept on local disk are served by the API itself; other stored
+	// files (book attachments) are only reachable through their endpoints
+	if cfg.Storage.Driver == "local" {
+		router.Static("/uploads/"+storage.PublicPrefix, filepath.Join(cfg.Storage.LocalDir, storage.PublicPrefix))
+	}
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -53,6 +157,11 @@ func main() {
 		})
 	})
 
+	// OpenAPI document generated from the handler annotations, and its docs UI
+	router.GET("/openapi.json", openapi.SpecHandler())
+	router.GET("/docs", openapi.DocsHandler())
+	router.GET("/docs/:asset", openapi.DocsHandler())
+
 	// API routes
 	api := router.Group("/api")
 	{
@@ -62,6 +171,9 @@ func main() {
 			users.POST("/login", authController.Login)
 		}
 
+		// Shared reading lists (no authentication required)
+		api.GET("/shared/reading-lists/:token", readingController.GetSharedReadingList)
+
 		// Protected routes
 		protected := api.Group("")
 		protected.Use(middleware.JWTAuthMiddleware(authService))
@@ -71,10 +183,20 @@ func main() {
 			{
 				categories.GET("", categoryController.GetAllCategories)
 				categories.POST("", categoryController.CreateCategory)
+				categories.GET("/tree", categoryController.GetCategoryTree)
+				categories.GET("/by-slug/:slug", categoryController.GetCategoryBySlug)
 				categories.GET("/:id", categoryController.GetCategoryByID)
 				categories.PUT("/:id", categoryController.UpdateCategory)
 				categories.DELETE("/:id", categoryController.DeleteCategory)
+				categories.POST("/:id/move", categoryController.MoveCategory)
+				categories.POST("/:id/merge", categoryController.MergeCategory)
 				categories.GET("/:id/books", categoryController.GetBooksByCategory)
+				categories.GET("/:id/translations", translationController.GetCategoryTranslations)
+				categories.PUT("/:id/translations/:locale", translationController.SaveCategoryTranslation)
+				categories.DELETE("/:id/translations/:locale", translationController.DeleteCategoryTranslation)
+				categories.GET("/:id/loan-policy", loanController.GetCategoryLoanPolicy)
+				categories.PUT("/:id/loan-policy", loanController.SetCategoryLoanPolicy)
+				categories.DELETE("/:id/loan-policy", loanController.DeleteCategoryLoanPolicy)
 			}
 
 			// Books routes
@@ -82,9 +204,184 @@ func main() {
 			{
 				books.GET("", bookController.GetAllBooks)
 				books.POST("", bookController.CreateBook)
+				books.POST("/metadata/extract", metadataController.ExtractMetadata)
+				books.GET("/duplicates", bookMergeController.GetDuplicates)
+				books.GET("/merges", bookMergeController.GetMerges)
 				books.GET("/:id", bookController.GetBookByID)
 				books.PUT("/:id", bookController.UpdateBook)
 				books.DELETE("/:id", bookController.DeleteBook)
+				books.GET("/:id/translations", translationController.GetBookTranslations)
+				books.PUT("/:id/translations/:locale", translationController.SaveBookTranslation)
+				books.DELETE("/:id/translations/:locale", translationController.DeleteBookTranslation)
+				books.GET("/:id/cover", coverController.GetCover)
+				books.POST("/:id/cover", coverController.UploadCover)
+				books.DELETE("/:id/cover", coverController.DeleteCover)
+				books.GET("/:id/files", bookFileController.GetFiles)
+				books.POST("/:id/files", bookFileController.UploadFile)
+				books.GET("/:id/files/:fileId/download", bookFileController.DownloadFile)
+				books.HEAD("/:id/files/:fileId/download", bookFileController.DownloadFile)
+				books.GET("/:id/files/:fileId/downloads", bookFileController.GetDownloads)
+				books.DELETE("/:id/files/:fileId", bookFileController.DeleteFile)
+				books.GET("/:id/files/:fileId/metadata", metadataController.GetFileMetadata)
+				books.POST("/:id/files/:fileId/metadata/apply", metadataController.ApplyFileMetadata)
+				books.GET("/:id/price-history", pricingController.GetPriceHistory)
+				books.GET("/:id/scheduled-prices", pricingController.GetScheduledPriceChanges)
+				books.POST("/:id/scheduled-prices", pricingController.SchedulePriceChange)
+				books.DELETE("/:id/scheduled-prices/:changeId", pricingController.CancelScheduledPriceChange)
+				books.GET("/:id/items", itemController.GetBookItems)
+				books.POST("/:id/items", itemController.CreateItem)
+				books.GET("/:id/holds", holdController.GetBookHolds)
+				books.POST("/:id/holds", holdController.PlaceHold)
+				books.GET("/:id/similar", similarityController.GetSimilarBooks)
+				books.POST("/:id/merge", bookMergeController.MergeBook)
+				books.GET("/:id/editions", workController.GetBookEditions)
+				books.GET("/:id/series", seriesController.GetBookSeries)
+				books.GET("/:id/relations", workController.GetBookRelations)
+				books.POST("/:id/relations", workController.CreateBookRelation)
+				books.DELETE("/:id/relations/:relationId", workController.DeleteBookRelation)
+				books.GET("/:id/reviews", reviewController.GetBookReviews)
+				books.POST("/:id/reviews", reviewController.CreateReview)
+				books.GET("/:id/progress", readingController.GetBookProgress)
+				books.PUT("/:id/progress", readingController.UpdateBookProgress)
+				books.DELETE("/:id/progress", readingController.DeleteBookProgress)
+			}
+
+			// Works routes
+			works := protected.Group("/works")
+			{
+				works.GET("", workController.GetWorks)
+				works.POST("", workController.CreateWork)
+				works.GET("/:id", workController.GetWorkByID)
+				works.PUT("/:id", workController.UpdateWork)
+				works.DELETE("/:id", workController.DeleteWork)
+				works.POST("/:id/books", workController.AddWorkEdition)
+				works.DELETE("/:id/books/:bookId", workController.RemoveWorkEdition)
+			}
+
+			// Series routes
+			series := protected.Group("/series")
+			{
+				series.GET("", seriesController.GetAllSeries)
+				series.POST("", seriesController.CreateSeries)
+				series.GET("/:id", seriesController.GetSeriesByID)
+				series.PUT("/:id", seriesController.UpdateSeries)
+				series.DELETE("/:id", seriesController.DeleteSeries)
+				series.POST("/:id/books", seriesController.AddSeriesBook)
+				series.PUT("/:id/books/:bookId", seriesController.UpdateSeriesVolume)
+				series.DELETE("/:id/books/:bookId", seriesController.RemoveSeriesBook)
+			}
+
+			// Items routes
+			items := protected.Group("/items")
+			{
+				items.GET("", itemController.GetItems)
+				items.GET("/by-barcode/:barcode", itemController.GetItemByBarcode)
+				items.GET("/:id", itemController.GetItemByID)
+				items.PUT("/:id", itemController.UpdateItem)
+				items.DELETE("/:id", itemController.DeleteItem)
+			}
+
+			// Patrons routes
+			patrons := protected.Group("/patrons")
+			{
+				patrons.GET("", patronController.GetPatrons)
+				patrons.POST("", patronController.CreatePatron)
+				patrons.GET("/:id", patronController.GetPatronByID)
+				patrons.PUT("/:id", patronController.UpdatePatron)
+				patrons.DELETE("/:id", patronController.DeletePatron)
+				patrons.GET("/:id/loans", patronController.GetPatronLoans)
+				patrons.GET("/:id/holds", patronController.GetPatronHolds)
+				patrons.GET("/:id/ledger", fineController.GetPatronLedger)
+				patrons.GET("/:id/balance", fineController.GetPatronBalance)
+				patrons.POST("/:id/payments", fineController.RecordPayment)
+				patrons.POST("/:id/waivers", fineController.RecordWaiver)
+			}
+
+			// Loans routes
+			loans := protected.Group("/loans")
+			{
+				loans.GET("", loanController.GetLoans)
+				loans.POST("", loanController.Checkout)
+				loans.GET("/:id", loanController.GetLoanByID)
+				loans.POST("/:id/return", loanController.ReturnLoan)
+				loans.POST("/:id/renew", loanController.RenewLoan)
+			}
+
+			// Holds routes
+			holds := protected.Group("/holds")
+			{
+				holds.GET("", holdController.GetHolds)
+				holds.GET("/:id", holdController.GetHoldByID)
+				holds.POST("/:id/cancel", holdController.CancelHold)
+			}
+
+			// Reviews routes
+			reviews := protected.Group("/reviews")
+			{
+				reviews.GET("", reviewController.GetReviews)
+				reviews.GET("/:id", reviewController.GetReview)
+				reviews.PUT("/:id", reviewController.UpdateReview)
+				reviews.DELETE("/:id", reviewController.DeleteReview)
+				reviews.POST("/:id/hide", reviewController.HideReview)
+				reviews.POST("/:id/unhide", reviewController.UnhideReview)
+			}
+
+			// Reading lists routes
+			readingLists := protected.Group("/reading-lists")
+			{
+				readingLists.GET("", readingController.GetReadingLists)
+				readingLists.POST("", readingController.CreateReadingList)
+				readingLists.GET("/:id", readingController.GetReadingList)
+				readingLists.PUT("/:id", readingController.UpdateReadingList)
+				readingLists.DELETE("/:id", readingController.DeleteReadingList)
+				readingLists.POST("/:id/books", readingController.AddReadingListBook)
+				readingLists.PUT("/:id/books/order", readingController.ReorderReadingList)
+				readingLists.DELETE("/:id/books/:bookId", readingController.RemoveReadingListBook)
+				readingLists.POST("/:id/share", readingController.ShareReadingList)
+				readingLists.DELETE("/:id/share", readingController.UnshareReadingList)
+			}
+
+			// Reading progress routes
+			readingProgress := protected.Group("/reading-progress")
+			{
+				readingProgress.GET("", readingController.GetReadingProgress)
+			}
+
+			// Loan policies routes
+			loanPolicies := protected.Group("/loan-policies")
+			{
+				loanPolicies.GET("", loanController.GetLoanPolicies)
+			}
+
+			// Tags routes
+			tags := protected.Group("/tags")
+			{
+				tags.GET("", tagController.GetAllTags)
+				tags.POST("", tagController.CreateTag)
+				tags.GET("/cloud", tagController.GetTagCloud)
+				tags.GET("/:id", tagController.GetTagByID)
+				tags.PUT("/:id", tagController.UpdateTag)
+				tags.DELETE("/:id", tagController.DeleteTag)
+			}
+
+			// Discounts routes
+			discounts := protected.Group("/discounts")
+			{
+				discounts.GET("", pricingController.GetDiscounts)
+				discounts.POST("", pricingController.CreateDiscount)
+				discounts.GET("/:id", pricingController.GetDiscountByID)
+				discounts.PUT("/:id", pricingController.UpdateDiscount)
+				discounts.DELETE("/:id", pricingController.DeleteDiscount)
+			}
+
+			// Exchange rates routes
+			exchangeRates := protected.Group("/exchange-rates")
+			{
+				exchangeRates.GET("", exchangeRateController.GetExchangeRates)
+				exchangeRates.POST("/import", exchangeRateController.ImportExchangeRates)
+				exchangeRates.GET("/:base/:quote", exchangeRateController.GetExchangeRate)
+				exchangeRates.PUT("/:base/:quote", exchangeRateController.SetExchangeRate)
+				exchangeRates.DELETE("/:base/:quote", exchangeRateController.DeleteExchangeRate)
 			}
 		}
 	}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"book-management/internal/openapi"
+)
+
+// Generates the OpenAPI document from the controller annotations.
+// Usually run through `go generate ./internal/openapi`.
+func main() {
+	root := flag.String("root", ".", "module root directory")
+	out := flag.String("out", "openapi.json", "output file")
+	flag.Parse()
+
+	spec, err := openapi.Generate(*root)
+	if err != nil {
+		log.Fatal("Failed to generate OpenAPI document:", err)
+	}
+
+	if err := os.WriteFile(*out, spec, 0644); err != nil {
+		log.Fatal("Failed to write OpenAPI document:", err)
+	}
+}
@@ -0,0 +1,48 @@
+// Command recalculate-thickness menghitung ulang kolom thickness semua buku
+// dengan skema BOOK_THICKNESS_SCHEME saat ini. Jalankan setelah skema diubah:
+//
+//	go run ./cmd/recalculate-thickness [-dry-run]
+package main
+
+import (
+	"flag"
+	"log"
+
+	"book-management/internal/config"
+	"book-management/internal/repositories"
+	"book-management/internal/services"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report the changes without saving them")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+	defer cfg.DB.Close()
+
+	// Stored files, prices and translations are not touched, so their services are not needed
+	bookRepo := repositories.NewBookRepository(cfg.DB)
+	bookService := services.NewBookService(bookRepo, nil, cfg.Thickness, nil, nil, nil)
+
+	log.Printf("Using thickness scheme %s", cfg.Thickness)
+
+	result, err := bookService.RecalculateThickness(*dryRun)
+	if err != nil {
+		log.Fatal("Failed to recalculate thickness:", err)
+	}
+
+	for _, label := range cfg.Thickness.Labels() {
+		log.Printf("%s: %d books", label, result.Counts[label])
+	}
+
+	if *dryRun {
+		log.Printf("Dry run: %d of %d books would change", result.ChangedBooks, result.TotalBooks)
+		return
+	}
+
+	log.Printf("Updated %d of %d books", result.ChangedBooks, result.TotalBooks)
+}
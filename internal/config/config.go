@@ -6,6 +6,12 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"book-management/internal/currency"
+	"book-management/internal/locale"
+	"book-management/internal/models"
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
@@ -13,10 +19,161 @@ import (
 )
 
 type Config struct {
-	DB        *sql.DB
-	Port      string
-	JWTSecret string
-	JWTExpire int
+	DB         *sql.DB
+	Port       string
+	JWTSecret  string
+	JWTExpire  int
+	Storage    StorageConfig
+	Cover      CoverConfig
+	Validation ValidationConfig
+	Thickness  models.ThicknessScheme
+	Jobs       JobsConfig
+	Loan       LoanConfig
+	Locale     LocaleConfig
+	Errors     ErrorConfig
+
+	// DefaultCurrency dipakai untuk harga buku tanpa mata uang dan sebagai
+	// perantara konversi kurs silang
+	DefaultCurrency string
+
+	// ReviewModerators adalah username yang boleh menyembunyikan ulasan
+	ReviewModerators []string
+
+	// SimilarBooksPerBook adalah jumlah buku mirip yang disimpan per buku
+	SimilarBooksPerBook int
+
+	BookFileMaxSizeBytes int64
+}
+
+// StorageConfig memilih tempat penyimpanan file unggahan: "local" (disk)
+// atau "s3" (layanan yang kompatibel dengan S3 seperti MinIO)
+type StorageConfig struct {
+	Driver         string
+	LocalDir       string
+	PublicURL      string
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3UsePathStyle bool
+}
+
+type CoverConfig struct {
+	MaxSizeBytes    int64
+	ThumbnailWidths []int
+}
+
+// JobsConfig berisi interval background job; interval 0 menonaktifkan job
+type JobsConfig struct {
+	ScheduledPriceInterval time.Duration
+	HoldExpiryInterval     time.Duration
+	FineAccrualInterval    time.Duration
+	SimilarBooksInterval   time.Duration
+}
+
+// LoanConfig berisi kebijakan peminjaman default untuk kategori yang (beserta
+// induknya) tidak memiliki kebijakan sendiri. MaxActiveLoans 0 berarti tanpa
+// batas jumlah pinjaman aktif per anggota. HoldPickupDays adalah lama
+// eksemplar disisihkan untuk hold sebelum diberikan ke antrean berikutnya.
+// Denda ditulis dalam minor unit FineCurrency; DefaultFineCap dan
+// FineBlockThreshold 0 berarti tanpa batas.
+type LoanConfig struct {
+	DefaultLoanDays      int
+	DefaultMaxRenewals   int
+	MaxActiveLoans       int
+	HoldPickupDays       int
+	DefaultFinePerDay    int
+	DefaultFineGraceDays int
+	DefaultFineCap       int
+	FineBlockThreshold   int
+	FineCurrency         string
+}
+
+// LocaleConfig berisi bahasa konten yang didukung. Judul, deskripsi dan nama
+// kategori yang tersimpan di buku dan kategori dianggap berbahasa Default;
+// bahasa lain disimpan sebagai terjemahan.
+type LocaleConfig struct {
+	Default   string
+	Supported []string
+}
+
+// ErrorConfig menentukan bentuk respons kesalahan. Format "envelope" memakai
+// utils.Response, format "problem" memakai RFC 7807 Problem Details untuk
+// semua request; dengan "envelope", klien tetap bisa meminta Problem Details
+// lewat header Accept: application/problem+json. ProblemTypeBaseURL diikuti
+// kode kesalahan menjadi field type; bila kosong, type bernilai about:blank.
+type ErrorConfig struct {
+	Format             string
+	ProblemTypeBaseURL string
+}
+
+// ValidationConfig berisi batas nilai buku yang divalidasi saat create/update.
+// Nilai maksimum 0 berarti tanpa batas atas.
+type ValidationConfig struct {
+	ReleaseYearMin YearBound
+	ReleaseYearMax YearBound
+	PriceMin       int
+	PriceMax       int
+	TotalPageMin   int
+	TotalPageMax   int
+	TitleMaxLength int
+}
+
+// YearBound adalah batas tahun yang bisa tetap ("2030") atau relatif
+// terhadap tahun berjalan ("current", "current+1", "current-50")
+type YearBound struct {
+	Year     int
+	Relative bool
+}
+
+// Resolve mengembalikan nilai tahun untuk tahun berjalan currentYear
+func (b YearBound) Resolve(currentYear int) int {
+	if b.Relative {
+		return currentYear + b.Year
+	}
+	return b.Year
+}
+
+func (b YearBound) String() string {
+	switch {
+	case !b.Relative:
+		return strconv.Itoa(b.Year)
+	case b.Year == 0:
+		return "current"
+	case b.Year > 0:
+		return fmt.Sprintf("current+%d", b.Year)
+	default:
+		return fmt.Sprintf("current%d", b.Year)
+	}
+}
+
+// ParseYearBound membaca batas tahun seperti "1980", "current" atau "current+1"
+func ParseYearBound(value string) (YearBound, error) {
+	value = strings.ToLower(strings.ReplaceAll(value, " ", ""))
+	if !strings.HasPrefix(value, "current") {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			return YearBound{}, fmt.Errorf("invalid year %q", value)
+		}
+		return YearBound{Year: year}, nil
+	}
+
+	offset := strings.TrimPrefix(value, "current")
+	if offset == "" {
+		return YearBound{Relative: true}, nil
+	}
+
+	if offset[0] != '+' && offset[0] != '-' {
+		return YearBound{}, fmt.Errorf("invalid year %q", value)
+	}
+
+	n, err := strconv.Atoi(offset)
+	if err != nil {
+		return YearBound{}, fmt.Errorf("invalid year %q", value)
+	}
+
+	return YearBound{Year: n, Relative: true}, nil
 }
 
 func LoadConfig() (*Config, error) {
@@ -44,6 +201,101 @@ func LoadConfig() (*Config, error) {
 	// Server configuration
 	port := getEnv("PORT", "8080")
 
+	// Storage configuration
+	storageDriver := getEnv("STORAGE_DRIVER", "local")
+	defaultPublicURL := ""
+	if storageDriver == "local" {
+		defaultPublicURL = "http://localhost:" + port + "/uploads"
+	}
+
+	storageConfig := StorageConfig{
+		Driver:         storageDriver,
+		LocalDir:       getEnv("STORAGE_LOCAL_DIR", "uploads"),
+		PublicURL:      getEnv("STORAGE_PUBLIC_URL", defaultPublicURL),
+		S3Endpoint:     getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		S3Region:       getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:       getEnv("S3_BUCKET", "book-management"),
+		S3AccessKey:    getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:    getEnv("S3_SECRET_KEY", ""),
+		S3UsePathStyle: getEnvBool("S3_USE_PATH_STYLE", true),
+	}
+
+	// Cover image configuration
+	coverConfig := CoverConfig{
+		MaxSizeBytes:    int64(getEnvInt("COVER_MAX_SIZE_MB", 5)) << 20,
+		ThumbnailWidths: getEnvIntList("COVER_THUMBNAIL_WIDTHS", []int{150, 300, 600}),
+	}
+
+	// Book validation rules
+	validationConfig, err := loadValidationConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Thickness classification
+	thicknessScheme := models.DefaultThicknessScheme
+	if value := getEnv("BOOK_THICKNESS_SCHEME", ""); value != "" {
+		thicknessScheme, err = models.ParseThicknessScheme(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BOOK_THICKNESS_SCHEME: %v", err)
+		}
+	}
+
+	// Currency configuration
+	defaultCurrency := currency.Normalize(getEnv("DEFAULT_CURRENCY", "IDR"))
+	if !currency.IsSupported(defaultCurrency) {
+		return nil, fmt.Errorf("unsupported DEFAULT_CURRENCY %q", defaultCurrency)
+	}
+
+	// Locale configuration
+	localeConfig, err := loadLocaleConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Error response configuration
+	errorFormat := strings.ToLower(getEnv("ERROR_FORMAT", "envelope"))
+	if errorFormat != "envelope" && errorFormat != "problem" {
+		return nil, fmt.Errorf("invalid ERROR_FORMAT %q, use envelope or problem", errorFormat)
+	}
+
+	// Background jobs configuration
+	jobsConfig := JobsConfig{
+		ScheduledPriceInterval: time.Duration(getEnvInt("SCHEDULED_PRICE_INTERVAL_SECONDS", 60)) * time.Second,
+		HoldExpiryInterval:     time.Duration(getEnvInt("HOLD_EXPIRY_INTERVAL_SECONDS", 300)) * time.Second,
+		FineAccrualInterval:    time.Duration(getEnvInt("FINE_ACCRUAL_INTERVAL_SECONDS", 3600)) * time.Second,
+		SimilarBooksInterval:   time.Duration(getEnvInt("SIMILAR_BOOKS_INTERVAL_SECONDS", 21600)) * time.Second,
+	}
+
+	// Circulation configuration
+	loanConfig := LoanConfig{
+		DefaultLoanDays:    getEnvInt("LOAN_DEFAULT_DAYS", 14),
+		DefaultMaxRenewals: getEnvInt("LOAN_DEFAULT_MAX_RENEWALS", 2),
+		MaxActiveLoans:     getEnvInt("LOAN_MAX_ACTIVE_PER_PATRON", 5),
+		HoldPickupDays:     getEnvInt("HOLD_PICKUP_DAYS", 3),
+
+		DefaultFinePerDay:    getEnvInt("FINE_DEFAULT_PER_DAY", 1000),
+		DefaultFineGraceDays: getEnvInt("FINE_DEFAULT_GRACE_DAYS", 0),
+		DefaultFineCap:       getEnvInt("FINE_DEFAULT_CAP", 0),
+		FineBlockThreshold:   getEnvInt("FINE_BLOCK_THRESHOLD", 50000),
+		FineCurrency:         defaultCurrency,
+	}
+	if loanConfig.DefaultLoanDays < 1 || loanConfig.DefaultMaxRenewals < 0 || loanConfig.MaxActiveLoans < 0 {
+		return nil, fmt.Errorf("LOAN_DEFAULT_DAYS must be at least 1 and LOAN_DEFAULT_MAX_RENEWALS, LOAN_MAX_ACTIVE_PER_PATRON must not be negative")
+	}
+	if loanConfig.HoldPickupDays < 1 {
+		return nil, fmt.Errorf("HOLD_PICKUP_DAYS must be at least 1")
+	}
+	if loanConfig.DefaultFinePerDay < 0 || loanConfig.DefaultFineGraceDays < 0 || loanConfig.DefaultFineCap < 0 || loanConfig.FineBlockThreshold < 0 {
+		return nil, fmt.Errorf("FINE_DEFAULT_PER_DAY, FINE_DEFAULT_GRACE_DAYS, FINE_DEFAULT_CAP and FINE_BLOCK_THRESHOLD must not be negative")
+	}
+
+	// Recommendations configuration
+	similarBooksPerBook := getEnvInt("SIMILAR_BOOKS_PER_BOOK", 20)
+	if similarBooksPerBook < 1 {
+		return nil, fmt.Errorf("SIMILAR_BOOKS_PER_BOOK must be at least 1")
+	}
+
 	// Database connection
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode)
@@ -63,13 +315,102 @@ func LoadConfig() (*Config, error) {
 	}
 
 	return &Config{
-		DB:        db,
-		Port:      port,
-		JWTSecret: jwtSecret,
-		JWTExpire: jwtExpire,
+		DB:         db,
+		Port:       port,
+		JWTSecret:  jwtSecret,
+		JWTExpire:  jwtExpire,
+		Storage:    storageConfig,
+		Cover:      coverConfig,
+		Validation: validationConfig,
+		Thickness:  thicknessScheme,
+		Jobs:       jobsConfig,
+		Loan:       loanConfig,
+		Locale:     localeConfig,
+		Errors: ErrorConfig{
+			Format:             errorFormat,
+			ProblemTypeBaseURL: getEnv("PROBLEM_TYPE_BASE_URL", ""),
+		},
+
+		DefaultCurrency: defaultCurrency,
+
+		ReviewModerators:    getEnvList("REVIEW_MODERATORS", []string{"admin"}),
+		SimilarBooksPerBook: similarBooksPerBook,
+
+		BookFileMaxSizeBytes: int64(getEnvInt("BOOK_FILE_MAX_SIZE_MB", 100)) << 20,
 	}, nil
 }
 
+func loadLocaleConfig() (LocaleConfig, error) {
+	defaultLocale := locale.Normalize(getEnv("DEFAULT_LOCALE", "id"))
+	if defaultLocale == "" {
+		return LocaleConfig{}, fmt.Errorf("invalid DEFAULT_LOCALE")
+	}
+
+	// The default locale is always supported and listed first
+	supported := []string{defaultLocale}
+	for _, value := range getEnvList("SUPPORTED_LOCALES", []string{"id", "en"}) {
+		code := locale.Normalize(value)
+		if code == "" {
+			return LocaleConfig{}, fmt.Errorf("invalid locale %q in SUPPORTED_LOCALES", value)
+		}
+
+		known := false
+		for _, existing := range supported {
+			if existing == code {
+				known = true
+				break
+			}
+		}
+		if !known {
+			supported = append(supported, code)
+		}
+	}
+
+	return LocaleConfig{Default: defaultLocale, Supported: supported}, nil
+}
+
+func loadValidationConfig() (ValidationConfig, error) {
+	yearMin, err := ParseYearBound(getEnv("BOOK_RELEASE_YEAR_MIN", "1980"))
+	if err != nil {
+		return ValidationConfig{}, fmt.Errorf("invalid BOOK_RELEASE_YEAR_MIN: %v", err)
+	}
+
+	yearMax, err := ParseYearBound(getEnv("BOOK_RELEASE_YEAR_MAX", "current+1"))
+	if err != nil {
+		return ValidationConfig{}, fmt.Errorf("invalid BOOK_RELEASE_YEAR_MAX: %v", err)
+	}
+
+	currentYear := time.Now().Year()
+	if yearMin.Resolve(currentYear) > yearMax.Resolve(currentYear) {
+		return ValidationConfig{}, fmt.Errorf("BOOK_RELEASE_YEAR_MIN must not be after BOOK_RELEASE_YEAR_MAX")
+	}
+
+	cfg := ValidationConfig{
+		ReleaseYearMin: yearMin,
+		ReleaseYearMax: yearMax,
+		PriceMin:       getEnvInt("BOOK_PRICE_MIN", 0),
+		PriceMax:       getEnvInt("BOOK_PRICE_MAX", 0),
+		TotalPageMin:   getEnvInt("BOOK_TOTAL_PAGE_MIN", 1),
+		TotalPageMax:   getEnvInt("BOOK_TOTAL_PAGE_MAX", 0),
+		TitleMaxLength: getEnvInt("BOOK_TITLE_MAX_LENGTH", 255),
+	}
+
+	// The books table stores at most 1000 characters per title
+	if cfg.TitleMaxLength < 1 || cfg.TitleMaxLength > 1000 {
+		return ValidationConfig{}, fmt.Errorf("BOOK_TITLE_MAX_LENGTH must be between 1 and 1000")
+	}
+
+	if cfg.PriceMin < 0 || (cfg.PriceMax > 0 && cfg.PriceMax < cfg.PriceMin) {
+		return ValidationConfig{}, fmt.Errorf("invalid BOOK_PRICE_MIN/BOOK_PRICE_MAX range")
+	}
+
+	if cfg.TotalPageMin < 1 || (cfg.TotalPageMax > 0 && cfg.TotalPageMax < cfg.TotalPageMin) {
+		return ValidationConfig{}, fmt.Errorf("invalid BOOK_TOTAL_PAGE_MIN/BOOK_TOTAL_PAGE_MAX range")
+	}
+
+	return cfg, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -77,6 +418,58 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvIntList membaca daftar angka yang dipisahkan koma, misalnya "150,300"
+func getEnvIntList(key string, defaultValue []int) []int {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []int
+	for _, part := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return defaultValue
+		}
+		result = append(result, number)
+	}
+
+	return result
+}
+
+// getEnvList membaca daftar teks yang dipisahkan koma, misalnya "admin,editor"
+func getEnvList(key string, defaultValue []string) []string {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+
+	return result
+}
+
 func runMigrations(db *sql.DB) error {
 	migrations := &migrate.FileMigrationSource{
 		Dir: "migrations",
@@ -38,7 +38,7 @@ func (ctrl *AuthController) Login(c *gin.Context) {
 
 	response, err := ctrl.authService.Login(&req)
 	if err != nil {
-		utils.Unauthorized(c, err.Error())
+		c.Error(err)
 		return
 	}
 
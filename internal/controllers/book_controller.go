@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"strconv"
+	"strings"
 
 	"book-management/internal/models"
 	"book-management/internal/services"
@@ -22,18 +23,37 @@ func NewBookController(bookService *services.BookService) *BookController {
 
 // GetAllBooks godoc
 // @Summary Get all books
-// @Description Get a list of all books with category information
+// @Description Get a list of all books with category information, optionally filtered by tags
 // @Tags books
 // @Produce json
 // @Security BearerAuth
+// @Param tags query string false "Comma separated tag names"
+// @Param tag_match query string false "Match all tags (default) or any tag" Enums(all, any)
+// @Param currency query string false "ISO 4217 currency code to convert prices into, e.g. USD"
+// @Param sort query string false "Sort by highest rating instead of ID" Enums(rating)
+// @Param lang query string false "Content language, e.g. en (defaults to the Accept-Language header, then the default locale)"
 // @Success 200 {object} utils.Response{data=[]models.BookWithCategory}
+// @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /api/books [get]
 func (ctrl *BookController) GetAllBooks(c *gin.Context) {
-	books, err := ctrl.bookService.GetAllBooks()
+	filter := models.BookFilter{
+		Tags:        splitQueryList(c.Query("tags")),
+		TagMatchAny: c.Query("tag_match") == "any",
+		Sort:        c.Query("sort"),
+	}
+
+	if filter.Sort != "" && filter.Sort != "rating" {
+		utils.BadRequest(c, "Invalid sort", "sort must be rating")
+		return
+	}
+
+	view := models.BookViewOptions{Currency: c.Query("currency"), Locale: c.GetString("locale")}
+
+	books, err := ctrl.bookService.GetAllBooks(filter, view)
 	if err != nil {
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
 		return
 	}
 
@@ -47,6 +67,8 @@ func (ctrl *BookController) GetAllBooks(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Book ID"
+// @Param currency query string false "ISO 4217 currency code to convert the price into, e.g. USD"
+// @Param lang query string false "Content language, e.g. en (defaults to the Accept-Language header, then the default locale)"
 // @Success 200 {object} utils.Response{data=models.BookWithCategory}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
@@ -61,13 +83,11 @@ func (ctrl *BookController) GetBookByID(c *gin.Context) {
 		return
 	}
 
-	book, err := ctrl.bookService.GetBookByID(id)
+	view := models.BookViewOptions{Currency: c.Query("currency"), Locale: c.GetString("locale")}
+
+	book, err := ctrl.bookService.GetBookByID(id, view)
 	if err != nil {
-		if err.Error() == "book not found" {
-			utils.NotFound(c, "Book not found")
-			return
-		}
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
 		return
 	}
 
@@ -97,16 +117,7 @@ func (ctrl *BookController) CreateBook(c *gin.Context) {
 	username := c.GetString("username")
 	book, err := ctrl.bookService.CreateBook(&req, username)
 	if err != nil {
-		if err.Error()[:10] == "validation" {
-			errors := utils.FormatValidationErrors(err)
-			utils.BadRequest(c, "Validation failed", errors)
-			return
-		}
-		if err.Error() == "category not found" {
-			utils.BadRequest(c, "Category not found", nil)
-			return
-		}
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
 		return
 	}
 
@@ -145,20 +156,7 @@ func (ctrl *BookController) UpdateBook(c *gin.Context) {
 	username := c.GetString("username")
 	book, err := ctrl.bookService.UpdateBook(id, &req, username)
 	if err != nil {
-		if err.Error() == "book not found" {
-			utils.NotFound(c, "Book not found")
-			return
-		}
-		if err.Error() == "category not found" {
-			utils.BadRequest(c, "Category not found", nil)
-			return
-		}
-		if err.Error()[:10] == "validation" {
-			errors := utils.FormatValidationErrors(err)
-			utils.BadRequest(c, "Validation failed", errors)
-			return
-		}
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
 		return
 	}
 
@@ -176,6 +174,7 @@ func (ctrl *BookController) UpdateBook(c *gin.Context) {
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /api/books/{id} [delete]
 func (ctrl *BookController) DeleteBook(c *gin.Context) {
@@ -188,13 +187,25 @@ func (ctrl *BookController) DeleteBook(c *gin.Context) {
 
 	err = ctrl.bookService.DeleteBook(id)
 	if err != nil {
-		if err.Error() == "book not found" {
-			utils.NotFound(c, "Book not found")
-			return
-		}
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
 		return
 	}
 
 	utils.OK(c, "Book deleted successfully", nil)
 }
+
+// splitQueryList memecah parameter query yang dipisahkan koma
+func splitQueryList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"book-management/internal/i18n"
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookFileController struct {
+	fileService *services.BookFileService
+}
+
+func NewBookFileController(fileService *services.BookFileService) *BookFileController {
+	return &BookFileController{
+		fileService: fileService,
+	}
+}
+
+// GetFiles godoc
+// @Summary Get book files
+// @Description Get the digital files (PDF, EPUB, MOBI) attached to a book
+// @Tags files
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=[]models.BookFile}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/files [get]
+func (ctrl *BookFileController) GetFiles(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	files, err := ctrl.fileService.GetFiles(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Files retrieved successfully", files)
+}
+
+// UploadFile godoc
+// @Summary Upload book file
+// @Description Attach a PDF, EPUB or MOBI file to a book. The format is detected from the file content and an existing file of the same format is replaced.
+// @Tags files
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param file formData file true "Book file"
+// @Success 201 {object} utils.Response{data=models.BookFile}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 413 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/files [post]
+func (ctrl *BookFileController) UploadFile(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	// Leave some room for the multipart envelope around the file itself
+	maxSize := ctrl.fileService.MaxSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.PayloadTooLarge(c, i18n.T(c.GetString("locale"), "File must not exceed %d MB", maxSize>>20))
+			return
+		}
+		utils.BadRequest(c, "File is required", err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.BadRequest(c, "Failed to read file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	username := c.GetString("username")
+	bookFile, err := ctrl.fileService.UploadFile(id, fileHeader.Filename, file, fileHeader.Size, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "File uploaded successfully", bookFile)
+}
+
+// DownloadFile godoc
+// @Summary Download book file
+// @Description Stream a book file. Supports HTTP Range and If-Range requests for resumable downloads; the ETag and X-Checksum-SHA256 headers carry the SHA-256 checksum of the whole file. Every download is recorded in the audit log.
+// @Tags files
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param fileId path int true "File ID"
+// @Param Range header string false "Byte range, e.g. bytes=0-1048575"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 416 {string} string
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/files/{fileId}/download [get]
+func (ctrl *BookFileController) DownloadFile(c *gin.Context) {
+	bookID, fileID, ok := parseBookFileIDs(c)
+	if !ok {
+		return
+	}
+
+	file, content, err := ctrl.fileService.OpenFile(bookID, fileID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer content.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	c.Header("Content-Type", file.ContentType)
+	c.Header("Content-Disposition", disposition)
+	c.Header("ETag", `"`+file.ChecksumSHA256+`"`)
+	c.Header("X-Checksum-SHA256", file.ChecksumSHA256)
+
+	// ServeContent answers Range, If-Range and conditional requests
+	http.ServeContent(c.Writer, c.Request, file.FileName, file.CreatedAt, content)
+
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+
+	rangeHeader := c.GetHeader("Range")
+	if len(rangeHeader) > 255 {
+		rangeHeader = rangeHeader[:255]
+	}
+
+	bytesSent := int64(c.Writer.Size())
+	if bytesSent < 0 {
+		bytesSent = 0
+	}
+
+	userID := c.GetInt("user_id")
+	download := &models.FileDownload{
+		FileID:      &file.ID,
+		BookID:      file.BookID,
+		FileName:    file.FileName,
+		UserID:      &userID,
+		Username:    c.GetString("username"),
+		RangeHeader: rangeHeader,
+		StatusCode:  c.Writer.Status(),
+		BytesSent:   bytesSent,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	}
+	if err := ctrl.fileService.RecordDownload(download); err != nil {
+		log.Printf("Failed to record download of file %d: %v", file.ID, err)
+	}
+}
+
+// GetDownloads godoc
+// @Summary Get file downloads
+// @Description Get the download audit records of a book file, newest first
+// @Tags files
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param fileId path int true "File ID"
+// @Success 200 {object} utils.Response{data=[]models.FileDownload}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/files/{fileId}/downloads [get]
+func (ctrl *BookFileController) GetDownloads(c *gin.Context) {
+	bookID, fileID, ok := parseBookFileIDs(c)
+	if !ok {
+		return
+	}
+
+	downloads, err := ctrl.fileService.GetDownloads(bookID, fileID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Downloads retrieved successfully", downloads)
+}
+
+// DeleteFile godoc
+// @Summary Delete book file
+// @Description Delete a file attached to a book
+// @Tags files
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param fileId path int true "File ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/files/{fileId} [delete]
+func (ctrl *BookFileController) DeleteFile(c *gin.Context) {
+	bookID, fileID, ok := parseBookFileIDs(c)
+	if !ok {
+		return
+	}
+
+	err := ctrl.fileService.DeleteFile(bookID, fileID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "File deleted successfully", nil)
+}
+
+func parseBookFileIDs(c *gin.Context) (int, int, bool) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return 0, 0, false
+	}
+
+	fileID, err := strconv.Atoi(c.Param("fileId"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid file ID", err.Error())
+		return 0, 0, false
+	}
+
+	return bookID, fileID, true
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookMergeController struct {
+	mergeService *services.BookMergeService
+}
+
+func NewBookMergeController(mergeService *services.BookMergeService) *BookMergeController {
+	return &BookMergeController{
+		mergeService: mergeService,
+	}
+}
+
+// GetDuplicates godoc
+// @Summary Get duplicate candidates
+// @Description Get pairs of books that are probably the same record, scored by normalized title similarity, release year and page count
+// @Tags books
+// @Produce json
+// @Security BearerAuth
+// @Param min_score query number false "Minimum duplicate score between 0 and 1" default(0.8)
+// @Param limit query int false "Maximum number of pairs" default(50)
+// @Success 200 {object} utils.Response{data=[]models.DuplicateCandidate}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/duplicates [get]
+func (ctrl *BookMergeController) GetDuplicates(c *gin.Context) {
+	minScore, err := strconv.ParseFloat(c.DefaultQuery("min_score", "0.8"), 64)
+	if err != nil {
+		utils.BadRequest(c, "Invalid min_score", err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		utils.BadRequest(c, "Invalid limit", nil)
+		return
+	}
+
+	candidates, err := ctrl.mergeService.FindDuplicates(minScore, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Duplicate candidates retrieved successfully", candidates)
+}
+
+// MergeBook godoc
+// @Summary Merge books
+// @Description Merge the source book into this book: categories, tags, covers, files, prices, items, holds, reviews, reading lists and progress are moved, the source book is deleted and the merge is recorded for audit
+// @Tags books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Target book ID (kept)"
+// @Param request body models.MergeBookRequest true "Source book to merge"
+// @Success 200 {object} utils.Response{data=models.BookMerge}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/merge [post]
+func (ctrl *BookMergeController) MergeBook(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.MergeBookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	merge, err := ctrl.mergeService.MergeBooks(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Books merged successfully", merge)
+}
+
+// GetMerges godoc
+// @Summary Get book merges
+// @Description Get the audit trail of merged books, newest first
+// @Tags books
+// @Produce json
+// @Security BearerAuth
+// @Param book_id query int false "Only merges where this book was the target or the source"
+// @Success 200 {object} utils.Response{data=[]models.BookMerge}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/merges [get]
+func (ctrl *BookMergeController) GetMerges(c *gin.Context) {
+	bookID := 0
+	if value := c.Query("book_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid book ID", err.Error())
+			return
+		}
+		bookID = id
+	}
+
+	merges, err := ctrl.mergeService.GetMerges(bookID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book merges retrieved successfully", merges)
+}
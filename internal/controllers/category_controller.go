@@ -26,20 +26,42 @@ func NewCategoryController(categoryService *services.CategoryService) *CategoryC
 // @Tags categories
 // @Produce json
 // @Security BearerAuth
+// @Param lang query string false "Content language, e.g. en (defaults to the Accept-Language header, then the default locale)"
 // @Success 200 {object} utils.Response{data=[]models.Category}
 // @Failure 401 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /api/categories [get]
 func (ctrl *CategoryController) GetAllCategories(c *gin.Context) {
-	categories, err := ctrl.categoryService.GetAllCategories()
+	categories, err := ctrl.categoryService.GetAllCategories(c.GetString("locale"))
 	if err != nil {
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
 		return
 	}
 
 	utils.OK(c, "Categories retrieved successfully", categories)
 }
 
+// GetCategoryTree godoc
+// @Summary Get category tree
+// @Description Get all categories nested under their parent categories
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param lang query string false "Content language, e.g. en (defaults to the Accept-Language header, then the default locale)"
+// @Success 200 {object} utils.Response{data=[]models.CategoryNode}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/tree [get]
+func (ctrl *CategoryController) GetCategoryTree(c *gin.Context) {
+	tree, err := ctrl.categoryService.GetCategoryTree(c.GetString("locale"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Category tree retrieved successfully", tree)
+}
+
 // GetCategoryByID godoc
 // @Summary Get category by ID
 // @Description Get a specific category by its ID
@@ -47,6 +69,7 @@ func (ctrl *CategoryController) GetAllCategories(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Category ID"
+// @Param lang query string false "Content language, e.g. en (defaults to the Accept-Language header, then the default locale)"
 // @Success 200 {object} utils.Response{data=models.Category}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
@@ -61,13 +84,32 @@ func (ctrl *CategoryController) GetCategoryByID(c *gin.Context) {
 		return
 	}
 
-	category, err := ctrl.categoryService.GetCategoryByID(id)
+	category, err := ctrl.categoryService.GetCategoryByID(id, c.GetString("locale"))
 	if err != nil {
-		if err.Error() == "category not found" {
-			utils.NotFound(c, "Category not found")
-			return
-		}
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Category retrieved successfully", category)
+}
+
+// GetCategoryBySlug godoc
+// @Summary Get category by slug
+// @Description Get a specific category by its URL slug
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param slug path string true "Category slug"
+// @Param lang query string false "Content language, e.g. en (defaults to the Accept-Language header, then the default locale)"
+// @Success 200 {object} utils.Response{data=models.Category}
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/by-slug/{slug} [get]
+func (ctrl *CategoryController) GetCategoryBySlug(c *gin.Context) {
+	category, err := ctrl.categoryService.GetCategoryBySlug(c.Param("slug"), c.GetString("locale"))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -85,6 +127,7 @@ func (ctrl *CategoryController) GetCategoryByID(c *gin.Context) {
 // @Success 201 {object} utils.Response{data=models.Category}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
+// @Failure 409 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /api/categories [post]
 func (ctrl *CategoryController) CreateCategory(c *gin.Context) {
@@ -97,12 +140,7 @@ func (ctrl *CategoryController) CreateCategory(c *gin.Context) {
 	username := c.GetString("username")
 	category, err := ctrl.categoryService.CreateCategory(&req, username)
 	if err != nil {
-		if err.Error()[:10] == "validation" {
-			errors := utils.FormatValidationErrors(err)
-			utils.BadRequest(c, "Validation failed", errors)
-			return
-		}
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
 		return
 	}
 
@@ -122,6 +160,7 @@ func (ctrl *CategoryController) CreateCategory(c *gin.Context) {
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /api/categories/{id} [put]
 func (ctrl *CategoryController) UpdateCategory(c *gin.Context) {
@@ -141,33 +180,65 @@ func (ctrl *CategoryController) UpdateCategory(c *gin.Context) {
 	username := c.GetString("username")
 	category, err := ctrl.categoryService.UpdateCategory(id, &req, username)
 	if err != nil {
-		if err.Error() == "category not found" {
-			utils.NotFound(c, "Category not found")
-			return
-		}
-		if err.Error()[:10] == "validation" {
-			errors := utils.FormatValidationErrors(err)
-			utils.BadRequest(c, "Validation failed", errors)
-			return
-		}
-		utils.InternalServerError(c, err.Error(), nil)
+		c.Error(err)
 		return
 	}
 
 	utils.OK(c, "Category updated successfully", category)
 }
 
+// MoveCategory godoc
+// @Summary Move category
+// @Description Move a category and its whole subtree under another parent, or to the root when parent_id is null
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param request body models.MoveCategoryRequest true "New parent"
+// @Success 200 {object} utils.Response{data=models.Category}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/{id}/move [post]
+func (ctrl *CategoryController) MoveCategory(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid category ID", err.Error())
+		return
+	}
+
+	var req models.MoveCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	category, err := ctrl.categoryService.MoveCategory(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Category moved successfully", category)
+}
+
 // DeleteCategory godoc
 // @Summary Delete category
-// @Description Delete a category by ID
+// @Description Delete a category by ID. Subcategories are moved to the parent of the deleted category. Books whose primary category is being deleted must be moved with reassign_to, otherwise the request is rejected with 409.
 // @Tags categories
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Category ID"
-// @Success 200 {object} utils.Response
+// @Param reassign_to query int false "Category ID that receives the books of the deleted category"
+// @Success 200 {object} utils.Response{data=models.CategoryReassignResult}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /api/categories/{id} [delete]
 func (ctrl *CategoryController) DeleteCategory(c *gin.Context) {
@@ -178,26 +249,74 @@ func (ctrl *CategoryController) DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.categoryService.DeleteCategory(id)
-	if err != nil {
-		if err.Error() == "category not found" {
-			utils.NotFound(c, "Category not found")
+	var reassignTo *int
+	if value := c.Query("reassign_to"); value != "" {
+		targetID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid reassign_to category ID", err.Error())
 			return
 		}
-		utils.InternalServerError(c, err.Error(), nil)
+		reassignTo = &targetID
+	}
+
+	username := c.GetString("username")
+	result, err := ctrl.categoryService.DeleteCategory(id, reassignTo, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Category deleted successfully", result)
+}
+
+// MergeCategory godoc
+// @Summary Merge category
+// @Description Move all books and subcategories of a category into the target category, then delete it
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Source category ID"
+// @Param request body models.MergeCategoryRequest true "Target category"
+// @Success 200 {object} utils.Response{data=models.CategoryReassignResult}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/{id}/merge [post]
+func (ctrl *CategoryController) MergeCategory(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid category ID", err.Error())
 		return
 	}
 
-	utils.OK(c, "Category deleted successfully", nil)
+	var req models.MergeCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	result, err := ctrl.categoryService.MergeCategory(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Category merged successfully", result)
 }
 
 // GetBooksByCategory godoc
 // @Summary Get books by category
-// @Description Get all books in a specific category
+// @Description Get all books in a specific category, optionally including its subcategories
 // @Tags categories
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Category ID"
+// @Param include_descendants query bool false "Include books of all subcategories"
+// @Param lang query string false "Content language, e.g. en (defaults to the Accept-Language header, then the default locale)"
 // @Success 200 {object} utils.Response{data=[]models.BookWithCategory}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
@@ -212,13 +331,15 @@ func (ctrl *CategoryController) GetBooksByCategory(c *gin.Context) {
 		return
 	}
 
-	books, err := ctrl.categoryService.GetBooksByCategory(id)
+	includeDescendants, err := strconv.ParseBool(c.DefaultQuery("include_descendants", "false"))
 	if err != nil {
-		if err.Error() == "category not found" {
-			utils.NotFound(c, "Category not found")
-			return
-		}
-		utils.InternalServerError(c, err.Error(), nil)
+		utils.BadRequest(c, "Invalid include_descendants value", err.Error())
+		return
+	}
+
+	books, err := ctrl.categoryService.GetBooksByCategory(id, includeDescendants, c.GetString("locale"))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
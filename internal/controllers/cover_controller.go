@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"book-management/internal/i18n"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CoverController struct {
+	coverService *services.CoverService
+}
+
+func NewCoverController(coverService *services.CoverService) *CoverController {
+	return &CoverController{
+		coverService: coverService,
+	}
+}
+
+// GetCover godoc
+// @Summary Get book cover
+// @Description Get the stored cover image of a book and all of its thumbnails
+// @Tags covers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=models.BookCover}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/cover [get]
+func (ctrl *CoverController) GetCover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	cover, err := ctrl.coverService.GetCover(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Cover retrieved successfully", cover)
+}
+
+// UploadCover godoc
+// @Summary Upload book cover
+// @Description Upload a JPEG, PNG or GIF cover image. The image type is detected from the file content, thumbnails are generated automatically and image_url of the book is set to the stored original.
+// @Tags covers
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param cover formData file true "Cover image"
+// @Success 201 {object} utils.Response{data=models.BookCover}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 413 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/cover [post]
+func (ctrl *CoverController) UploadCover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	// Leave some room for the multipart envelope around the file itself
+	maxSize := ctrl.coverService.MaxSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("cover")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.PayloadTooLarge(c, i18n.T(c.GetString("locale"), "Cover image must not exceed %d MB", maxSize>>20))
+			return
+		}
+		utils.BadRequest(c, "Cover image file is required", err.Error())
+		return
+	}
+
+	if fileHeader.Size > maxSize {
+		utils.PayloadTooLarge(c, i18n.T(c.GetString("locale"), "Cover image must not exceed %d MB", maxSize>>20))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.BadRequest(c, "Failed to read cover image", err.Error())
+		return
+	}
+	defer file.Close()
+
+	username := c.GetString("username")
+	cover, err := ctrl.coverService.UploadCover(id, file, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Cover uploaded successfully", cover)
+}
+
+// DeleteCover godoc
+// @Summary Delete book cover
+// @Description Delete the stored cover image and thumbnails of a book and clear its image_url
+// @Tags covers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/cover [delete]
+func (ctrl *CoverController) DeleteCover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	err = ctrl.coverService.DeleteCover(id, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Cover deleted successfully", nil)
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"net/http"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxExchangeRateFileSize membatasi ukuran file CSV impor kurs
+const maxExchangeRateFileSize = 1 << 20
+
+type ExchangeRateController struct {
+	rateService *services.ExchangeRateService
+}
+
+func NewExchangeRateController(rateService *services.ExchangeRateService) *ExchangeRateController {
+	return &ExchangeRateController{
+		rateService: rateService,
+	}
+}
+
+// GetExchangeRates godoc
+// @Summary Get exchange rates
+// @Description Get all stored exchange rates. Each rate means 1 base_currency = rate quote_currency.
+// @Tags exchange-rates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.ExchangeRate}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/exchange-rates [get]
+func (ctrl *ExchangeRateController) GetExchangeRates(c *gin.Context) {
+	rates, err := ctrl.rateService.GetRates()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Exchange rates retrieved successfully", rates)
+}
+
+// GetExchangeRate godoc
+// @Summary Get exchange rate
+// @Description Get the stored exchange rate for a currency pair
+// @Tags exchange-rates
+// @Produce json
+// @Security BearerAuth
+// @Param base path string true "Base currency (ISO 4217)"
+// @Param quote path string true "Quote currency (ISO 4217)"
+// @Success 200 {object} utils.Response{data=models.ExchangeRate}
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/exchange-rates/{base}/{quote} [get]
+func (ctrl *ExchangeRateController) GetExchangeRate(c *gin.Context) {
+	rate, err := ctrl.rateService.GetRate(c.Param("base"), c.Param("quote"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Exchange rate retrieved successfully", rate)
+}
+
+// SetExchangeRate godoc
+// @Summary Set exchange rate
+// @Description Create or replace the exchange rate 1 base = rate quote. The rate may be sent as a number or a decimal string.
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param base path string true "Base currency (ISO 4217)"
+// @Param quote path string true "Quote currency (ISO 4217)"
+// @Param request body models.SetExchangeRateRequest true "Exchange rate"
+// @Success 200 {object} utils.Response{data=models.ExchangeRate}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/exchange-rates/{base}/{quote} [put]
+func (ctrl *ExchangeRateController) SetExchangeRate(c *gin.Context) {
+	var req models.SetExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	rate, err := ctrl.rateService.SetRate(c.Param("base"), c.Param("quote"), &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Exchange rate saved successfully", rate)
+}
+
+// ImportExchangeRates godoc
+// @Summary Import exchange rates
+// @Description Import exchange rates from a CSV file with the columns base_currency,quote_currency,rate (header row optional). All rows are saved in one transaction; any invalid row rejects the whole file.
+// @Tags exchange-rates
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file"
+// @Success 200 {object} utils.Response{data=models.ExchangeRateImportResult}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/exchange-rates/import [post]
+func (ctrl *ExchangeRateController) ImportExchangeRates(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxExchangeRateFileSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequest(c, "File is required", err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.BadRequest(c, "Failed to read file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	username := c.GetString("username")
+	result, err := ctrl.rateService.ImportCSV(file, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Exchange rates imported successfully", result)
+}
+
+// DeleteExchangeRate godoc
+// @Summary Delete exchange rate
+// @Description Delete the exchange rate of a currency pair
+// @Tags exchange-rates
+// @Produce json
+// @Security BearerAuth
+// @Param base path string true "Base currency (ISO 4217)"
+// @Param quote path string true "Quote currency (ISO 4217)"
+// @Success 200 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/exchange-rates/{base}/{quote} [delete]
+func (ctrl *ExchangeRateController) DeleteExchangeRate(c *gin.Context) {
+	err := ctrl.rateService.DeleteRate(c.Param("base"), c.Param("quote"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Exchange rate deleted successfully", nil)
+}
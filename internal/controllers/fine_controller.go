@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FineController struct {
+	fineService *services.FineService
+}
+
+func NewFineController(fineService *services.FineService) *FineController {
+	return &FineController{
+		fineService: fineService,
+	}
+}
+
+// GetPatronLedger godoc
+// @Summary Get patron ledger
+// @Description Get the fines, payments and waivers of a patron, newest first
+// @Tags fines
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Success 200 {object} utils.Response{data=[]models.LedgerEntry}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id}/ledger [get]
+func (ctrl *FineController) GetPatronLedger(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid patron ID", err.Error())
+		return
+	}
+
+	entries, err := ctrl.fineService.GetLedger(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Ledger retrieved successfully", entries)
+}
+
+// GetPatronBalance godoc
+// @Summary Get patron balance
+// @Description Get the total charges, payments, waivers and outstanding balance of a patron
+// @Tags fines
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Success 200 {object} utils.Response{data=models.PatronBalance}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id}/balance [get]
+func (ctrl *FineController) GetPatronBalance(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid patron ID", err.Error())
+		return
+	}
+
+	balance, err := ctrl.fineService.GetBalance(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Balance retrieved successfully", balance)
+}
+
+// RecordPayment godoc
+// @Summary Record payment
+// @Description Record a fine payment; the amount cannot exceed the outstanding balance
+// @Tags fines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Param request body models.LedgerEntryRequest true "Payment data"
+// @Success 201 {object} utils.Response{data=models.LedgerEntry}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id}/payments [post]
+func (ctrl *FineController) RecordPayment(c *gin.Context) {
+	ctrl.recordEntry(c, "Payment recorded successfully", ctrl.fineService.RecordPayment)
+}
+
+// RecordWaiver godoc
+// @Summary Record waiver
+// @Description Waive part or all of the outstanding fines of a patron
+// @Tags fines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Param request body models.LedgerEntryRequest true "Waiver data"
+// @Success 201 {object} utils.Response{data=models.LedgerEntry}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id}/waivers [post]
+func (ctrl *FineController) RecordWaiver(c *gin.Context) {
+	ctrl.recordEntry(c, "Waiver recorded successfully", ctrl.fineService.RecordWaiver)
+}
+
+func (ctrl *FineController) recordEntry(c *gin.Context, message string, record func(int, *models.LedgerEntryRequest, string) (*models.LedgerEntry, error)) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid patron ID", err.Error())
+		return
+	}
+
+	var req models.LedgerEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	entry, err := record(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, message, entry)
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HoldController struct {
+	circulationService *services.CirculationService
+}
+
+func NewHoldController(circulationService *services.CirculationService) *HoldController {
+	return &HoldController{
+		circulationService: circulationService,
+	}
+}
+
+// GetBookHolds godoc
+// @Summary Get book holds
+// @Description Get the hold queue of a book: ready holds first, then waiting holds in FIFO order with their position
+// @Tags holds
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param include_closed query bool false "Include fulfilled, cancelled and expired holds"
+// @Success 200 {object} utils.Response{data=[]models.Hold}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/holds [get]
+func (ctrl *HoldController) GetBookHolds(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	holds, err := ctrl.circulationService.GetBookHolds(id, c.Query("include_closed") == "true")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Holds retrieved successfully", holds)
+}
+
+// PlaceHold godoc
+// @Summary Place hold
+// @Description Queue a patron for a book; an available copy is set aside right away
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param request body models.PlaceHoldRequest true "Hold data"
+// @Success 201 {object} utils.Response{data=models.Hold}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/holds [post]
+func (ctrl *HoldController) PlaceHold(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.PlaceHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	hold, err := ctrl.circulationService.PlaceHold(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Hold placed successfully", hold)
+}
+
+// GetHolds godoc
+// @Summary Get holds
+// @Description Get holds, optionally filtered by book, patron or status
+// @Tags holds
+// @Produce json
+// @Security BearerAuth
+// @Param book_id query int false "Book ID"
+// @Param patron_id query int false "Patron ID"
+// @Param status query string false "Hold status (open, waiting, ready, fulfilled, cancelled, expired)"
+// @Success 200 {object} utils.Response{data=[]models.Hold}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/holds [get]
+func (ctrl *HoldController) GetHolds(c *gin.Context) {
+	filter := models.HoldFilter{Status: c.Query("status")}
+
+	if value := c.Query("book_id"); value != "" {
+		bookID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid book ID", err.Error())
+			return
+		}
+		filter.BookID = bookID
+	}
+
+	if value := c.Query("patron_id"); value != "" {
+		patronID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid patron ID", err.Error())
+			return
+		}
+		filter.PatronID = patronID
+	}
+
+	holds, err := ctrl.circulationService.GetHolds(filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Holds retrieved successfully", holds)
+}
+
+// GetHoldByID godoc
+// @Summary Get hold by ID
+// @Description Get a specific hold by its ID
+// @Tags holds
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Hold ID"
+// @Success 200 {object} utils.Response{data=models.Hold}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/holds/{id} [get]
+func (ctrl *HoldController) GetHoldByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid hold ID", err.Error())
+		return
+	}
+
+	hold, err := ctrl.circulationService.GetHoldByID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Hold retrieved successfully", hold)
+}
+
+// CancelHold godoc
+// @Summary Cancel hold
+// @Description Cancel an open hold; a copy set aside for it goes to the next patron in the queue
+// @Tags holds
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Hold ID"
+// @Success 200 {object} utils.Response{data=models.Hold}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/holds/{id}/cancel [post]
+func (ctrl *HoldController) CancelHold(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid hold ID", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	hold, err := ctrl.circulationService.CancelHold(id, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Hold cancelled successfully", hold)
+}
@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ItemController struct {
+	itemService *services.ItemService
+}
+
+func NewItemController(itemService *services.ItemService) *ItemController {
+	return &ItemController{
+		itemService: itemService,
+	}
+}
+
+// GetItems godoc
+// @Summary Get items
+// @Description Get physical copies, optionally filtered by book, status or shelf location
+// @Tags items
+// @Produce json
+// @Security BearerAuth
+// @Param book_id query int false "Book ID"
+// @Param status query string false "Item status (available, on_loan, lost, damaged)"
+// @Param shelf_location query string false "Shelf location"
+// @Success 200 {object} utils.Response{data=[]models.Item}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/items [get]
+func (ctrl *ItemController) GetItems(c *gin.Context) {
+	filter := models.ItemFilter{
+		Status:        c.Query("status"),
+		ShelfLocation: c.Query("shelf_location"),
+	}
+
+	if value := c.Query("book_id"); value != "" {
+		bookID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid book ID", err.Error())
+			return
+		}
+		filter.BookID = bookID
+	}
+
+	items, err := ctrl.itemService.GetItems(filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Items retrieved successfully", items)
+}
+
+// GetBookItems godoc
+// @Summary Get book items
+// @Description Get every physical copy of a book
+// @Tags items
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=[]models.Item}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/items [get]
+func (ctrl *ItemController) GetBookItems(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	items, err := ctrl.itemService.GetBookItems(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Items retrieved successfully", items)
+}
+
+// GetItemByID godoc
+// @Summary Get item by ID
+// @Description Get a specific physical copy by its ID
+// @Tags items
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Item ID"
+// @Success 200 {object} utils.Response{data=models.Item}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/items/{id} [get]
+func (ctrl *ItemController) GetItemByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid item ID", err.Error())
+		return
+	}
+
+	item, err := ctrl.itemService.GetItemByID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Item retrieved successfully", item)
+}
+
+// GetItemByBarcode godoc
+// @Summary Get item by barcode
+// @Description Look up a physical copy by its barcode
+// @Tags items
+// @Produce json
+// @Security BearerAuth
+// @Param barcode path string true "Barcode"
+// @Success 200 {object} utils.Response{data=models.Item}
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/items/by-barcode/{barcode} [get]
+func (ctrl *ItemController) GetItemByBarcode(c *gin.Context) {
+	item, err := ctrl.itemService.GetItemByBarcode(c.Param("barcode"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Item retrieved successfully", item)
+}
+
+// CreateItem godoc
+// @Summary Create item
+// @Description Register a new physical copy of a book
+// @Tags items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param request body models.CreateItemRequest true "Item data"
+// @Success 201 {object} utils.Response{data=models.Item}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/items [post]
+func (ctrl *ItemController) CreateItem(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.CreateItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	item, err := ctrl.itemService.CreateItem(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Item created successfully", item)
+}
+
+// UpdateItem godoc
+// @Summary Update item
+// @Description Update the barcode, condition, location or status of a physical copy
+// @Tags items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Item ID"
+// @Param request body models.UpdateItemRequest true "Item data"
+// @Success 200 {object} utils.Response{data=models.Item}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/items/{id} [put]
+func (ctrl *ItemController) UpdateItem(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid item ID", err.Error())
+		return
+	}
+
+	var req models.UpdateItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	item, err := ctrl.itemService.UpdateItem(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Item updated successfully", item)
+}
+
+// DeleteItem godoc
+// @Summary Delete item
+// @Description Delete a physical copy
+// @Tags items
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Item ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/items/{id} [delete]
+func (ctrl *ItemController) DeleteItem(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid item ID", err.Error())
+		return
+	}
+
+	err = ctrl.itemService.DeleteItem(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Item deleted successfully", nil)
+}
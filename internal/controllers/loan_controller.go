@@ -0,0 +1,309 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoanController struct {
+	circulationService *services.CirculationService
+}
+
+func NewLoanController(circulationService *services.CirculationService) *LoanController {
+	return &LoanController{
+		circulationService: circulationService,
+	}
+}
+
+// GetLoans godoc
+// @Summary Get loans
+// @Description Get loans, optionally filtered by patron, item or status
+// @Tags loans
+// @Produce json
+// @Security BearerAuth
+// @Param patron_id query int false "Patron ID"
+// @Param item_id query int false "Item ID"
+// @Param status query string false "Loan status (active, overdue, returned)"
+// @Success 200 {object} utils.Response{data=[]models.Loan}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/loans [get]
+func (ctrl *LoanController) GetLoans(c *gin.Context) {
+	filter := models.LoanFilter{Status: c.Query("status")}
+
+	if value := c.Query("patron_id"); value != "" {
+		patronID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid patron ID", err.Error())
+			return
+		}
+		filter.PatronID = patronID
+	}
+
+	if value := c.Query("item_id"); value != "" {
+		itemID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid item ID", err.Error())
+			return
+		}
+		filter.ItemID = itemID
+	}
+
+	loans, err := ctrl.circulationService.GetLoans(filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loans retrieved successfully", loans)
+}
+
+// GetLoanByID godoc
+// @Summary Get loan by ID
+// @Description Get a specific loan by its ID
+// @Tags loans
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Loan ID"
+// @Success 200 {object} utils.Response{data=models.Loan}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/loans/{id} [get]
+func (ctrl *LoanController) GetLoanByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid loan ID", err.Error())
+		return
+	}
+
+	loan, err := ctrl.circulationService.GetLoanByID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loan retrieved successfully", loan)
+}
+
+// Checkout godoc
+// @Summary Checkout item
+// @Description Lend a physical copy (by item_id or barcode) to a patron; the due date follows the loan policy of the book's category
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CheckoutRequest true "Checkout data"
+// @Success 201 {object} utils.Response{data=models.Loan}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/loans [post]
+func (ctrl *LoanController) Checkout(c *gin.Context) {
+	var req models.CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	loan, err := ctrl.circulationService.Checkout(&req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Item checked out successfully", loan)
+}
+
+// ReturnLoan godoc
+// @Summary Return loan
+// @Description Close a loan and make its copy available again
+// @Tags loans
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Loan ID"
+// @Success 200 {object} utils.Response{data=models.Loan}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/loans/{id}/return [post]
+func (ctrl *LoanController) ReturnLoan(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid loan ID", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	loan, err := ctrl.circulationService.ReturnLoan(id, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loan returned successfully", loan)
+}
+
+// RenewLoan godoc
+// @Summary Renew loan
+// @Description Extend an open, not yet overdue loan by its loan period, up to its renewal limit and only while no other patron is waiting for the book
+// @Tags loans
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Loan ID"
+// @Success 200 {object} utils.Response{data=models.Loan}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/loans/{id}/renew [post]
+func (ctrl *LoanController) RenewLoan(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid loan ID", err.Error())
+		return
+	}
+
+	loan, err := ctrl.circulationService.RenewLoan(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loan renewed successfully", loan)
+}
+
+// GetLoanPolicies godoc
+// @Summary Get loan policies
+// @Description Get the loan policies defined directly on categories
+// @Tags loans
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.LoanPolicy}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/loan-policies [get]
+func (ctrl *LoanController) GetLoanPolicies(c *gin.Context) {
+	policies, err := ctrl.circulationService.GetLoanPolicies()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loan policies retrieved successfully", policies)
+}
+
+// GetCategoryLoanPolicy godoc
+// @Summary Get category loan policy
+// @Description Get the loan policy that applies to a category: its own, inherited from the nearest parent, or the configured default
+// @Tags loans
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} utils.Response{data=models.LoanPolicy}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/{id}/loan-policy [get]
+func (ctrl *LoanController) GetCategoryLoanPolicy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid category ID", err.Error())
+		return
+	}
+
+	policy, err := ctrl.circulationService.GetLoanPolicy(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loan policy retrieved successfully", policy)
+}
+
+// SetCategoryLoanPolicy godoc
+// @Summary Set category loan policy
+// @Description Set the loan period, renewal limit and overdue fine rules of a category and its subcategories without their own policy
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param request body models.SetLoanPolicyRequest true "Loan policy"
+// @Success 200 {object} utils.Response{data=models.LoanPolicy}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/{id}/loan-policy [put]
+func (ctrl *LoanController) SetCategoryLoanPolicy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid category ID", err.Error())
+		return
+	}
+
+	var req models.SetLoanPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	policy, err := ctrl.circulationService.SetLoanPolicy(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loan policy saved successfully", policy)
+}
+
+// DeleteCategoryLoanPolicy godoc
+// @Summary Delete category loan policy
+// @Description Remove the policy of a category so it inherits again
+// @Tags loans
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/{id}/loan-policy [delete]
+func (ctrl *LoanController) DeleteCategoryLoanPolicy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid category ID", err.Error())
+		return
+	}
+
+	err = ctrl.circulationService.DeleteLoanPolicy(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loan policy deleted successfully", nil)
+}
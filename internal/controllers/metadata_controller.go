@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"book-management/internal/i18n"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MetadataController struct {
+	metadataService *services.MetadataService
+}
+
+func NewMetadataController(metadataService *services.MetadataService) *MetadataController {
+	return &MetadataController{
+		metadataService: metadataService,
+	}
+}
+
+// ExtractMetadata godoc
+// @Summary Extract metadata from file
+// @Description Read title, description, publication year, page count and embedded cover from an EPUB (OPF) or PDF (Info) file without storing it, and return a prefilled create-book draft
+// @Tags metadata
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "EPUB or PDF file"
+// @Success 200 {object} utils.Response{data=models.BookMetadataDraft}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 413 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/metadata/extract [post]
+func (ctrl *MetadataController) ExtractMetadata(c *gin.Context) {
+	maxSize := ctrl.metadataService.MaxSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.PayloadTooLarge(c, i18n.T(c.GetString("locale"), "File must not exceed %d MB", maxSize>>20))
+			return
+		}
+		utils.BadRequest(c, "File is required", err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.BadRequest(c, "Failed to read file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	draft, err := ctrl.metadataService.ExtractFromUpload(file, fileHeader.Size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Metadata extracted successfully", draft)
+}
+
+// GetFileMetadata godoc
+// @Summary Get book file metadata
+// @Description Read the metadata of a file attached to a book and return the book data as it would look after applying it
+// @Tags metadata
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param fileId path int true "File ID"
+// @Success 200 {object} utils.Response{data=models.BookMetadataDraft}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/files/{fileId}/metadata [get]
+func (ctrl *MetadataController) GetFileMetadata(c *gin.Context) {
+	bookID, fileID, ok := parseBookFileIDs(c)
+	if !ok {
+		return
+	}
+
+	draft, err := ctrl.metadataService.ExtractFromFile(bookID, fileID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Metadata extracted successfully", draft)
+}
+
+// ApplyFileMetadata godoc
+// @Summary Apply book file metadata
+// @Description Update the book with the title, description, publication year and page count read from an attached file. The embedded cover is stored only when the book has no cover yet, unless replace_cover=true.
+// @Tags metadata
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param fileId path int true "File ID"
+// @Param replace_cover query bool false "Replace an existing cover with the embedded one"
+// @Success 200 {object} utils.Response{data=models.BookMetadataResult}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/files/{fileId}/metadata/apply [post]
+func (ctrl *MetadataController) ApplyFileMetadata(c *gin.Context) {
+	bookID, fileID, ok := parseBookFileIDs(c)
+	if !ok {
+		return
+	}
+
+	replaceCover := c.Query("replace_cover") == "true"
+	username := c.GetString("username")
+
+	result, err := ctrl.metadataService.ApplyFromFile(bookID, fileID, replaceCover, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Metadata applied successfully", result)
+}
@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PatronController struct {
+	patronService      *services.PatronService
+	circulationService *services.CirculationService
+}
+
+func NewPatronController(patronService *services.PatronService, circulationService *services.CirculationService) *PatronController {
+	return &PatronController{
+		patronService:      patronService,
+		circulationService: circulationService,
+	}
+}
+
+// GetPatrons godoc
+// @Summary Get patrons
+// @Description Get all library patrons, optionally searched by name or card number
+// @Tags patrons
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Name or card number"
+// @Success 200 {object} utils.Response{data=[]models.Patron}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons [get]
+func (ctrl *PatronController) GetPatrons(c *gin.Context) {
+	patrons, err := ctrl.patronService.GetPatrons(c.Query("search"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Patrons retrieved successfully", patrons)
+}
+
+// GetPatronByID godoc
+// @Summary Get patron by ID
+// @Description Get a specific patron by its ID
+// @Tags patrons
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Success 200 {object} utils.Response{data=models.Patron}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id} [get]
+func (ctrl *PatronController) GetPatronByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid patron ID", err.Error())
+		return
+	}
+
+	patron, err := ctrl.patronService.GetPatronByID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Patron retrieved successfully", patron)
+}
+
+// GetPatronLoans godoc
+// @Summary Get patron loans
+// @Description Get the loans of a patron, newest first
+// @Tags patrons
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Param status query string false "Loan status (active, overdue, returned)"
+// @Success 200 {object} utils.Response{data=[]models.Loan}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id}/loans [get]
+func (ctrl *PatronController) GetPatronLoans(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid patron ID", err.Error())
+		return
+	}
+
+	loans, err := ctrl.circulationService.GetPatronLoans(id, c.Query("status"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Loans retrieved successfully", loans)
+}
+
+// GetPatronHolds godoc
+// @Summary Get patron holds
+// @Description Get the holds of a patron
+// @Tags patrons
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Param status query string false "Hold status (open, waiting, ready, fulfilled, cancelled, expired)"
+// @Success 200 {object} utils.Response{data=[]models.Hold}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id}/holds [get]
+func (ctrl *PatronController) GetPatronHolds(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid patron ID", err.Error())
+		return
+	}
+
+	holds, err := ctrl.circulationService.GetPatronHolds(id, c.Query("status"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Holds retrieved successfully", holds)
+}
+
+// CreatePatron godoc
+// @Summary Create patron
+// @Description Register a new library patron
+// @Tags patrons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.PatronRequest true "Patron data"
+// @Success 201 {object} utils.Response{data=models.Patron}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons [post]
+func (ctrl *PatronController) CreatePatron(c *gin.Context) {
+	var req models.PatronRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	patron, err := ctrl.patronService.CreatePatron(&req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Patron created successfully", patron)
+}
+
+// UpdatePatron godoc
+// @Summary Update patron
+// @Description Update a patron; an empty status keeps the current one
+// @Tags patrons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Param request body models.PatronRequest true "Patron data"
+// @Success 200 {object} utils.Response{data=models.Patron}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id} [put]
+func (ctrl *PatronController) UpdatePatron(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid patron ID", err.Error())
+		return
+	}
+
+	var req models.PatronRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	patron, err := ctrl.patronService.UpdatePatron(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Patron updated successfully", patron)
+}
+
+// DeletePatron godoc
+// @Summary Delete patron
+// @Description Delete a patron without loan history
+// @Tags patrons
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Patron ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/patrons/{id} [delete]
+func (ctrl *PatronController) DeletePatron(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid patron ID", err.Error())
+		return
+	}
+
+	err = ctrl.patronService.DeletePatron(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Patron deleted successfully", nil)
+}
@@ -0,0 +1,329 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PricingController struct {
+	pricingService *services.PricingService
+}
+
+func NewPricingController(pricingService *services.PricingService) *PricingController {
+	return &PricingController{
+		pricingService: pricingService,
+	}
+}
+
+// GetPriceHistory godoc
+// @Summary Get price history
+// @Description Get every price change of a book, newest first
+// @Tags pricing
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=[]models.PriceHistory}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/price-history [get]
+func (ctrl *PricingController) GetPriceHistory(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	history, err := ctrl.pricingService.GetPriceHistory(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Price history retrieved successfully", history)
+}
+
+// GetScheduledPriceChanges godoc
+// @Summary Get scheduled price changes
+// @Description Get the pending, applied and cancelled scheduled price changes of a book
+// @Tags pricing
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=[]models.ScheduledPriceChange}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/scheduled-prices [get]
+func (ctrl *PricingController) GetScheduledPriceChanges(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	changes, err := ctrl.pricingService.GetScheduledPriceChanges(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Scheduled price changes retrieved successfully", changes)
+}
+
+// SchedulePriceChange godoc
+// @Summary Schedule price change
+// @Description Schedule a new price that a background job applies once effective_at is reached
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param request body models.CreateScheduledPriceChangeRequest true "Scheduled price"
+// @Success 201 {object} utils.Response{data=models.ScheduledPriceChange}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/scheduled-prices [post]
+func (ctrl *PricingController) SchedulePriceChange(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.CreateScheduledPriceChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	change, err := ctrl.pricingService.SchedulePriceChange(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Price change scheduled successfully", change)
+}
+
+// CancelScheduledPriceChange godoc
+// @Summary Cancel scheduled price change
+// @Description Cancel a scheduled price change that has not been applied yet
+// @Tags pricing
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param changeId path int true "Scheduled price change ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/scheduled-prices/{changeId} [delete]
+func (ctrl *PricingController) CancelScheduledPriceChange(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	changeID, err := strconv.Atoi(c.Param("changeId"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid scheduled price change ID", err.Error())
+		return
+	}
+
+	err = ctrl.pricingService.CancelScheduledPriceChange(bookID, changeID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Scheduled price change cancelled successfully", nil)
+}
+
+// GetDiscounts godoc
+// @Summary Get discounts
+// @Description Get discounts, optionally filtered by book, category or currently active ones
+// @Tags pricing
+// @Produce json
+// @Security BearerAuth
+// @Param book_id query int false "Book ID"
+// @Param category_id query int false "Category ID"
+// @Param active query bool false "Only discounts active right now"
+// @Success 200 {object} utils.Response{data=[]models.Discount}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/discounts [get]
+func (ctrl *PricingController) GetDiscounts(c *gin.Context) {
+	filter := models.DiscountFilter{ActiveOnly: c.Query("active") == "true"}
+
+	if value := c.Query("book_id"); value != "" {
+		bookID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid book ID", err.Error())
+			return
+		}
+		filter.BookID = bookID
+	}
+
+	if value := c.Query("category_id"); value != "" {
+		categoryID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid category ID", err.Error())
+			return
+		}
+		filter.CategoryID = categoryID
+	}
+
+	discounts, err := ctrl.pricingService.GetDiscounts(filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Discounts retrieved successfully", discounts)
+}
+
+// GetDiscountByID godoc
+// @Summary Get discount by ID
+// @Description Get a specific discount by its ID
+// @Tags pricing
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Discount ID"
+// @Success 200 {object} utils.Response{data=models.Discount}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/discounts/{id} [get]
+func (ctrl *PricingController) GetDiscountByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid discount ID", err.Error())
+		return
+	}
+
+	discount, err := ctrl.pricingService.GetDiscountByID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Discount retrieved successfully", discount)
+}
+
+// CreateDiscount godoc
+// @Summary Create discount
+// @Description Create a time-boxed percentage or fixed discount for a book or for every book in a category (including its subcategories)
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DiscountRequest true "Discount data"
+// @Success 201 {object} utils.Response{data=models.Discount}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/discounts [post]
+func (ctrl *PricingController) CreateDiscount(c *gin.Context) {
+	var req models.DiscountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	discount, err := ctrl.pricingService.CreateDiscount(&req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Discount created successfully", discount)
+}
+
+// UpdateDiscount godoc
+// @Summary Update discount
+// @Description Update an existing discount
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Discount ID"
+// @Param request body models.DiscountRequest true "Discount data"
+// @Success 200 {object} utils.Response{data=models.Discount}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/discounts/{id} [put]
+func (ctrl *PricingController) UpdateDiscount(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid discount ID", err.Error())
+		return
+	}
+
+	var req models.DiscountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	discount, err := ctrl.pricingService.UpdateDiscount(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Discount updated successfully", discount)
+}
+
+// DeleteDiscount godoc
+// @Summary Delete discount
+// @Description Delete a discount
+// @Tags pricing
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Discount ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/discounts/{id} [delete]
+func (ctrl *PricingController) DeleteDiscount(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid discount ID", err.Error())
+		return
+	}
+
+	err = ctrl.pricingService.DeleteDiscount(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Discount deleted successfully", nil)
+}
@@ -0,0 +1,495 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReadingController struct {
+	readingService *services.ReadingService
+}
+
+func NewReadingController(readingService *services.ReadingService) *ReadingController {
+	return &ReadingController{
+		readingService: readingService,
+	}
+}
+
+// GetReadingLists godoc
+// @Summary Get reading lists
+// @Description Get your shelves (want_to_read, reading, read) and custom lists, or the public lists of another user
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query int false "Owner user ID (defaults to the current user)"
+// @Success 200 {object} utils.Response{data=[]models.ReadingList}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists [get]
+func (ctrl *ReadingController) GetReadingLists(c *gin.Context) {
+	viewerID := c.GetInt("user_id")
+	userID := viewerID
+
+	if value := c.Query("user_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid user ID", err.Error())
+			return
+		}
+		userID = id
+	}
+
+	lists, err := ctrl.readingService.GetLists(userID, viewerID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading lists retrieved successfully", lists)
+}
+
+// CreateReadingList godoc
+// @Summary Create reading list
+// @Description Create a custom reading list
+// @Tags reading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ReadingListRequest true "Reading list data"
+// @Success 201 {object} utils.Response{data=models.ReadingList}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists [post]
+func (ctrl *ReadingController) CreateReadingList(c *gin.Context) {
+	var req models.ReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	list, err := ctrl.readingService.CreateList(c.GetInt("user_id"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Reading list created successfully", list)
+}
+
+// GetReadingList godoc
+// @Summary Get reading list by ID
+// @Description Get a reading list with its books in order; private lists are only visible to their owner
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Success 200 {object} utils.Response{data=models.ReadingList}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists/{id} [get]
+func (ctrl *ReadingController) GetReadingList(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid reading list ID", err.Error())
+		return
+	}
+
+	list, err := ctrl.readingService.GetList(id, c.GetInt("user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading list retrieved successfully", list)
+}
+
+// UpdateReadingList godoc
+// @Summary Update reading list
+// @Description Update the name, description and visibility of your reading list; shelves keep their name
+// @Tags reading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Param request body models.ReadingListRequest true "Reading list data"
+// @Success 200 {object} utils.Response{data=models.ReadingList}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists/{id} [put]
+func (ctrl *ReadingController) UpdateReadingList(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid reading list ID", err.Error())
+		return
+	}
+
+	var req models.ReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	list, err := ctrl.readingService.UpdateList(id, c.GetInt("user_id"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading list updated successfully", list)
+}
+
+// DeleteReadingList godoc
+// @Summary Delete reading list
+// @Description Delete one of your custom reading lists; shelves cannot be deleted
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists/{id} [delete]
+func (ctrl *ReadingController) DeleteReadingList(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid reading list ID", err.Error())
+		return
+	}
+
+	if err := ctrl.readingService.DeleteList(id, c.GetInt("user_id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading list deleted successfully", nil)
+}
+
+// AddReadingListBook godoc
+// @Summary Add book to reading list
+// @Description Append a book to your reading list. Putting a book on a shelf removes it from your other shelves.
+// @Tags reading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Param request body models.AddReadingListBookRequest true "Book data"
+// @Success 201 {object} utils.Response{data=models.ReadingList}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists/{id}/books [post]
+func (ctrl *ReadingController) AddReadingListBook(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid reading list ID", err.Error())
+		return
+	}
+
+	var req models.AddReadingListBookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	list, err := ctrl.readingService.AddBook(id, c.GetInt("user_id"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Book added to reading list successfully", list)
+}
+
+// RemoveReadingListBook godoc
+// @Summary Remove book from reading list
+// @Description Remove a book from your reading list
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Param bookId path int true "Book ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists/{id}/books/{bookId} [delete]
+func (ctrl *ReadingController) RemoveReadingListBook(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid reading list ID", err.Error())
+		return
+	}
+
+	bookID, err := strconv.Atoi(c.Param("bookId"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	if err := ctrl.readingService.RemoveBook(id, bookID, c.GetInt("user_id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book removed from reading list successfully", nil)
+}
+
+// ReorderReadingList godoc
+// @Summary Reorder reading list
+// @Description Set the order of the books in your reading list; book_ids must contain every book in the list once
+// @Tags reading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Param request body models.ReorderReadingListRequest true "New order"
+// @Success 200 {object} utils.Response{data=models.ReadingList}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists/{id}/books/order [put]
+func (ctrl *ReadingController) ReorderReadingList(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid reading list ID", err.Error())
+		return
+	}
+
+	var req models.ReorderReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	list, err := ctrl.readingService.ReorderBooks(id, c.GetInt("user_id"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading list reordered successfully", list)
+}
+
+// ShareReadingList godoc
+// @Summary Share reading list
+// @Description Create a share link token for your reading list; any previous link stops working
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Success 200 {object} utils.Response{data=models.ReadingList}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists/{id}/share [post]
+func (ctrl *ReadingController) ShareReadingList(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid reading list ID", err.Error())
+		return
+	}
+
+	list, err := ctrl.readingService.ShareList(id, c.GetInt("user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading list shared successfully", list)
+}
+
+// UnshareReadingList godoc
+// @Summary Unshare reading list
+// @Description Revoke the share link of your reading list
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-lists/{id}/share [delete]
+func (ctrl *ReadingController) UnshareReadingList(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid reading list ID", err.Error())
+		return
+	}
+
+	if err := ctrl.readingService.UnshareList(id, c.GetInt("user_id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading list share link revoked successfully", nil)
+}
+
+// GetSharedReadingList godoc
+// @Summary Get shared reading list
+// @Description Get a reading list through its share link token; no authentication required
+// @Tags reading
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} utils.Response{data=models.ReadingList}
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/shared/reading-lists/{token} [get]
+func (ctrl *ReadingController) GetSharedReadingList(c *gin.Context) {
+	list, err := ctrl.readingService.GetSharedList(c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading list retrieved successfully", list)
+}
+
+// GetReadingProgress godoc
+// @Summary Get reading progress
+// @Description Get your reading progress for all books, most recently updated first
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.ReadingProgress}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reading-progress [get]
+func (ctrl *ReadingController) GetReadingProgress(c *gin.Context) {
+	progress, err := ctrl.readingService.GetAllProgress(c.GetInt("user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading progress retrieved successfully", progress)
+}
+
+// GetBookProgress godoc
+// @Summary Get book reading progress
+// @Description Get your reading progress for a book
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=models.ReadingProgress}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/progress [get]
+func (ctrl *ReadingController) GetBookProgress(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	progress, err := ctrl.readingService.GetProgress(c.GetInt("user_id"), bookID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading progress retrieved successfully", progress)
+}
+
+// UpdateBookProgress godoc
+// @Summary Update book reading progress
+// @Description Record the current page of a book. started_at and finished_at default to today when reading starts and when the last page is reached.
+// @Tags reading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param request body models.ReadingProgressRequest true "Reading progress"
+// @Success 200 {object} utils.Response{data=models.ReadingProgress}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/progress [put]
+func (ctrl *ReadingController) UpdateBookProgress(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.ReadingProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	progress, err := ctrl.readingService.UpdateProgress(c.GetInt("user_id"), bookID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading progress updated successfully", progress)
+}
+
+// DeleteBookProgress godoc
+// @Summary Delete book reading progress
+// @Description Clear your reading progress for a book
+// @Tags reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/progress [delete]
+func (ctrl *ReadingController) DeleteBookProgress(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	if err := ctrl.readingService.DeleteProgress(c.GetInt("user_id"), bookID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reading progress deleted successfully", nil)
+}
@@ -0,0 +1,309 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewController struct {
+	reviewService *services.ReviewService
+}
+
+func NewReviewController(reviewService *services.ReviewService) *ReviewController {
+	return &ReviewController{
+		reviewService: reviewService,
+	}
+}
+
+// GetBookReviews godoc
+// @Summary Get book reviews
+// @Description Get the visible reviews of a book, newest first. Moderators can include hidden reviews.
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param include_hidden query bool false "Include hidden reviews (moderators only)"
+// @Success 200 {object} utils.Response{data=[]models.Review}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/reviews [get]
+func (ctrl *ReviewController) GetBookReviews(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	reviews, err := ctrl.reviewService.GetBookReviews(id, c.Query("include_hidden") == "true", username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reviews retrieved successfully", reviews)
+}
+
+// CreateReview godoc
+// @Summary Create review
+// @Description Rate (1-5) and review a book as the current user; each user can review a book once
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param request body models.ReviewRequest true "Review data"
+// @Success 201 {object} utils.Response{data=models.Review}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/reviews [post]
+func (ctrl *ReviewController) CreateReview(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	review, err := ctrl.reviewService.CreateReview(id, c.GetInt("user_id"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Review created successfully", review)
+}
+
+// GetReviews godoc
+// @Summary Get reviews
+// @Description Get reviews, optionally filtered by book, user or status. Only moderators can list hidden reviews.
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param book_id query int false "Book ID"
+// @Param user_id query int false "User ID"
+// @Param status query string false "Review status (visible, hidden)"
+// @Success 200 {object} utils.Response{data=[]models.Review}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reviews [get]
+func (ctrl *ReviewController) GetReviews(c *gin.Context) {
+	filter := models.ReviewFilter{Status: c.Query("status")}
+
+	if value := c.Query("book_id"); value != "" {
+		bookID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid book ID", err.Error())
+			return
+		}
+		filter.BookID = bookID
+	}
+
+	if value := c.Query("user_id"); value != "" {
+		userID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequest(c, "Invalid user ID", err.Error())
+			return
+		}
+		filter.UserID = userID
+	}
+
+	reviews, err := ctrl.reviewService.GetReviews(filter, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Reviews retrieved successfully", reviews)
+}
+
+// GetReview godoc
+// @Summary Get review by ID
+// @Description Get a review; hidden reviews are only visible to their author and moderators
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Success 200 {object} utils.Response{data=models.Review}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reviews/{id} [get]
+func (ctrl *ReviewController) GetReview(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid review ID", err.Error())
+		return
+	}
+
+	review, err := ctrl.reviewService.GetReviewByID(id, c.GetInt("user_id"), c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Review retrieved successfully", review)
+}
+
+// UpdateReview godoc
+// @Summary Update review
+// @Description Edit the rating and text of your own review
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Param request body models.ReviewRequest true "Review data"
+// @Success 200 {object} utils.Response{data=models.Review}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reviews/{id} [put]
+func (ctrl *ReviewController) UpdateReview(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid review ID", err.Error())
+		return
+	}
+
+	var req models.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	review, err := ctrl.reviewService.UpdateReview(id, c.GetInt("user_id"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Review updated successfully", review)
+}
+
+// DeleteReview godoc
+// @Summary Delete review
+// @Description Delete a review; allowed for its author and moderators
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reviews/{id} [delete]
+func (ctrl *ReviewController) DeleteReview(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid review ID", err.Error())
+		return
+	}
+
+	if err := ctrl.reviewService.DeleteReview(id, c.GetInt("user_id"), c.GetString("username")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Review deleted successfully", nil)
+}
+
+// HideReview godoc
+// @Summary Hide review
+// @Description Hide an abusive review from the public and from the book rating (moderators only)
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Param request body models.ModerateReviewRequest false "Moderation reason"
+// @Success 200 {object} utils.Response{data=models.Review}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reviews/{id}/hide [post]
+func (ctrl *ReviewController) HideReview(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid review ID", err.Error())
+		return
+	}
+
+	var req models.ModerateReviewRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, "Invalid request body", err.Error())
+			return
+		}
+	}
+
+	review, err := ctrl.reviewService.HideReview(id, &req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Review hidden successfully", review)
+}
+
+// UnhideReview godoc
+// @Summary Unhide review
+// @Description Make a hidden review visible again (moderators only)
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Success 200 {object} utils.Response{data=models.Review}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/reviews/{id}/unhide [post]
+func (ctrl *ReviewController) UnhideReview(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid review ID", err.Error())
+		return
+	}
+
+	review, err := ctrl.reviewService.UnhideReview(id, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Review unhidden successfully", review)
+}
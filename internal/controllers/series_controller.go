@@ -0,0 +1,321 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeriesController struct {
+	seriesService *services.SeriesService
+}
+
+func NewSeriesController(seriesService *services.SeriesService) *SeriesController {
+	return &SeriesController{
+		seriesService: seriesService,
+	}
+}
+
+// GetAllSeries godoc
+// @Summary Get series
+// @Description Get all book series, optionally searched by name
+// @Tags series
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Series name"
+// @Success 200 {object} utils.Response{data=[]models.Series}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/series [get]
+func (ctrl *SeriesController) GetAllSeries(c *gin.Context) {
+	list, err := ctrl.seriesService.GetAllSeries(c.Query("search"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Series retrieved successfully", list)
+}
+
+// GetSeriesByID godoc
+// @Summary Get series by ID
+// @Description Get a series together with its volumes ordered by volume number
+// @Tags series
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Success 200 {object} utils.Response{data=models.Series}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/series/{id} [get]
+func (ctrl *SeriesController) GetSeriesByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	series, err := ctrl.seriesService.GetSeries(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Series retrieved successfully", series)
+}
+
+// CreateSeries godoc
+// @Summary Create series
+// @Description Create a book series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.SeriesRequest true "Series data"
+// @Success 201 {object} utils.Response{data=models.Series}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/series [post]
+func (ctrl *SeriesController) CreateSeries(c *gin.Context) {
+	var req models.SeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	series, err := ctrl.seriesService.CreateSeries(&req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Series created successfully", series)
+}
+
+// UpdateSeries godoc
+// @Summary Update series
+// @Description Update the name and description of a series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param request body models.SeriesRequest true "Series data"
+// @Success 200 {object} utils.Response{data=models.Series}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/series/{id} [put]
+func (ctrl *SeriesController) UpdateSeries(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	var req models.SeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	series, err := ctrl.seriesService.UpdateSeries(id, &req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Series updated successfully", series)
+}
+
+// DeleteSeries godoc
+// @Summary Delete series
+// @Description Delete a series; its books are kept
+// @Tags series
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/series/{id} [delete]
+func (ctrl *SeriesController) DeleteSeries(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	if err := ctrl.seriesService.DeleteSeries(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Series deleted successfully", nil)
+}
+
+// AddSeriesBook godoc
+// @Summary Add book to series
+// @Description Add a book to the series as the given volume number; fractional volumes such as 2.5 are allowed
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param request body models.SeriesBookRequest true "Book and volume number"
+// @Success 200 {object} utils.Response{data=models.Series}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/series/{id}/books [post]
+func (ctrl *SeriesController) AddSeriesBook(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	var req models.SeriesBookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	series, err := ctrl.seriesService.AddBook(id, &req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book added to series successfully", series)
+}
+
+// UpdateSeriesVolume godoc
+// @Summary Update volume number
+// @Description Change the volume number of a book in the series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param bookId path int true "Book ID"
+// @Param request body models.SeriesVolumeRequest true "Volume number"
+// @Success 200 {object} utils.Response{data=models.Series}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/series/{id}/books/{bookId} [put]
+func (ctrl *SeriesController) UpdateSeriesVolume(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	bookID, err := strconv.Atoi(c.Param("bookId"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.SeriesVolumeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	series, err := ctrl.seriesService.UpdateVolume(id, bookID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Volume number updated successfully", series)
+}
+
+// RemoveSeriesBook godoc
+// @Summary Remove book from series
+// @Description Remove a book from the series
+// @Tags series
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param bookId path int true "Book ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/series/{id}/books/{bookId} [delete]
+func (ctrl *SeriesController) RemoveSeriesBook(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	bookID, err := strconv.Atoi(c.Param("bookId"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	if err := ctrl.seriesService.RemoveBook(id, bookID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book removed from series successfully", nil)
+}
+
+// GetBookSeries godoc
+// @Summary Get series of a book
+// @Description Get the series the book belongs to with the previous and next volumes
+// @Tags series
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=[]models.BookSeriesEntry}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/series [get]
+func (ctrl *SeriesController) GetBookSeries(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	entries, err := ctrl.seriesService.GetBookSeries(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book series retrieved successfully", entries)
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SimilarityController struct {
+	similarityService *services.SimilarityService
+}
+
+func NewSimilarityController(similarityService *services.SimilarityService) *SimilarityController {
+	return &SimilarityController{
+		similarityService: similarityService,
+	}
+}
+
+// GetSimilarBooks godoc
+// @Summary Get similar books
+// @Description Get related titles ranked by category overlap, title/description similarity, release year proximity and shared readers. Results are precomputed by a background job, so new books appear after its next run.
+// @Tags books
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param limit query int false "Maximum number of books (capped at SIMILAR_BOOKS_PER_BOOK)" default(10)
+// @Success 200 {object} utils.Response{data=[]models.SimilarBook}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/similar [get]
+func (ctrl *SimilarityController) GetSimilarBooks(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		utils.BadRequest(c, "Invalid limit", nil)
+		return
+	}
+
+	books, err := ctrl.similarityService.GetSimilarBooks(id, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Similar books retrieved successfully", books)
+}
@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagController struct {
+	tagService *services.TagService
+}
+
+func NewTagController(tagService *services.TagService) *TagController {
+	return &TagController{
+		tagService: tagService,
+	}
+}
+
+// GetAllTags godoc
+// @Summary Get all tags
+// @Description Get a list of all tags with the number of books using them
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.Tag}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/tags [get]
+func (ctrl *TagController) GetAllTags(c *gin.Context) {
+	tags, err := ctrl.tagService.GetAllTags()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Tags retrieved successfully", tags)
+}
+
+// GetTagCloud godoc
+// @Summary Get tag cloud
+// @Description Get the most used tags with a relative weight from 1 to 5
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum number of tags" default(50)
+// @Success 200 {object} utils.Response{data=[]models.TagCloudEntry}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/tags/cloud [get]
+func (ctrl *TagController) GetTagCloud(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		utils.BadRequest(c, "Invalid limit", nil)
+		return
+	}
+
+	entries, err := ctrl.tagService.GetTagCloud(limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Tag cloud retrieved successfully", entries)
+}
+
+// GetTagByID godoc
+// @Summary Get tag by ID
+// @Description Get a specific tag by its ID
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Success 200 {object} utils.Response{data=models.Tag}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/tags/{id} [get]
+func (ctrl *TagController) GetTagByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid tag ID", err.Error())
+		return
+	}
+
+	tag, err := ctrl.tagService.GetTagByID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Tag retrieved successfully", tag)
+}
+
+// CreateTag godoc
+// @Summary Create new tag
+// @Description Create a new tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateTagRequest true "Tag data"
+// @Success 201 {object} utils.Response{data=models.Tag}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/tags [post]
+func (ctrl *TagController) CreateTag(c *gin.Context) {
+	var req models.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	tag, err := ctrl.tagService.CreateTag(&req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Tag created successfully", tag)
+}
+
+// UpdateTag godoc
+// @Summary Update tag
+// @Description Rename an existing tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Param request body models.UpdateTagRequest true "Tag data"
+// @Success 200 {object} utils.Response{data=models.Tag}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/tags/{id} [put]
+func (ctrl *TagController) UpdateTag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid tag ID", err.Error())
+		return
+	}
+
+	var req models.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	username := c.GetString("username")
+	tag, err := ctrl.tagService.UpdateTag(id, &req, username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Tag updated successfully", tag)
+}
+
+// DeleteTag godoc
+// @Summary Delete tag
+// @Description Delete a tag and remove it from all books
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/tags/{id} [delete]
+func (ctrl *TagController) DeleteTag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid tag ID", err.Error())
+		return
+	}
+
+	err = ctrl.tagService.DeleteTag(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Tag deleted successfully", nil)
+}
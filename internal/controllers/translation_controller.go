@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TranslationController struct {
+	translationService *services.TranslationService
+}
+
+func NewTranslationController(translationService *services.TranslationService) *TranslationController {
+	return &TranslationController{
+		translationService: translationService,
+	}
+}
+
+// GetBookTranslations godoc
+// @Summary Get book translations
+// @Description Get every translation of a book's title and description
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=[]models.BookTranslation}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/translations [get]
+func (ctrl *TranslationController) GetBookTranslations(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	translations, err := ctrl.translationService.GetBookTranslations(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book translations retrieved successfully", translations)
+}
+
+// SaveBookTranslation godoc
+// @Summary Save book translation
+// @Description Create or replace the title and description of a book in a supported locale other than the default; an empty description falls back to the default locale
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param locale path string true "Locale, e.g. en"
+// @Param request body models.BookTranslationRequest true "Translation data"
+// @Success 200 {object} utils.Response{data=models.BookTranslation}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/translations/{locale} [put]
+func (ctrl *TranslationController) SaveBookTranslation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.BookTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	translation, err := ctrl.translationService.SaveBookTranslation(id, c.Param("locale"), &req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book translation saved successfully", translation)
+}
+
+// DeleteBookTranslation godoc
+// @Summary Delete book translation
+// @Description Delete the translation of a book in one locale
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param locale path string true "Locale, e.g. en"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/translations/{locale} [delete]
+func (ctrl *TranslationController) DeleteBookTranslation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	if err := ctrl.translationService.DeleteBookTranslation(id, c.Param("locale")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book translation deleted successfully", nil)
+}
+
+// GetCategoryTranslations godoc
+// @Summary Get category translations
+// @Description Get every translation of a category's name
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} utils.Response{data=[]models.CategoryTranslation}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/{id}/translations [get]
+func (ctrl *TranslationController) GetCategoryTranslations(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid category ID", err.Error())
+		return
+	}
+
+	translations, err := ctrl.translationService.GetCategoryTranslations(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Category translations retrieved successfully", translations)
+}
+
+// SaveCategoryTranslation godoc
+// @Summary Save category translation
+// @Description Create or replace the name of a category in a supported locale other than the default
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param locale path string true "Locale, e.g. en"
+// @Param request body models.CategoryTranslationRequest true "Translation data"
+// @Success 200 {object} utils.Response{data=models.CategoryTranslation}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/{id}/translations/{locale} [put]
+func (ctrl *TranslationController) SaveCategoryTranslation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid category ID", err.Error())
+		return
+	}
+
+	var req models.CategoryTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	translation, err := ctrl.translationService.SaveCategoryTranslation(id, c.Param("locale"), &req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Category translation saved successfully", translation)
+}
+
+// DeleteCategoryTranslation godoc
+// @Summary Delete category translation
+// @Description Delete the translation of a category in one locale
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param locale path string true "Locale, e.g. en"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/categories/{id}/translations/{locale} [delete]
+func (ctrl *TranslationController) DeleteCategoryTranslation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid category ID", err.Error())
+		return
+	}
+
+	if err := ctrl.translationService.DeleteCategoryTranslation(id, c.Param("locale")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Category translation deleted successfully", nil)
+}
@@ -0,0 +1,377 @@
+package controllers
+
+import (
+	"strconv"
+
+	"book-management/internal/models"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkController struct {
+	workService *services.WorkService
+}
+
+func NewWorkController(workService *services.WorkService) *WorkController {
+	return &WorkController{
+		workService: workService,
+	}
+}
+
+// GetWorks godoc
+// @Summary Get works
+// @Description Get all works, optionally searched by title
+// @Tags works
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Work title"
+// @Success 200 {object} utils.Response{data=[]models.Work}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/works [get]
+func (ctrl *WorkController) GetWorks(c *gin.Context) {
+	works, err := ctrl.workService.GetWorks(c.Query("search"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Works retrieved successfully", works)
+}
+
+// GetWorkByID godoc
+// @Summary Get work by ID
+// @Description Get a work together with all of its editions
+// @Tags works
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Work ID"
+// @Success 200 {object} utils.Response{data=models.Work}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/works/{id} [get]
+func (ctrl *WorkController) GetWorkByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid work ID", err.Error())
+		return
+	}
+
+	work, err := ctrl.workService.GetWork(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Work retrieved successfully", work)
+}
+
+// CreateWork godoc
+// @Summary Create work
+// @Description Create a work that groups editions and translations of the same title
+// @Tags works
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WorkRequest true "Work data"
+// @Success 201 {object} utils.Response{data=models.Work}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/works [post]
+func (ctrl *WorkController) CreateWork(c *gin.Context) {
+	var req models.WorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	work, err := ctrl.workService.CreateWork(&req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Work created successfully", work)
+}
+
+// UpdateWork godoc
+// @Summary Update work
+// @Description Update the title and description of a work
+// @Tags works
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Work ID"
+// @Param request body models.WorkRequest true "Work data"
+// @Success 200 {object} utils.Response{data=models.Work}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/works/{id} [put]
+func (ctrl *WorkController) UpdateWork(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid work ID", err.Error())
+		return
+	}
+
+	var req models.WorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	work, err := ctrl.workService.UpdateWork(id, &req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Work updated successfully", work)
+}
+
+// DeleteWork godoc
+// @Summary Delete work
+// @Description Delete a work; its editions are kept without a work
+// @Tags works
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Work ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/works/{id} [delete]
+func (ctrl *WorkController) DeleteWork(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid work ID", err.Error())
+		return
+	}
+
+	if err := ctrl.workService.DeleteWork(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Work deleted successfully", nil)
+}
+
+// AddWorkEdition godoc
+// @Summary Add edition to work
+// @Description Make a book an edition of the work; a book belonging to another work is moved
+// @Tags works
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Work ID"
+// @Param request body models.WorkBookRequest true "Book"
+// @Success 200 {object} utils.Response{data=models.Work}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/works/{id}/books [post]
+func (ctrl *WorkController) AddWorkEdition(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid work ID", err.Error())
+		return
+	}
+
+	var req models.WorkBookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	work, err := ctrl.workService.AddEdition(id, &req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Edition added successfully", work)
+}
+
+// RemoveWorkEdition godoc
+// @Summary Remove edition from work
+// @Description Detach a book from the work
+// @Tags works
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Work ID"
+// @Param bookId path int true "Book ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/works/{id}/books/{bookId} [delete]
+func (ctrl *WorkController) RemoveWorkEdition(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid work ID", err.Error())
+		return
+	}
+
+	bookID, err := strconv.Atoi(c.Param("bookId"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	if err := ctrl.workService.RemoveEdition(id, bookID, c.GetString("username")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Edition removed successfully", nil)
+}
+
+// GetBookEditions godoc
+// @Summary Get other editions of a book
+// @Description Get the other editions of the work the book belongs to; empty when the book has no work
+// @Tags works
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=[]models.WorkEdition}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/editions [get]
+func (ctrl *WorkController) GetBookEditions(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	editions, err := ctrl.workService.GetBookEditions(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Editions retrieved successfully", editions)
+}
+
+// GetBookRelations godoc
+// @Summary Get book relations
+// @Description Get the relations of a book in both directions; incoming relations use the inverse type (translated_as, prequel_to, revised_as)
+// @Tags works
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} utils.Response{data=[]models.BookRelation}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/relations [get]
+func (ctrl *WorkController) GetBookRelations(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	relations, err := ctrl.workService.GetRelations(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book relations retrieved successfully", relations)
+}
+
+// CreateBookRelation godoc
+// @Summary Create book relation
+// @Description Record that the book is a translation of, sequel to or revised edition of another book
+// @Tags works
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param request body models.BookRelationRequest true "Relation data"
+// @Success 201 {object} utils.Response{data=[]models.BookRelation}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/relations [post]
+func (ctrl *WorkController) CreateBookRelation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	var req models.BookRelationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	relations, err := ctrl.workService.CreateRelation(id, &req, c.GetString("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.Created(c, "Book relation created successfully", relations)
+}
+
+// DeleteBookRelation godoc
+// @Summary Delete book relation
+// @Description Delete a relation from either of the books involved
+// @Tags works
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param relationId path int true "Relation ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /api/books/{id}/relations/{relationId} [delete]
+func (ctrl *WorkController) DeleteBookRelation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.BadRequest(c, "Invalid book ID", err.Error())
+		return
+	}
+
+	relationID, err := strconv.Atoi(c.Param("relationId"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid relation ID", err.Error())
+		return
+	}
+
+	if err := ctrl.workService.DeleteRelation(id, relationID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OK(c, "Book relation deleted successfully", nil)
+}
@@ -0,0 +1,155 @@
+package currency
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// minorUnits berisi jumlah digit desimal (minor unit) tiap mata uang menurut
+// ISO 4217. IDR sengaja dicatat tanpa desimal karena sen tidak lagi dipakai
+// dan harga lama sudah tersimpan dalam rupiah penuh.
+var minorUnits = map[string]int{
+	"AUD": 2,
+	"BHD": 3,
+	"BND": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"IDR": 0,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"MYR": 2,
+	"NZD": 2,
+	"PHP": 2,
+	"SAR": 2,
+	"SGD": 2,
+	"THB": 2,
+	"TWD": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+var (
+	ErrInvalidRate = errors.New("invalid exchange rate")
+
+	// ErrRateTooSmall dan ErrRateTooLarge dikembalikan untuk kurs yang tidak
+	// bisa disimpan di kolom NUMERIC(30, 12): menjadi 0 setelah dibulatkan ke
+	// 12 desimal, atau lebih dari 18 digit sebelum koma
+	ErrRateTooSmall = errors.New("exchange rate is too small")
+	ErrRateTooLarge = errors.New("exchange rate is too large")
+
+	// ErrAmountOverflow dikembalikan ketika hasil konversi tidak muat di int64
+	ErrAmountOverflow = errors.New("converted amount overflows")
+)
+
+// maxRate adalah batas atas (eksklusif) kurs yang bisa disimpan
+var maxRate = new(big.Rat).SetInt(pow10(18))
+
+// Normalize merapikan kode mata uang menjadi huruf besar tanpa spasi
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsSupported mengecek apakah kode mata uang dikenal
+func IsSupported(code string) bool {
+	_, ok := minorUnits[code]
+	return ok
+}
+
+// Codes mengembalikan semua kode mata uang yang didukung, terurut
+func Codes() []string {
+	codes := make([]string, 0, len(minorUnits))
+	for code := range minorUnits {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// MinorUnits mengembalikan jumlah digit desimal mata uang
+func MinorUnits(code string) int {
+	return minorUnits[code]
+}
+
+// ParseRate membaca kurs desimal seperti "15850.25"; kurs harus positif
+// dan tetap positif setelah dibulatkan ke 12 desimal
+func ParseRate(value string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || rate.Sign() <= 0 {
+		return nil, ErrInvalidRate
+	}
+	if FormatRate(rate) == "0" {
+		return nil, ErrRateTooSmall
+	}
+	if rate.Cmp(maxRate) >= 0 {
+		return nil, ErrRateTooLarge
+	}
+	return rate, nil
+}
+
+// FormatRate menulis kurs sebagai desimal dengan maksimal 12 digit di
+// belakang koma, tanpa nol di akhir
+func FormatRate(rate *big.Rat) string {
+	value := rate.FloatString(12)
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
+
+// Convert mengubah amount (dalam minor unit mata uang from) ke minor unit
+// mata uang to dengan kurs 1 from = rate to. Hasil dibulatkan ke minor unit
+// terdekat, nilai tepat di tengah dibulatkan menjauhi nol. Hasil yang tidak
+// muat di int64 menghasilkan ErrAmountOverflow.
+func Convert(amount int64, from, to string, rate *big.Rat) (int64, error) {
+	value := new(big.Rat).SetInt64(amount)
+	value.Mul(value, rate)
+	value.Mul(value, new(big.Rat).SetFrac(pow10(MinorUnits(to)), pow10(MinorUnits(from))))
+
+	rounded := round(value)
+	if !rounded.IsInt64() {
+		return 0, ErrAmountOverflow
+	}
+	return rounded.Int64(), nil
+}
+
+// Format menulis amount dalam minor unit sebagai angka desimal, misalnya
+// 1999 USD menjadi "19.99" dan 500000 IDR menjadi "500000"
+func Format(amount int64, code string) string {
+	units := MinorUnits(code)
+	if units == 0 {
+		return big.NewInt(amount).String()
+	}
+	return new(big.Rat).SetFrac(big.NewInt(amount), pow10(units)).FloatString(units)
+}
+
+// Round membulatkan value ke bilangan bulat terdekat; nilai tepat di tengah
+// dibulatkan menjauhi nol. value harus muat di int64.
+func Round(value *big.Rat) int64 {
+	return round(value).Int64()
+}
+
+func round(value *big.Rat) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+
+	// Round up when the remainder is at least half of the denominator
+	remainder.Abs(remainder).Lsh(remainder, 1)
+	if remainder.Cmp(value.Denom()) >= 0 {
+		if value.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
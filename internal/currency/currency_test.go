@@ -0,0 +1,153 @@
+package currency
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		num, denom int64
+		want       int64
+	}{
+		{num: 0, denom: 1, want: 0},
+		{num: 5, denom: 2, want: 3},
+		{num: -5, denom: 2, want: -3},
+		{num: 1, denom: 2, want: 1},
+		{num: -1, denom: 2, want: -1},
+		{num: 7, denom: 3, want: 2},
+		{num: -7, denom: 3, want: -2},
+		{num: 5, denom: 3, want: 2},
+		{num: -5, denom: 3, want: -2},
+		{num: 499, denom: 1000, want: 0},
+		{num: -499, denom: 1000, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := Round(big.NewRat(tt.num, tt.denom)); got != tt.want {
+			t.Errorf("Round(%d/%d) = %d, want %d", tt.num, tt.denom, got, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		from, to string
+		rate     string
+		want     int64
+	}{
+		{name: "cents to rupiah", amount: 1999, from: "USD", to: "IDR", rate: "15850.25", want: 316846},
+		{name: "negative amount", amount: -1999, from: "USD", to: "IDR", rate: "15850.25", want: -316846},
+		{name: "rupiah to cents", amount: 100000, from: "IDR", to: "USD", rate: "0.0000630914826", want: 631},
+		{name: "half rounds up", amount: 1, from: "USD", to: "EUR", rate: "0.5", want: 1},
+		{name: "negative half rounds down", amount: -1, from: "USD", to: "EUR", rate: "0.5", want: -1},
+		{name: "three minor units", amount: 1000, from: "JPY", to: "KWD", rate: "0.002055", want: 2055},
+		{name: "below half a minor unit", amount: 1, from: "KWD", to: "USD", rate: "3.25", want: 0},
+		{name: "same currency", amount: 12345, from: "USD", to: "USD", rate: "1", want: 12345},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Convert(tt.amount, tt.from, tt.to, rate)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert(%d, %s, %s, %s) = %d, want %d", tt.amount, tt.from, tt.to, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		from, to string
+		rate     string
+		wantErr  error
+	}{
+		{name: "large rate", amount: math.MaxInt64, from: "IDR", to: "JPY", rate: "2", wantErr: ErrAmountOverflow},
+		{name: "negative large rate", amount: math.MinInt64, from: "IDR", to: "JPY", rate: "2", wantErr: ErrAmountOverflow},
+		{name: "more minor units", amount: math.MaxInt64 / 10, from: "IDR", to: "USD", rate: "1", wantErr: ErrAmountOverflow},
+		{name: "largest amount that fits", amount: math.MaxInt64, from: "USD", to: "USD", rate: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Convert(tt.amount, tt.from, tt.to, rate); err != tt.wantErr {
+				t.Errorf("Convert(%d, %s, %s, %s) error = %v, want %v", tt.amount, tt.from, tt.to, tt.rate, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr error
+	}{
+		{value: " 15850.25 ", want: "15850.25"},
+		{value: "0.000063091482649842", want: "0.000063091483"},
+		{value: "1/3", want: "0.333333333333"},
+		{value: "0.000000000001", want: "0.000000000001"},
+		{value: "0.0000000000005", want: "0.000000000001"},
+		{value: "999999999999999999.999999999999", want: "999999999999999999.999999999999"},
+		{value: "0.0000000000004", wantErr: ErrRateTooSmall},
+		{value: "1e-15", wantErr: ErrRateTooSmall},
+		{value: "1000000000000000000", wantErr: ErrRateTooLarge},
+		{value: "0", wantErr: ErrInvalidRate},
+		{value: "-1.5", wantErr: ErrInvalidRate},
+		{value: "abc", wantErr: ErrInvalidRate},
+		{value: "", wantErr: ErrInvalidRate},
+	}
+
+	for _, tt := range tests {
+		rate, err := ParseRate(tt.value)
+		if tt.wantErr != nil {
+			if err != tt.wantErr {
+				t.Errorf("ParseRate(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRate(%q) error = %v", tt.value, err)
+			continue
+		}
+		if got := FormatRate(rate); got != tt.want {
+			t.Errorf("FormatRate(ParseRate(%q)) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		amount int64
+		code   string
+		want   string
+	}{
+		{amount: 1999, code: "USD", want: "19.99"},
+		{amount: 5, code: "USD", want: "0.05"},
+		{amount: -1999, code: "USD", want: "-19.99"},
+		{amount: 500000, code: "IDR", want: "500000"},
+		{amount: 2055, code: "KWD", want: "2.055"},
+	}
+
+	for _, tt := range tests {
+		if got := Format(tt.amount, tt.code); got != tt.want {
+			t.Errorf("Format(%d, %s) = %q, want %q", tt.amount, tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package i18n menerjemahkan pesan API, pesan validasi dan label ketebalan
+// buku. Kunci pesan respons adalah teks bahasa Inggrisnya sendiri sehingga
+// pesan tanpa terjemahan tetap tampil dalam bahasa Inggris; pesan validasi
+// dan label ketebalan memakai kunci bernama seperti "validation.required".
+package i18n
+
+import (
+	"fmt"
+	"sort"
+)
+
+// catalogs berisi katalog pesan per bahasa
+var catalogs = map[string]map[string]string{
+	"en": enMessages,
+	"id": idMessages,
+}
+
+// defaultLocale dipakai ketika request tidak membawa bahasa; diganti lewat
+// SetDefaultLocale
+var defaultLocale = "id"
+
+// SetDefaultLocale mengganti bahasa default; dipanggil sekali saat aplikasi
+// dimulai, sebelum request pertama dilayani
+func SetDefaultLocale(locale string) {
+	defaultLocale = locale
+}
+
+// Locales mengembalikan bahasa yang memiliki katalog pesan, terurut
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// T menerjemahkan key ke bahasa locale (kosong berarti bahasa default) lalu
+// mengisi args bila ada. Key yang tidak ada di katalog bahasa tersebut dicari
+// di katalog bahasa Inggris, lalu dipakai apa adanya.
+func T(locale, key string, args ...interface{}) string {
+	if locale == "" {
+		locale = defaultLocale
+	}
+
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = enMessages[key]
+	}
+	if !ok {
+		message = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// ThicknessLabel menerjemahkan label ketebalan buku seperti "tipis". Label
+// dari skema kustom yang tidak ada di katalog dikembalikan apa adanya.
+func ThicknessLabel(locale, label string) string {
+	key := "thickness." + label
+	if message := T(locale, key); message != key {
+		return message
+	}
+	return label
+}
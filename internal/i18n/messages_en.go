@@ -0,0 +1,37 @@
+package i18n
+
+// enMessages hanya memuat pesan dengan kunci bernama; pesan respons memakai
+// teks bahasa Inggris sebagai kuncinya
+var enMessages = map[string]string{
+	// Validation messages, %s is the field name
+	"validation.required":         "%s is required",
+	"validation.required_without": "%s is required when %s is empty",
+	"validation.min.string":       "%s must be at least %s characters",
+	"validation.min.number":       "%s must be at least %s",
+	"validation.min.items":        "%s must contain at least %s items",
+	"validation.max.string":       "%s must be at most %s characters",
+	"validation.max.number":       "%s must be at most %s",
+	"validation.max.items":        "%s must contain at most %s items",
+	"validation.len.string":       "%s must be exactly %s characters",
+	"validation.len.number":       "%s must be %s",
+	"validation.len.items":        "%s must contain exactly %s items",
+	"validation.gt":               "%s must be greater than %s",
+	"validation.gte":              "%s must be at least %s",
+	"validation.lt":               "%s must be less than %s",
+	"validation.lte":              "%s must be at most %s",
+	"validation.oneof":            "%s must be one of: %s",
+	"validation.email":            "%s must be a valid email address",
+	"validation.url":              "%s must be a valid URL",
+	"validation.datetime":         "%s must be a date in the format %s",
+	"validation.book_title":       "%s must be between 1 and %d characters",
+	"validation.between":          "%s must be between %d and %d",
+	"validation.at_least":         "%s must be at least %d",
+	"validation.currency":         "%s must be a supported ISO 4217 currency code",
+	"validation.invalid":          "%s is invalid",
+
+	// Thickness labels of the default and common custom schemes
+	"thickness.tipis":        "thin",
+	"thickness.sedang":       "medium",
+	"thickness.tebal":        "thick",
+	"thickness.sangat_tebal": "very thick",
+}
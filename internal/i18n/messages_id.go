@@ -0,0 +1,307 @@
+package i18n
+
+// idMessages adalah katalog bahasa Indonesia
+var idMessages = map[string]string{
+	// Pesan validasi, %s adalah nama field
+	"validation.required":         "%s wajib diisi",
+	"validation.required_without": "%s wajib diisi bila %s kosong",
+	"validation.min.string":       "%s minimal %s karakter",
+	"validation.min.number":       "%s minimal %s",
+	"validation.min.items":        "%s minimal berisi %s item",
+	"validation.max.string":       "%s maksimal %s karakter",
+	"validation.max.number":       "%s maksimal %s",
+	"validation.max.items":        "%s maksimal berisi %s item",
+	"validation.len.string":       "%s harus tepat %s karakter",
+	"validation.len.number":       "%s harus bernilai %s",
+	"validation.len.items":        "%s harus berisi tepat %s item",
+	"validation.gt":               "%s harus lebih dari %s",
+	"validation.gte":              "%s minimal %s",
+	"validation.lt":               "%s harus kurang dari %s",
+	"validation.lte":              "%s maksimal %s",
+	"validation.oneof":            "%s harus salah satu dari: %s",
+	"validation.email":            "%s harus berupa alamat email yang valid",
+	"validation.url":              "%s harus berupa URL yang valid",
+	"validation.datetime":         "%s harus berupa tanggal dengan format %s",
+	"validation.book_title":       "%s harus terdiri dari 1 sampai %d karakter",
+	"validation.between":          "%s harus di antara %d dan %d",
+	"validation.at_least":         "%s minimal %d",
+	"validation.currency":         "%s harus berupa kode mata uang ISO 4217 yang didukung",
+	"validation.invalid":          "%s tidak valid",
+
+	// Label ketebalan
+	"thickness.tipis":        "tipis",
+	"thickness.sedang":       "sedang",
+	"thickness.tebal":        "tebal",
+	"thickness.sangat_tebal": "sangat tebal",
+
+	// Umum
+	"Server is running":     "Server berjalan",
+	"Invalid request body":  "Body request tidak valid",
+	"Validation failed":     "Validasi gagal",
+	"Invalid limit":         "Limit tidak valid",
+	"Invalid sort":          "Urutan tidak valid",
+	"Invalid status":        "Status tidak valid",
+	"Invalid locale":        "Bahasa tidak valid",
+	"Unsupported currency":  "Mata uang tidak didukung",
+	"Translation not found": "Terjemahan tidak ditemukan",
+	"Internal server error": "Terjadi kesalahan pada server",
+
+	// Autentikasi
+	"Login successful":                    "Login berhasil",
+	"Authorization header is required":    "Header Authorization wajib diisi",
+	"Invalid authorization header format": "Format header Authorization tidak valid",
+	"Token is required":                   "Token wajib diisi",
+	"Invalid or expired token":            "Token tidak valid atau sudah kedaluwarsa",
+	"Invalid credentials":                 "Username atau password salah",
+	"User not found":                      "Pengguna tidak ditemukan",
+	"Invalid user ID":                     "ID pengguna tidak valid",
+
+	// Kategori
+	"Categories retrieved successfully":                        "Kategori berhasil diambil",
+	"Category tree retrieved successfully":                     "Pohon kategori berhasil diambil",
+	"Category retrieved successfully":                          "Kategori berhasil diambil",
+	"Category created successfully":                            "Kategori berhasil dibuat",
+	"Category updated successfully":                            "Kategori berhasil diperbarui",
+	"Category deleted successfully":                            "Kategori berhasil dihapus",
+	"Category moved successfully":                              "Kategori berhasil dipindahkan",
+	"Category merged successfully":                             "Kategori berhasil digabungkan",
+	"Category not found":                                       "Kategori tidak ditemukan",
+	"Parent category not found":                                "Kategori induk tidak ditemukan",
+	"Target category not found":                                "Kategori tujuan tidak ditemukan",
+	"Category name already exists":                             "Nama kategori sudah ada",
+	"Category cannot be merged into itself":                    "Kategori tidak dapat digabungkan ke dirinya sendiri",
+	"Category cannot be merged into its own descendant":        "Kategori tidak dapat digabungkan ke sub-kategorinya sendiri",
+	"Category cannot be moved under itself or its descendants": "Kategori tidak dapat dipindahkan ke bawah dirinya sendiri atau sub-kategorinya",
+	"Category cannot be reassigned to itself":                  "Buku tidak dapat dipindahkan ke kategori yang sama",
+	"Invalid category ID":                                      "ID kategori tidak valid",
+	"Invalid reassign_to category ID":                          "ID kategori reassign_to tidak valid",
+	"Invalid include_descendants value":                        "Nilai include_descendants tidak valid",
+	"Category is the primary category of %d books; move them with ?reassign_to=<category_id> or merge the category": "Kategori ini adalah kategori utama %d buku; pindahkan bukunya dengan ?reassign_to=<category_id> atau gabungkan kategorinya",
+	"Category translations retrieved successfully":                                                                  "Terjemahan kategori berhasil diambil",
+	"Category translation saved successfully":                                                                       "Terjemahan kategori berhasil disimpan",
+	"Category translation deleted successfully":                                                                     "Terjemahan kategori berhasil dihapus",
+
+	// Buku
+	"Books retrieved successfully":                "Buku berhasil diambil",
+	"Book retrieved successfully":                 "Buku berhasil diambil",
+	"Book created successfully":                   "Buku berhasil dibuat",
+	"Book updated successfully":                   "Buku berhasil diperbarui",
+	"Book deleted successfully":                   "Buku berhasil dihapus",
+	"Book not found":                              "Buku tidak ditemukan",
+	"Source book not found":                       "Buku sumber tidak ditemukan",
+	"Related book not found":                      "Buku terkait tidak ditemukan",
+	"Invalid book ID":                             "ID buku tidak valid",
+	"Book has loan history":                       "Buku memiliki riwayat peminjaman",
+	"Book translations retrieved successfully":    "Terjemahan buku berhasil diambil",
+	"Book translation saved successfully":         "Terjemahan buku berhasil disimpan",
+	"Book translation deleted successfully":       "Terjemahan buku berhasil dihapus",
+	"Similar books retrieved successfully":        "Buku serupa berhasil diambil",
+	"Duplicate candidates retrieved successfully": "Kandidat buku ganda berhasil diambil",
+	"Book merges retrieved successfully":          "Riwayat penggabungan buku berhasil diambil",
+	"Books merged successfully":                   "Buku berhasil digabungkan",
+	"Invalid merge":                               "Penggabungan tidak valid",
+	"Invalid min_score":                           "Nilai min_score tidak valid",
+
+	// Sampul, file & metadata
+	"Cover retrieved successfully":                       "Sampul berhasil diambil",
+	"Cover uploaded successfully":                        "Sampul berhasil diunggah",
+	"Cover deleted successfully":                         "Sampul berhasil dihapus",
+	"Cover not found":                                    "Sampul tidak ditemukan",
+	"Cover image file is required":                       "File gambar sampul wajib diisi",
+	"Failed to read cover image":                         "Gagal membaca gambar sampul",
+	"Invalid cover image":                                "Gambar sampul tidak valid",
+	"Unsupported cover image type, use JPEG, PNG or GIF": "Jenis gambar sampul tidak didukung, gunakan JPEG, PNG atau GIF",
+	"Cover image must not exceed %d MB":                  "Gambar sampul tidak boleh lebih dari %d MB",
+	"Files retrieved successfully":                       "File berhasil diambil",
+	"File uploaded successfully":                         "File berhasil diunggah",
+	"File deleted successfully":                          "File berhasil dihapus",
+	"File not found":                                     "File tidak ditemukan",
+	"File is required":                                   "File wajib diisi",
+	"Failed to read file":                                "Gagal membaca file",
+	"Invalid file ID":                                    "ID file tidak valid",
+	"File must not exceed %d MB":                         "File tidak boleh lebih dari %d MB",
+	"Unsupported file format, use PDF, EPUB or MOBI":     "Format file tidak didukung, gunakan PDF, EPUB atau MOBI",
+	"Unsupported file format, use EPUB or PDF":           "Format file tidak didukung, gunakan EPUB atau PDF",
+	"Downloads retrieved successfully":                   "Riwayat unduhan berhasil diambil",
+	"Metadata extracted successfully":                    "Metadata berhasil dibaca",
+	"Metadata applied successfully":                      "Metadata berhasil diterapkan",
+	"Failed to read metadata from file":                  "Gagal membaca metadata dari file",
+	"Metadata can only be read from EPUB or PDF files":   "Metadata hanya dapat dibaca dari file EPUB atau PDF",
+
+	// Harga, diskon & kurs
+	"Price history retrieved successfully":           "Riwayat harga berhasil diambil",
+	"Scheduled price changes retrieved successfully": "Perubahan harga terjadwal berhasil diambil",
+	"Price change scheduled successfully":            "Perubahan harga berhasil dijadwalkan",
+	"Scheduled price change cancelled successfully":  "Perubahan harga terjadwal berhasil dibatalkan",
+	"Pending scheduled price change not found":       "Perubahan harga terjadwal yang belum diterapkan tidak ditemukan",
+	"Invalid scheduled price change":                 "Perubahan harga terjadwal tidak valid",
+	"Invalid scheduled price change ID":              "ID perubahan harga terjadwal tidak valid",
+	"Discounts retrieved successfully":               "Diskon berhasil diambil",
+	"Discount retrieved successfully":                "Diskon berhasil diambil",
+	"Discount created successfully":                  "Diskon berhasil dibuat",
+	"Discount updated successfully":                  "Diskon berhasil diperbarui",
+	"Discount deleted successfully":                  "Diskon berhasil dihapus",
+	"Discount not found":                             "Diskon tidak ditemukan",
+	"Invalid discount":                               "Diskon tidak valid",
+	"Invalid discount ID":                            "ID diskon tidak valid",
+	"Exchange rates retrieved successfully":          "Kurs berhasil diambil",
+	"Exchange rate retrieved successfully":           "Kurs berhasil diambil",
+	"Exchange rate saved successfully":               "Kurs berhasil disimpan",
+	"Exchange rate deleted successfully":             "Kurs berhasil dihapus",
+	"Exchange rates imported successfully":           "Kurs berhasil diimpor",
+	"Exchange rate not found":                        "Kurs tidak ditemukan",
+	"Exchange rate not available":                    "Kurs tidak tersedia",
+	"Invalid exchange rate file":                     "File kurs tidak valid",
+	"Base and quote currency must differ":            "Mata uang dasar dan mata uang kuotasi harus berbeda",
+	"Rate must be a positive number":                 "Kurs harus berupa angka positif",
+	"Rate is too large":                              "Kurs terlalu besar",
+	"Converted amount is too large":                  "Hasil konversi terlalu besar",
+
+	// Tag
+	"Tags retrieved successfully":      "Tag berhasil diambil",
+	"Tag cloud retrieved successfully": "Tag cloud berhasil diambil",
+	"Tag retrieved successfully":       "Tag berhasil diambil",
+	"Tag created successfully":         "Tag berhasil dibuat",
+	"Tag updated successfully":         "Tag berhasil diperbarui",
+	"Tag deleted successfully":         "Tag berhasil dihapus",
+	"Tag not found":                    "Tag tidak ditemukan",
+	"Tag already exists":               "Tag sudah ada",
+	"Invalid tag ID":                   "ID tag tidak valid",
+
+	// Eksemplar
+	"Items retrieved successfully": "Eksemplar berhasil diambil",
+	"Item retrieved successfully":  "Eksemplar berhasil diambil",
+	"Item created successfully":    "Eksemplar berhasil dibuat",
+	"Item updated successfully":    "Eksemplar berhasil diperbarui",
+	"Item deleted successfully":    "Eksemplar berhasil dihapus",
+	"Item not found":               "Eksemplar tidak ditemukan",
+	"Item has loan history":        "Eksemplar memiliki riwayat peminjaman",
+	"Item is on loan or on hold":   "Eksemplar sedang dipinjam atau disisihkan untuk hold",
+	"Item status has changed":      "Status eksemplar telah berubah",
+	"Barcode already exists":       "Barcode sudah ada",
+	"Invalid item ID":              "ID eksemplar tidak valid",
+	"Invalid item status":          "Status eksemplar tidak valid",
+
+	// Anggota & denda
+	"Patrons retrieved successfully": "Anggota berhasil diambil",
+	"Patron retrieved successfully":  "Anggota berhasil diambil",
+	"Patron created successfully":    "Anggota berhasil dibuat",
+	"Patron updated successfully":    "Anggota berhasil diperbarui",
+	"Patron deleted successfully":    "Anggota berhasil dihapus",
+	"Patron not found":               "Anggota tidak ditemukan",
+	"Patron has loan history":        "Anggota memiliki riwayat peminjaman",
+	"Card number already exists":     "Nomor kartu sudah ada",
+	"Invalid patron ID":              "ID anggota tidak valid",
+	"Ledger retrieved successfully":  "Buku besar denda berhasil diambil",
+	"Balance retrieved successfully": "Saldo denda berhasil diambil",
+	"Payment recorded successfully":  "Pembayaran berhasil dicatat",
+	"Waiver recorded successfully":   "Penghapusan denda berhasil dicatat",
+	"Invalid ledger entry":           "Entri buku besar tidak valid",
+
+	// Peminjaman
+	"Loans retrieved successfully":                           "Peminjaman berhasil diambil",
+	"Loan retrieved successfully":                            "Peminjaman berhasil diambil",
+	"Item checked out successfully":                          "Eksemplar berhasil dipinjamkan",
+	"Loan returned successfully":                             "Eksemplar berhasil dikembalikan",
+	"Loan renewed successfully":                              "Peminjaman berhasil diperpanjang",
+	"Loan not found":                                         "Peminjaman tidak ditemukan",
+	"Loan already returned":                                  "Peminjaman sudah dikembalikan",
+	"Invalid loan ID":                                        "ID peminjaman tidak valid",
+	"Item is not available for loan":                         "Eksemplar tidak tersedia untuk dipinjam",
+	"Item is on hold for another patron":                     "Eksemplar sedang disisihkan untuk anggota lain",
+	"Patron is suspended":                                    "Anggota sedang ditangguhkan",
+	"Patron has reached the active loan limit":               "Anggota sudah mencapai batas pinjaman aktif",
+	"Patron has outstanding fines above the allowed balance": "Anggota memiliki denda tertunggak di atas batas yang diizinkan",
+	"Patron already has this book on loan":                   "Anggota sedang meminjam buku ini",
+	"Overdue loans cannot be renewed":                        "Peminjaman yang terlambat tidak dapat diperpanjang",
+	"Renewal limit reached":                                  "Batas perpanjangan sudah tercapai",
+	"Other patrons are waiting for this book":                "Anggota lain sedang menunggu buku ini",
+	"Loan policies retrieved successfully":                   "Kebijakan peminjaman berhasil diambil",
+	"Loan policy retrieved successfully":                     "Kebijakan peminjaman berhasil diambil",
+	"Loan policy saved successfully":                         "Kebijakan peminjaman berhasil disimpan",
+	"Loan policy deleted successfully":                       "Kebijakan peminjaman berhasil dihapus",
+	"Loan policy not found":                                  "Kebijakan peminjaman tidak ditemukan",
+
+	// Hold
+	"Holds retrieved successfully":                  "Hold berhasil diambil",
+	"Hold retrieved successfully":                   "Hold berhasil diambil",
+	"Hold placed successfully":                      "Hold berhasil dipasang",
+	"Hold cancelled successfully":                   "Hold berhasil dibatalkan",
+	"Hold not found":                                "Hold tidak ditemukan",
+	"Hold already closed":                           "Hold sudah ditutup",
+	"Patron already has an open hold for this book": "Anggota sudah memiliki hold aktif untuk buku ini",
+	"Invalid hold ID":                               "ID hold tidak valid",
+
+	// Ulasan
+	"Reviews retrieved successfully":       "Ulasan berhasil diambil",
+	"Review retrieved successfully":        "Ulasan berhasil diambil",
+	"Review created successfully":          "Ulasan berhasil dibuat",
+	"Review updated successfully":          "Ulasan berhasil diperbarui",
+	"Review deleted successfully":          "Ulasan berhasil dihapus",
+	"Review hidden successfully":           "Ulasan berhasil disembunyikan",
+	"Review unhidden successfully":         "Ulasan berhasil ditampilkan kembali",
+	"Review not found":                     "Ulasan tidak ditemukan",
+	"You have already reviewed this book":  "Anda sudah mengulas buku ini",
+	"You can only change your own review":  "Anda hanya dapat mengubah ulasan milik sendiri",
+	"Only moderators can moderate reviews": "Hanya moderator yang dapat memoderasi ulasan",
+	"Invalid review ID":                    "ID ulasan tidak valid",
+
+	// Daftar bacaan & kemajuan membaca
+	"Reading lists retrieved successfully":         "Daftar bacaan berhasil diambil",
+	"Reading list retrieved successfully":          "Daftar bacaan berhasil diambil",
+	"Reading list created successfully":            "Daftar bacaan berhasil dibuat",
+	"Reading list updated successfully":            "Daftar bacaan berhasil diperbarui",
+	"Reading list deleted successfully":            "Daftar bacaan berhasil dihapus",
+	"Reading list reordered successfully":          "Daftar bacaan berhasil disusun ulang",
+	"Reading list shared successfully":             "Tautan berbagi daftar bacaan berhasil dibuat",
+	"Reading list share link revoked successfully": "Tautan berbagi daftar bacaan berhasil dicabut",
+	"Reading list not found":                       "Daftar bacaan tidak ditemukan",
+	"Book added to reading list successfully":      "Buku berhasil ditambahkan ke daftar bacaan",
+	"Book removed from reading list successfully":  "Buku berhasil dikeluarkan dari daftar bacaan",
+	"Book is already in the reading list":          "Buku sudah ada di daftar bacaan",
+	"Book is not in the reading list":              "Buku tidak ada di daftar bacaan",
+	"You can only change your own reading lists":   "Anda hanya dapat mengubah daftar bacaan milik sendiri",
+	"Shelves cannot be deleted":                    "Rak bawaan tidak dapat dihapus",
+	"Invalid reading list ID":                      "ID daftar bacaan tidak valid",
+	"Invalid order":                                "Urutan tidak valid",
+	"Reading progress retrieved successfully":      "Kemajuan membaca berhasil diambil",
+	"Reading progress updated successfully":        "Kemajuan membaca berhasil diperbarui",
+	"Reading progress deleted successfully":        "Kemajuan membaca berhasil dihapus",
+	"Reading progress not found":                   "Kemajuan membaca tidak ditemukan",
+	"Invalid reading progress":                     "Kemajuan membaca tidak valid",
+
+	// Karya, seri & hubungan buku
+	"Works retrieved successfully":                "Karya berhasil diambil",
+	"Work retrieved successfully":                 "Karya berhasil diambil",
+	"Work created successfully":                   "Karya berhasil dibuat",
+	"Work updated successfully":                   "Karya berhasil diperbarui",
+	"Work deleted successfully":                   "Karya berhasil dihapus",
+	"Work not found":                              "Karya tidak ditemukan",
+	"Invalid work ID":                             "ID karya tidak valid",
+	"Edition added successfully":                  "Edisi berhasil ditambahkan",
+	"Edition removed successfully":                "Edisi berhasil dilepaskan",
+	"Editions retrieved successfully":             "Edisi berhasil diambil",
+	"Book is not an edition of this work":         "Buku bukan edisi dari karya ini",
+	"Series retrieved successfully":               "Seri berhasil diambil",
+	"Series created successfully":                 "Seri berhasil dibuat",
+	"Series updated successfully":                 "Seri berhasil diperbarui",
+	"Series deleted successfully":                 "Seri berhasil dihapus",
+	"Series not found":                            "Seri tidak ditemukan",
+	"Series name already exists":                  "Nama seri sudah ada",
+	"Invalid series ID":                           "ID seri tidak valid",
+	"Book series retrieved successfully":          "Seri buku berhasil diambil",
+	"Book added to series successfully":           "Buku berhasil ditambahkan ke seri",
+	"Book removed from series successfully":       "Buku berhasil dikeluarkan dari seri",
+	"Book is already in the series":               "Buku sudah ada di seri",
+	"Book is not in the series":                   "Buku tidak ada di seri",
+	"Volume number updated successfully":          "Nomor volume berhasil diperbarui",
+	"Volume number is already used in the series": "Nomor volume sudah dipakai di seri",
+	"Invalid volume number":                       "Nomor volume tidak valid",
+	"Book relations retrieved successfully":       "Hubungan buku berhasil diambil",
+	"Book relation created successfully":          "Hubungan buku berhasil dibuat",
+	"Book relation deleted successfully":          "Hubungan buku berhasil dihapus",
+	"Book relation not found":                     "Hubungan buku tidak ditemukan",
+	"Book relation already exists":                "Hubungan buku sudah ada",
+	"Invalid book relation":                       "Hubungan buku tidak valid",
+	"Invalid relation ID":                         "ID hubungan tidak valid",
+}
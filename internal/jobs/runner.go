@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Runner menjalankan background job secara berkala di goroutine masing-masing.
+// Setiap job langsung dijalankan sekali saat Start, lalu setiap interval.
+type Runner struct {
+	jobs []job
+}
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func() error
+}
+
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Add mendaftarkan job; interval <= 0 menonaktifkan job tersebut
+func (r *Runner) Add(name string, interval time.Duration, run func() error) {
+	if interval <= 0 {
+		log.Printf("Job %s is disabled", name)
+		return
+	}
+
+	r.jobs = append(r.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start menjalankan semua job sampai ctx dibatalkan
+func (r *Runner) Start(ctx context.Context) {
+	for _, j := range r.jobs {
+		go r.loop(ctx, j)
+	}
+}
+
+func (r *Runner) loop(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		r.runOnce(j)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce menjalankan job sekali; error dan panic hanya dicatat agar job
+// tetap berjalan pada interval berikutnya
+func (r *Runner) runOnce(j job) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("Job %s panicked: %v", j.name, recovered)
+		}
+	}()
+
+	if err := j.run(); err != nil {
+		log.Printf("Job %s failed: %v", j.name, err)
+	}
+}
@@ -0,0 +1,95 @@
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalize mengubah tag bahasa menjadi subtag bahasa utama dalam huruf
+// kecil, misalnya "en-US" dan "EN_gb" menjadi "en". Tag yang tidak valid
+// menghasilkan string kosong.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	if len(tag) < 2 || len(tag) > 3 {
+		return ""
+	}
+	for _, r := range tag {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+
+	return tag
+}
+
+// Negotiate memilih locale respons dari daftar supported dengan urutan:
+// parameter lang, lalu header Accept-Language menurut bobot q, lalu
+// fallback. Nilai yang tidak didukung dilewati.
+func Negotiate(lang, acceptLanguage string, supported []string, fallback string) string {
+	candidates := append([]string{lang}, parseAcceptLanguage(acceptLanguage)...)
+
+	for _, candidate := range candidates {
+		code := Normalize(candidate)
+		if code == "" {
+			continue
+		}
+		for _, s := range supported {
+			if s == code {
+				return code
+			}
+		}
+	}
+
+	return fallback
+}
+
+// parseAcceptLanguage mengembalikan tag pada header Accept-Language
+// diurutkan dari bobot tertinggi; tag dengan q=0 dan wildcard diabaikan
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err != nil {
+				value = 0
+			}
+			q = value
+		}
+
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+
+	return result
+}
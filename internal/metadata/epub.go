@@ -0,0 +1,189 @@
+package metadata
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// charactersPerPage dipakai untuk memperkirakan jumlah halaman EPUB yang
+// tidak menyimpan jumlah halaman cetaknya
+const charactersPerPage = 1800
+
+// maxEPUBTextSize membatasi jumlah total isi bab yang dibuka untuk
+// memperkirakan jumlah halaman
+const maxEPUBTextSize = 50 << 20
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type epubPackage struct {
+	Metadata struct {
+		Titles       []string `xml:"title"`
+		Descriptions []string `xml:"description"`
+		Dates        []string `xml:"date"`
+		Metas        []struct {
+			Name     string `xml:"name,attr"`
+			Content  string `xml:"content,attr"`
+			Property string `xml:"property,attr"`
+			Value    string `xml:",chardata"`
+		} `xml:"meta"`
+	} `xml:"metadata"`
+	Manifest []struct {
+		ID         string `xml:"id,attr"`
+		Href       string `xml:"href,attr"`
+		MediaType  string `xml:"media-type,attr"`
+		Properties string `xml:"properties,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+func extractEPUB(r io.ReaderAt, size int64) (*Metadata, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var container epubContainer
+	if err := readXML(files["META-INF/container.xml"], &container); err != nil {
+		return nil, err
+	}
+
+	if len(container.Rootfiles) == 0 {
+		return nil, errors.New("epub has no package document")
+	}
+
+	opfPath := container.Rootfiles[0].FullPath
+	var pkg epubPackage
+	if err := readXML(files[opfPath], &pkg); err != nil {
+		return nil, err
+	}
+
+	meta := &Metadata{}
+	if len(pkg.Metadata.Titles) > 0 {
+		meta.Title = strings.TrimSpace(pkg.Metadata.Titles[0])
+	}
+	if len(pkg.Metadata.Descriptions) > 0 {
+		meta.Description = plainText(pkg.Metadata.Descriptions[0])
+	}
+	for _, date := range pkg.Metadata.Dates {
+		if year := parseYear(date); year > 0 {
+			meta.PublicationYear = year
+			break
+		}
+	}
+
+	// Manifest hrefs are relative to the package document
+	baseDir := path.Dir(opfPath)
+	resolve := func(href string) *zip.File {
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+		return files[path.Clean(path.Join(baseDir, href))]
+	}
+
+	coverID := ""
+	for _, m := range pkg.Metadata.Metas {
+		switch {
+		case m.Name == "cover":
+			coverID = m.Content
+		case m.Property == "schema:numberOfPages" || m.Name == "schema:numberOfPages":
+			value := m.Value
+			if value == "" {
+				value = m.Content
+			}
+			if pages, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && pages > 0 {
+				meta.PageCount = pages
+			}
+		}
+	}
+
+	itemsByID := make(map[string]int, len(pkg.Manifest))
+	for i, item := range pkg.Manifest {
+		itemsByID[item.ID] = i
+		if coverID == "" && strings.Contains(" "+item.Properties+" ", " cover-image ") {
+			coverID = item.ID
+		}
+	}
+
+	if i, ok := itemsByID[coverID]; ok && strings.HasPrefix(pkg.Manifest[i].MediaType, "image/") {
+		if cover, err := readZipFile(resolve(pkg.Manifest[i].Href), 20<<20); err == nil {
+			meta.Cover = cover
+			meta.CoverContentType = pkg.Manifest[i].MediaType
+		}
+	}
+
+	if meta.PageCount == 0 {
+		characters := 0
+		budget := int64(maxEPUBTextSize)
+		read := make(map[*zip.File]bool, len(pkg.Spine))
+		for _, ref := range pkg.Spine {
+			i, ok := itemsByID[ref.IDRef]
+			if !ok {
+				continue
+			}
+			// A spine may list the same chapter many times; count it once
+			file := resolve(pkg.Manifest[i].Href)
+			if file == nil || read[file] {
+				continue
+			}
+			read[file] = true
+
+			content, err := readZipFile(file, min(budget, 10<<20))
+			if err != nil {
+				continue
+			}
+			characters += len([]rune(plainText(string(content))))
+
+			budget -= int64(len(content))
+			if budget <= 0 {
+				break
+			}
+		}
+
+		if characters > 0 {
+			meta.PageCount = (characters + charactersPerPage - 1) / charactersPerPage
+			meta.PageCountEstimated = true
+		}
+	}
+
+	return meta, nil
+}
+
+func readXML(file *zip.File, v interface{}) error {
+	content, err := readZipFile(file, 5<<20)
+	if err != nil {
+		return err
+	}
+
+	return xml.Unmarshal(content, v)
+}
+
+func readZipFile(file *zip.File, limit int64) ([]byte, error) {
+	if file == nil {
+		return nil, errors.New("file not found in epub")
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(io.LimitReader(rc, limit))
+}
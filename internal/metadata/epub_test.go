@@ -0,0 +1,160 @@
+package metadata
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const epubContainerXML = `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`
+
+const defaultOPF = `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title> Bumi Manusia </dc:title>
+    <dc:description>&lt;p&gt;Roman &lt;b&gt;sejarah&lt;/b&gt; &amp;amp; cinta&lt;/p&gt;</dc:description>
+    <dc:date>unknown</dc:date>
+    <dc:date>1980-08-25</dc:date>
+    <meta name="cover" content="cover-img"/>
+    <meta name="schema:numberOfPages" content="535"/>
+  </metadata>
+  <manifest>
+    <item id="cover-img" href="images/cover%20art.jpg" media-type="image/jpeg"/>
+    <item id="ch1" href="text/ch1.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine><itemref idref="ch1"/></spine>
+</package>`
+
+// epubFiles mengembalikan isi EPUB minimal dengan package document opf
+func epubFiles(opf string) map[string]string {
+	return map[string]string{
+		"mimetype":                   "application/epub+zip",
+		"META-INF/container.xml":     epubContainerXML,
+		"OEBPS/content.opf":          opf,
+		"OEBPS/images/cover art.jpg": "\xFF\xD8cover",
+		"OEBPS/text/ch1.xhtml":       "<html><body><p>" + strings.Repeat("a", 2000) + "</p></body></html>",
+	}
+}
+
+func buildEPUB(tb testing.TB, files map[string]string) []byte {
+	tb.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		tb.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestExtractEPUB(t *testing.T) {
+	epub3OPF := `<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Cantik Itu Luka</dc:title>
+    <dc:date>2002</dc:date>
+  </metadata>
+  <manifest>
+    <item id="img" href="images/cover%20art.jpg" media-type="image/jpeg" properties="cover-image"/>
+    <item id="ch1" href="text/ch1.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch2" href="text/missing.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine><itemref idref="ch1"/><itemref idref="ch2"/><itemref idref="unknown"/></spine>
+</package>`
+
+	repeatedSpine := strings.Replace(epub3OPF, `<spine>`, `<spine>`+strings.Repeat(`<itemref idref="ch1"/>`, 1000), 1)
+
+	nonImageCover := strings.Replace(defaultOPF, `media-type="image/jpeg"`, `media-type="application/xhtml+xml"`, 1)
+
+	withoutContainer := epubFiles(defaultOPF)
+	delete(withoutContainer, "META-INF/container.xml")
+
+	withoutRootfile := epubFiles(defaultOPF)
+	withoutRootfile["META-INF/container.xml"] = `<container><rootfiles/></container>`
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    Metadata
+		wantErr bool
+	}{
+		{
+			name: "epub 2 metadata",
+			data: buildEPUB(t, epubFiles(defaultOPF)),
+			want: Metadata{
+				Title:            "Bumi Manusia",
+				Description:      "Roman sejarah & cinta",
+				PublicationYear:  1980,
+				PageCount:        535,
+				Cover:            []byte("\xFF\xD8cover"),
+				CoverContentType: "image/jpeg",
+			},
+		},
+		{
+			name: "epub 3 cover and estimated pages",
+			data: buildEPUB(t, epubFiles(epub3OPF)),
+			want: Metadata{
+				Title:              "Cantik Itu Luka",
+				PublicationYear:    2002,
+				PageCount:          2,
+				PageCountEstimated: true,
+				Cover:              []byte("\xFF\xD8cover"),
+				CoverContentType:   "image/jpeg",
+			},
+		},
+		{
+			name: "repeated spine items are counted once",
+			data: buildEPUB(t, epubFiles(repeatedSpine)),
+			want: Metadata{
+				Title:              "Cantik Itu Luka",
+				PublicationYear:    2002,
+				PageCount:          2,
+				PageCountEstimated: true,
+				Cover:              []byte("\xFF\xD8cover"),
+				CoverContentType:   "image/jpeg",
+			},
+		},
+		{
+			name: "cover item is not an image",
+			data: buildEPUB(t, epubFiles(nonImageCover)),
+			want: Metadata{
+				Title:           "Bumi Manusia",
+				Description:     "Roman sejarah & cinta",
+				PublicationYear: 1980,
+				PageCount:       535,
+			},
+		},
+		{name: "missing container", data: buildEPUB(t, withoutContainer), wantErr: true},
+		{name: "missing rootfile", data: buildEPUB(t, withoutRootfile), wantErr: true},
+		{name: "not a zip", data: []byte("%PDF-1.4"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Extract("epub", bytes.NewReader(tt.data), int64(len(tt.data)))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Extract() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			assertMetadata(t, got, &tt.want)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package metadata
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedFormat dikembalikan untuk format selain "epub" dan "pdf"
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// Metadata adalah informasi buku yang dibaca dari file EPUB (OPF) atau PDF
+// (dictionary Info). Field yang tidak ditemukan dibiarkan kosong.
+type Metadata struct {
+	Title           string
+	Description     string
+	PublicationYear int
+	PageCount       int
+
+	// PageCountEstimated bernilai true bila file tidak menyimpan jumlah
+	// halaman dan PageCount diperkirakan dari panjang teks
+	PageCountEstimated bool
+
+	Cover            []byte
+	CoverContentType string
+}
+
+// Extract membaca metadata file berformat "epub" atau "pdf"
+func Extract(format string, r io.ReadSeeker, size int64) (*Metadata, error) {
+	switch format {
+	case "epub":
+		return extractEPUB(&readerAt{r: r}, size)
+	case "pdf":
+		return extractPDF(r, size)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// readerAt menyediakan io.ReaderAt di atas io.ReadSeeker sehingga arsip zip
+// bisa dibaca tanpa memuat seluruh file ke memori
+type readerAt struct {
+	mu sync.Mutex
+	r  io.ReadSeeker
+}
+
+func (ra *readerAt) ReadAt(p []byte, off int64) (int, error) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	if _, err := ra.r.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return io.ReadFull(ra.r, p)
+}
+
+var yearPattern = regexp.MustCompile(`\b(1[5-9]\d\d|2\d\d\d)\b`)
+
+// parseYear mengambil tahun empat digit pertama dari teks tanggal seperti
+// "2019-03-01", "March 2019" atau "D:20190301120000Z"
+func parseYear(value string) int {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "D:")
+	if len(value) >= 4 {
+		if year, err := strconv.Atoi(value[:4]); err == nil && year >= 1500 {
+			return year
+		}
+	}
+
+	if match := yearPattern.FindString(value); match != "" {
+		year, _ := strconv.Atoi(match)
+		return year
+	}
+
+	return 0
+}
+
+var (
+	tagPattern        = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// plainText membuang tag HTML dan merapikan spasi
+func plainText(value string) string {
+	value = tagPattern.ReplaceAllString(value, " ")
+	value = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&#39;", "'", "&nbsp;", " ").Replace(value)
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(value, " "))
+}
@@ -0,0 +1,81 @@
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func assertMetadata(t *testing.T, got, want *Metadata) {
+	t.Helper()
+
+	if got.Title != want.Title || got.Description != want.Description ||
+		got.PublicationYear != want.PublicationYear || got.PageCount != want.PageCount ||
+		got.PageCountEstimated != want.PageCountEstimated || got.CoverContentType != want.CoverContentType {
+		t.Errorf("metadata = %+v, want %+v", withoutCover(got), withoutCover(want))
+	}
+	if !bytes.Equal(got.Cover, want.Cover) {
+		t.Errorf("cover = %q, want %q", got.Cover, want.Cover)
+	}
+}
+
+func withoutCover(meta *Metadata) Metadata {
+	copied := *meta
+	copied.Cover = nil
+	return copied
+}
+
+func TestExtractUnsupportedFormat(t *testing.T) {
+	_, err := Extract("mobi", bytes.NewReader(nil), 0)
+	if !errors.Is(err, ErrUnsupportedFormat) {
+		t.Fatalf("Extract() error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestParseYear(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "2019-03-01", want: 2019},
+		{value: "D:20190301120000Z", want: 2019},
+		{value: "March 1999", want: 1999},
+		{value: "0042-01-01", want: 0},
+		{value: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseYear(tt.value); got != tt.want {
+			t.Errorf("parseYear(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+// FuzzExtract memastikan input sembarang tidak membuat Extract panic atau
+// menghasilkan nilai yang tidak masuk akal
+func FuzzExtract(f *testing.F) {
+	f.Add(true, buildPDF("<< /Info 4 0 R >>", pdfCatalog, pdfPages, pdfPage, "<< /Title (Seed) /CreationDate (D:2001) >>"))
+	f.Add(true, buildPDF("<< /Info 5 0 R >>", pdfCatalog, pdfPages, pdfPage, objectStream("5 0 ", "<< /Title (Packed) >>")))
+	f.Add(true, buildPDF("<< >>", pdfCatalog, pdfPages, pdfPage, jpegImage(600)))
+	f.Add(true, []byte("%PDF-1.4\n1 0 obj\n[[[[<</A [1 2 R]>>]]]]\nendobj\n"))
+	f.Add(false, buildEPUB(f, epubFiles(defaultOPF)))
+	f.Add(false, []byte("PK\x05\x06\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"))
+
+	f.Fuzz(func(t *testing.T, pdf bool, data []byte) {
+		format := "epub"
+		if pdf {
+			format = "pdf"
+		}
+
+		meta, err := Extract(format, bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return
+		}
+		if meta.PageCount < 0 {
+			t.Errorf("PageCount = %d, want >= 0", meta.PageCount)
+		}
+		if len(meta.Cover) > 0 && meta.CoverContentType == "" {
+			t.Error("cover without content type")
+		}
+	})
+}
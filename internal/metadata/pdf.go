@@ -0,0 +1,595 @@
+package metadata
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxPDFSize membatasi ukuran PDF yang dimuat ke memori untuk dibaca
+const maxPDFSize = 200 << 20
+
+// maxPDFNesting membatasi kedalaman array/dictionary bersarang agar PDF yang
+// dibuat khusus tidak menghabiskan stack
+const maxPDFNesting = 64
+
+// maxPDFInflatedSize adalah jumlah total byte yang boleh dihasilkan semua
+// stream terkompresi dalam satu PDF, agar banyak stream kecil yang
+// mengembang besar (zip bomb) tidak menghabiskan memori
+const maxPDFInflatedSize = 50 << 20
+
+type pdfName string
+
+type pdfRef struct {
+	num int
+}
+
+type pdfStream struct {
+	dict map[string]interface{}
+	data []byte
+}
+
+type pdfLocation struct {
+	data []byte
+	pos  int
+}
+
+// pdfDocument adalah pembaca PDF minimal: hanya cukup untuk menemukan
+// objek, dictionary Info, jumlah halaman dan gambar JPEG pertama
+type pdfDocument struct {
+	data    []byte
+	objects map[int]pdfLocation
+	order   []int
+
+	// inflateBudget adalah sisa jatah maxPDFInflatedSize
+	inflateBudget int64
+}
+
+var (
+	errPDFNesting      = errors.New("pdf objects are nested too deeply")
+	errPDFInflateLimit = errors.New("pdf streams decompress to too much data")
+)
+
+var (
+	pdfObjectPattern = regexp.MustCompile(`\b(\d+)\s+(\d+)\s+obj\b`)
+	pdfInfoPattern   = regexp.MustCompile(`/Info\s+(\d+)\s+(\d+)\s+R`)
+)
+
+func extractPDF(r io.ReadSeeker, size int64) (*Metadata, error) {
+	if size > maxPDFSize {
+		return nil, errors.New("pdf is too large to read metadata")
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxPDFSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-")) {
+		return nil, errors.New("not a pdf file")
+	}
+
+	doc := newPDFDocument(data)
+	meta := &Metadata{}
+
+	// With incremental updates the last trailer wins
+	if matches := pdfInfoPattern.FindAllSubmatch(data, -1); len(matches) > 0 {
+		num, _ := strconv.Atoi(string(matches[len(matches)-1][1]))
+		if info, ok := doc.resolve(pdfRef{num: num}).(map[string]interface{}); ok {
+			meta.Title = doc.text(info["Title"])
+			meta.Description = doc.text(info["Subject"])
+			meta.PublicationYear = parseYear(doc.text(info["CreationDate"]))
+		}
+	}
+
+	meta.PageCount = doc.pageCount()
+	meta.Cover = doc.firstJPEG()
+	if meta.Cover != nil {
+		meta.CoverContentType = "image/jpeg"
+	}
+
+	return meta, nil
+}
+
+func newPDFDocument(data []byte) *pdfDocument {
+	doc := &pdfDocument{data: data, objects: make(map[int]pdfLocation), inflateBudget: maxPDFInflatedSize}
+
+	for _, match := range pdfObjectPattern.FindAllSubmatchIndex(data, -1) {
+		num, err := strconv.Atoi(string(data[match[2]:match[3]]))
+		if err != nil {
+			continue
+		}
+		if _, seen := doc.objects[num]; !seen {
+			doc.order = append(doc.order, num)
+		}
+		doc.objects[num] = pdfLocation{data: data, pos: match[1]}
+	}
+
+	// Objects can also be packed inside compressed object streams (PDF 1.5+)
+	for _, num := range append([]int(nil), doc.order...) {
+		stream, ok := doc.object(num).(*pdfStream)
+		if !ok || stream.dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		doc.indexObjectStream(stream)
+	}
+
+	return doc
+}
+
+func (doc *pdfDocument) indexObjectStream(stream *pdfStream) {
+	content, err := doc.decodeStream(stream)
+	if err != nil {
+		return
+	}
+
+	count, _ := stream.dict["N"].(int)
+	first, _ := stream.dict["First"].(int)
+	if first <= 0 || first > len(content) {
+		return
+	}
+
+	header := strings.Fields(string(content[:first]))
+	for i := 0; i+1 < len(header) && i/2 < count; i += 2 {
+		num, err1 := strconv.Atoi(header[i])
+		offset, err2 := strconv.Atoi(header[i+1])
+		if err1 != nil || err2 != nil || offset < 0 || offset >= len(content)-first {
+			continue
+		}
+		if _, seen := doc.objects[num]; !seen {
+			doc.order = append(doc.order, num)
+			doc.objects[num] = pdfLocation{data: content, pos: first + offset}
+		}
+	}
+}
+
+func (doc *pdfDocument) object(num int) interface{} {
+	location, ok := doc.objects[num]
+	if !ok || location.pos < 0 || location.pos > len(location.data) {
+		return nil
+	}
+
+	p := &pdfParser{data: location.data, pos: location.pos}
+	value, err := p.parse(0)
+	if err != nil {
+		return nil
+	}
+
+	dict, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	// A dictionary followed by the "stream" keyword is a stream object
+	p.skipSpace()
+	if !bytes.HasPrefix(p.data[p.pos:], []byte("stream")) {
+		return dict
+	}
+
+	start := p.pos + len("stream")
+	if start < len(p.data) && p.data[start] == '\r' {
+		start++
+	}
+	if start < len(p.data) && p.data[start] == '\n' {
+		start++
+	}
+
+	end := -1
+	if length, ok := dict["Length"].(int); ok && length >= 0 && length <= len(p.data)-start {
+		end = start + length
+	} else if i := bytes.Index(p.data[start:], []byte("endstream")); i >= 0 {
+		end = start + i
+	}
+
+	if end < 0 {
+		return dict
+	}
+
+	return &pdfStream{dict: dict, data: p.data[start:end]}
+}
+
+func (doc *pdfDocument) resolve(value interface{}) interface{} {
+	for depth := 0; depth < 10; depth++ {
+		ref, ok := value.(pdfRef)
+		if !ok {
+			return value
+		}
+		value = doc.object(ref.num)
+	}
+
+	return nil
+}
+
+func (doc *pdfDocument) text(value interface{}) string {
+	raw, ok := doc.resolve(value).([]byte)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(decodePDFString(raw))
+}
+
+// pageCount mengambil /Count terbesar dari node /Pages (yaitu node akar);
+// bila tidak ada, objek /Page dihitung satu per satu
+func (doc *pdfDocument) pageCount() int {
+	maxCount, pages := 0, 0
+	for _, num := range doc.order {
+		dict := doc.dict(num)
+		switch dict["Type"] {
+		case pdfName("Pages"):
+			if count, ok := doc.resolve(dict["Count"]).(int); ok && count > maxCount {
+				maxCount = count
+			}
+		case pdfName("Page"):
+			pages++
+		}
+	}
+
+	if maxCount > 0 {
+		return maxCount
+	}
+
+	return pages
+}
+
+// firstJPEG mengembalikan gambar JPEG (DCTDecode) pertama yang cukup besar
+// untuk dijadikan sampul, mengikuti urutan kemunculan di file
+func (doc *pdfDocument) firstJPEG() []byte {
+	nums := append([]int(nil), doc.order...)
+	sort.SliceStable(nums, func(i, j int) bool {
+		return doc.objects[nums[i]].pos < doc.objects[nums[j]].pos
+	})
+
+	for _, num := range nums {
+		stream, ok := doc.object(num).(*pdfStream)
+		if !ok || stream.dict["Subtype"] != pdfName("Image") {
+			continue
+		}
+
+		filter := doc.resolve(stream.dict["Filter"])
+		if array, ok := filter.([]interface{}); ok && len(array) == 1 {
+			filter = array[0]
+		}
+		if filter != pdfName("DCTDecode") {
+			continue
+		}
+
+		width, _ := doc.resolve(stream.dict["Width"]).(int)
+		if width < 200 || !bytes.HasPrefix(stream.data, []byte{0xFF, 0xD8}) {
+			continue
+		}
+
+		return stream.data
+	}
+
+	return nil
+}
+
+func (doc *pdfDocument) dict(num int) map[string]interface{} {
+	switch value := doc.object(num).(type) {
+	case map[string]interface{}:
+		return value
+	case *pdfStream:
+		return value.dict
+	default:
+		return nil
+	}
+}
+
+// decodeStream membuka stream FlateDecode dengan memakai jatah
+// inflateBudget dokumen; setelah jatah habis, stream berikutnya ditolak
+func (doc *pdfDocument) decodeStream(stream *pdfStream) ([]byte, error) {
+	if stream.dict["Filter"] != pdfName("FlateDecode") {
+		return nil, errors.New("unsupported stream filter")
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(stream.data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, doc.inflateBudget+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > doc.inflateBudget {
+		doc.inflateBudget = 0
+		return nil, errPDFInflateLimit
+	}
+	doc.inflateBudget -= int64(len(content))
+
+	return content, nil
+}
+
+// decodePDFString mengubah string PDF (UTF-16BE dengan BOM, UTF-8 dengan BOM,
+// atau PDFDocEncoding yang sebagian besar sama dengan Latin-1) menjadi UTF-8
+func decodePDFString(raw []byte) string {
+	switch {
+	case bytes.HasPrefix(raw, []byte{0xFE, 0xFF}):
+		units := make([]uint16, 0, len(raw)/2)
+		for i := 2; i+1 < len(raw); i += 2 {
+			units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+		}
+		return string(utf16.Decode(units))
+	case bytes.HasPrefix(raw, []byte{0xEF, 0xBB, 0xBF}):
+		return string(raw[3:])
+	default:
+		runes := make([]rune, len(raw))
+		for i, b := range raw {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+}
+
+type pdfParser struct {
+	data []byte
+	pos  int
+}
+
+func isPDFWhitespace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n' || b == '\f' || b == 0
+}
+
+func isPDFDelimiter(b byte) bool {
+	return strings.IndexByte("()<>[]{}/%", b) >= 0
+}
+
+func (p *pdfParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch {
+		case isPDFWhitespace(p.data[p.pos]):
+			p.pos++
+		case p.data[p.pos] == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// parse membaca satu objek PDF; depth adalah kedalaman array/dictionary
+// tempat objek tersebut berada
+func (p *pdfParser) parse(depth int) (interface{}, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	switch c := p.data[p.pos]; {
+	case bytes.HasPrefix(p.data[p.pos:], []byte("<<")):
+		return p.parseDict(depth + 1)
+	case c == '<':
+		return p.parseHexString()
+	case c == '(':
+		return p.parseLiteralString()
+	case c == '[':
+		return p.parseArray(depth + 1)
+	case c == '/':
+		return p.parseName(), nil
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return p.parseNumberOrRef(), nil
+	default:
+		keyword := p.parseKeyword()
+		switch keyword {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		case "":
+			return nil, errors.New("unexpected character in pdf")
+		default:
+			return pdfName(keyword), nil
+		}
+	}
+}
+
+func (p *pdfParser) parseDict(depth int) (map[string]interface{}, error) {
+	if depth > maxPDFNesting {
+		return nil, errPDFNesting
+	}
+
+	p.pos += 2
+	dict := make(map[string]interface{})
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if bytes.HasPrefix(p.data[p.pos:], []byte(">>")) {
+			p.pos += 2
+			return dict, nil
+		}
+		if p.data[p.pos] != '/' {
+			return nil, errors.New("expected name as pdf dictionary key")
+		}
+		key := p.parseName()
+		value, err := p.parse(depth)
+		if err != nil {
+			return nil, err
+		}
+		dict[string(key)] = value
+	}
+}
+
+func (p *pdfParser) parseArray(depth int) ([]interface{}, error) {
+	if depth > maxPDFNesting {
+		return nil, errPDFNesting
+	}
+
+	p.pos++
+	var array []interface{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if p.data[p.pos] == ']' {
+			p.pos++
+			return array, nil
+		}
+		value, err := p.parse(depth)
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+	}
+}
+
+func (p *pdfParser) parseName() pdfName {
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.data) && !isPDFWhitespace(p.data[p.pos]) && !isPDFDelimiter(p.data[p.pos]) {
+		c := p.data[p.pos]
+		if c == '#' && p.pos+2 < len(p.data) {
+			if value, err := strconv.ParseUint(string(p.data[p.pos+1:p.pos+3]), 16, 8); err == nil {
+				b.WriteByte(byte(value))
+				p.pos += 3
+				continue
+			}
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+	return pdfName(b.String())
+}
+
+func (p *pdfParser) parseKeyword() string {
+	start := p.pos
+	for p.pos < len(p.data) && !isPDFWhitespace(p.data[p.pos]) && !isPDFDelimiter(p.data[p.pos]) {
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+// parseNumberOrRef membaca angka, atau referensi "num gen R"
+func (p *pdfParser) parseNumberOrRef() interface{} {
+	token := p.parseKeyword()
+	number, err := strconv.Atoi(token)
+	if err != nil {
+		value, _ := strconv.ParseFloat(token, 64)
+		return value
+	}
+
+	saved := p.pos
+	p.skipSpace()
+	if gen := p.parseKeyword(); gen != "" {
+		if _, err := strconv.Atoi(gen); err == nil {
+			p.skipSpace()
+			if p.pos < len(p.data) && p.data[p.pos] == 'R' &&
+				(p.pos+1 == len(p.data) || isPDFWhitespace(p.data[p.pos+1]) || isPDFDelimiter(p.data[p.pos+1])) {
+				p.pos++
+				return pdfRef{num: number}
+			}
+		}
+	}
+
+	p.pos = saved
+	return number
+}
+
+func (p *pdfParser) parseHexString() ([]byte, error) {
+	p.pos++
+	var digits []byte
+	for p.pos < len(p.data) && p.data[p.pos] != '>' {
+		if !isPDFWhitespace(p.data[p.pos]) {
+			digits = append(digits, p.data[p.pos])
+		}
+		p.pos++
+	}
+	if p.pos >= len(p.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	p.pos++
+
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	result := make([]byte, len(digits)/2)
+	for i := range result {
+		value, err := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = byte(value)
+	}
+
+	return result, nil
+}
+
+func (p *pdfParser) parseLiteralString() ([]byte, error) {
+	p.pos++
+	var result []byte
+	depth := 1
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '(':
+			depth++
+			result = append(result, c)
+		case ')':
+			depth--
+			if depth == 0 {
+				return result, nil
+			}
+			result = append(result, c)
+		case '\\':
+			if p.pos >= len(p.data) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			escaped := p.data[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				result = append(result, '\n')
+			case 'r':
+				result = append(result, '\r')
+			case 't':
+				result = append(result, '\t')
+			case 'b':
+				result = append(result, '\b')
+			case 'f':
+				result = append(result, '\f')
+			case '\r':
+				if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+					p.pos++
+				}
+			case '\n':
+				// Escaped line break continues the string
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					value := int(escaped - '0')
+					for i := 0; i < 2 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; i++ {
+						value = value*8 + int(p.data[p.pos]-'0')
+						p.pos++
+					}
+					result = append(result, byte(value))
+				} else {
+					result = append(result, escaped)
+				}
+			}
+		default:
+			result = append(result, c)
+		}
+	}
+
+	return nil, io.ErrUnexpectedEOF
+}
@@ -0,0 +1,207 @@
+package metadata
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildPDF menyusun PDF sederhana dari isi objek; objek ke-i bernomor i+1
+func buildPDF(trailer string, objects ...string) []byte {
+	var b strings.Builder
+	b.WriteString("%PDF-1.7\n")
+	for i, object := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	b.WriteString("trailer\n" + trailer + "\n%%EOF\n")
+	return []byte(b.String())
+}
+
+// objectStream membuat objek /ObjStm terkompresi dengan header "num offset"
+// dan isi objek-objeknya
+func objectStream(header, body string) string {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	w.Write([]byte(header + body))
+	w.Close()
+
+	return fmt.Sprintf("<< /Type /ObjStm /N %d /First %d /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+		len(strings.Fields(header))/2, len(header), compressed.Len(), compressed.String())
+}
+
+func jpegImage(width int) string {
+	data := "\xFF\xD8\xFF\xE0fake-jpeg\xFF\xD9"
+	return fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height 400 /Filter /DCTDecode /Length %d >>\nstream\n%s\nendstream",
+		width, len(data), data)
+}
+
+const (
+	pdfCatalog = "<< /Type /Catalog /Pages 2 0 R >>"
+	pdfPages   = "<< /Type /Pages /Kids [3 0 R] /Count 3 >>"
+	pdfPage    = "<< /Type /Page /Parent 2 0 R >>"
+)
+
+func TestExtractPDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    Metadata
+		wantErr bool
+	}{
+		{
+			name: "info dictionary",
+			data: buildPDF("<< /Root 1 0 R /Info 4 0 R >>",
+				pdfCatalog, pdfPages, pdfPage,
+				`<< /Title (Laskar \(Pelangi\)) /Subject (Novel tentang\nsekolah) /CreationDate (D:20050101120000Z) >>`),
+			want: Metadata{Title: "Laskar (Pelangi)", Description: "Novel tentang\nsekolah", PublicationYear: 2005, PageCount: 3},
+		},
+		{
+			name: "utf-16 hex title and indirect subject",
+			data: buildPDF("<< /Info 4 0 R >>",
+				pdfCatalog, pdfPages, pdfPage,
+				"<< /Title <FEFF0042007500630068> /Subject 5 0 R >>",
+				"(Indirect)"),
+			want: Metadata{Title: "Buch", Description: "Indirect", PageCount: 3},
+		},
+		{
+			name: "last trailer wins",
+			data: buildPDF("<< /Info 4 0 R >>\ntrailer\n<< /Info 5 0 R >>",
+				pdfCatalog, pdfPages, pdfPage,
+				"<< /Title (Old) >>", "<< /Title (New) >>"),
+			want: Metadata{Title: "New", PageCount: 3},
+		},
+		{
+			name: "pages counted without page tree count",
+			data: buildPDF("<< >>", pdfCatalog, "<< /Type /Pages >>", pdfPage, pdfPage),
+			want: Metadata{PageCount: 2},
+		},
+		{
+			name: "jpeg cover",
+			data: buildPDF("<< >>", pdfCatalog, pdfPages, pdfPage, jpegImage(100), jpegImage(600)),
+			want: Metadata{PageCount: 3, Cover: []byte("\xFF\xD8\xFF\xE0fake-jpeg\xFF\xD9"), CoverContentType: "image/jpeg"},
+		},
+		{
+			name: "object stream",
+			data: buildPDF("<< /Info 5 0 R >>",
+				pdfCatalog, pdfPages, pdfPage,
+				objectStream("5 0 ", "<< /Title (Packed) >>")),
+			want: Metadata{Title: "Packed", PageCount: 3},
+		},
+		{
+			name: "object stream with negative offset",
+			data: buildPDF("<< /Info 5 0 R >>",
+				pdfCatalog, pdfPages, pdfPage,
+				objectStream("5 -40 ", "<< /Title (Packed) >>")),
+			want: Metadata{PageCount: 3},
+		},
+		{
+			name: "object stream with offset past content",
+			data: buildPDF("<< /Info 5 0 R >>",
+				pdfCatalog, pdfPages, pdfPage,
+				objectStream("5 4000 ", "<< /Title (Packed) >>")),
+			want: Metadata{PageCount: 3},
+		},
+		{
+			name: "stream length overflow",
+			data: buildPDF("<< >>",
+				pdfCatalog, pdfPages, pdfPage,
+				"<< /Subtype /Image /Width 600 /Filter /DCTDecode /Length 9223372036854775807 >>\nstream\n\xFF\xD8data\nendstream"),
+			want: Metadata{PageCount: 3, Cover: []byte("\xFF\xD8data\n"), CoverContentType: "image/jpeg"},
+		},
+		{
+			name: "truncated object",
+			data: buildPDF("<< /Info 4 0 R >>", pdfCatalog, pdfPages, pdfPage, "<< /Title (Unterminated"),
+			want: Metadata{PageCount: 3},
+		},
+		{
+			name:    "not a pdf",
+			data:    []byte("PK\x03\x04 not a pdf"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Extract("pdf", bytes.NewReader(tt.data), int64(len(tt.data)))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Extract() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			assertMetadata(t, got, &tt.want)
+		})
+	}
+}
+
+func TestPDFParserNesting(t *testing.T) {
+	arrays := func(depth int) string {
+		return strings.Repeat("[", depth) + strings.Repeat("]", depth)
+	}
+	dicts := func(depth int) string {
+		return strings.Repeat("<</A ", depth) + "1" + strings.Repeat(">>", depth)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "arrays at limit", input: arrays(maxPDFNesting)},
+		{name: "arrays above limit", input: arrays(maxPDFNesting + 1), wantErr: errPDFNesting},
+		{name: "deeply nested arrays", input: arrays(1 << 20), wantErr: errPDFNesting},
+		{name: "dictionaries at limit", input: dicts(maxPDFNesting)},
+		{name: "dictionaries above limit", input: dicts(maxPDFNesting + 1), wantErr: errPDFNesting},
+		{name: "mixed above limit", input: strings.Repeat("[<</A ", maxPDFNesting), wantErr: errPDFNesting},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &pdfParser{data: []byte(tt.input)}
+			_, err := p.parse(0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExtractPDFDeeplyNested(t *testing.T) {
+	data := buildPDF("<< /Info 1 0 R >>", strings.Repeat("[", 1<<20)+strings.Repeat("]", 1<<20))
+	meta, err := Extract("pdf", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	assertMetadata(t, meta, &Metadata{})
+}
+
+func TestPDFInflateBudget(t *testing.T) {
+	// Each stream stays under the budget on its own; together they exceed it
+	padding := strings.Repeat(" ", maxPDFInflatedSize/2)
+	data := buildPDF("",
+		pdfCatalog,
+		objectStream("10 0 ", "(first)"+padding),
+		objectStream("11 0 ", "(second)"+padding),
+		objectStream("12 0 ", "(third)"),
+	)
+
+	doc := newPDFDocument(data)
+	if got := doc.text(doc.object(10)); got != "first" {
+		t.Errorf("object 10 = %q, want %q", got, "first")
+	}
+	if got := doc.object(11); got != nil {
+		t.Errorf("object 11 = %v, want nil once the budget is exceeded", got)
+	}
+	if got := doc.object(12); got != nil {
+		t.Errorf("object 12 = %v, want nil once the budget is exhausted", got)
+	}
+	if doc.inflateBudget != 0 {
+		t.Errorf("inflateBudget = %d, want 0", doc.inflateBudget)
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"book-management/internal/i18n"
+	"book-management/internal/services"
+	"book-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler menulis respons untuk kesalahan yang diteruskan controller
+// lewat c.Error. Status HTTP ditentukan dari jenis services.Error dan kode
+// kesalahannya dikirim di field code; kesalahan lain dianggap kesalahan
+// internal, dicatat di log dan tidak dikirim ke klien karena bisa memuat
+// detail SQL atau storage.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		var domainErr *services.Error
+		if !errors.As(err, &domainErr) {
+			log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			utils.ErrorResponse(c, http.StatusInternalServerError, utils.CodeInternalError, "Internal server error", nil)
+			return
+		}
+
+		message := domainErr.Message
+		if len(domainErr.Args) > 0 {
+			message = i18n.T(c.GetString("locale"), message, domainErr.Args...)
+		}
+		utils.ErrorResponse(c, errorStatus(domainErr), domainErr.Code, message, domainErr.Detail)
+	}
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrValidation), errors.Is(err, services.ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"book-management/internal/i18n"
+	"book-management/internal/locale"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware menentukan bahasa respons dari parameter ?lang= atau
+// header Accept-Language dan menyimpannya di context sebagai "locale".
+// Bahasa konten yang didukung dan bahasa yang memiliki katalog pesan
+// diterima; selain itu jatuh ke defaultLocale.
+func LocaleMiddleware(supported []string, defaultLocale string) gin.HandlerFunc {
+	locales := append([]string{}, supported...)
+	for _, code := range i18n.Locales() {
+		known := false
+		for _, existing := range locales {
+			if existing == code {
+				known = true
+				break
+			}
+		}
+		if !known {
+			locales = append(locales, code)
+		}
+	}
+
+	return func(c *gin.Context) {
+		code := locale.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"), locales, defaultLocale)
+
+		c.Set("locale", code)
+		c.Writer.Header().Set("Content-Language", code)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
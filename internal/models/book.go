@@ -6,14 +6,17 @@ import (
 
 type Book struct {
 	ID          int       `json:"id" db:"id"`
-	Title       string    `json:"title" db:"title" validate:"required,min=1,max=255"`
+	Title       string    `json:"title" db:"title" validate:"required,book_title"`
 	Description string    `json:"description" db:"description"`
 	ImageURL    string    `json:"image_url" db:"image_url"`
-	ReleaseYear int       `json:"release_year" db:"release_year" validate:"required,min=1980,max=2024"`
-	Price       int       `json:"price" db:"price" validate:"required,min=0"`
-	TotalPage   int       `json:"total_page" db:"total_page" validate:"required,min=1"`
+	ReleaseYear int       `json:"release_year" db:"release_year" validate:"required,release_year"`
+	Price       int       `json:"price" db:"price" validate:"required,book_price"`
+	Currency    string    `json:"currency" db:"currency"`
+	TotalPage   int       `json:"total_page" db:"total_page" validate:"required,total_page"`
 	Thickness   string    `json:"thickness" db:"thickness"`
 	CategoryID  int       `json:"category_id" db:"category_id" validate:"required"`
+	CategoryIDs []int     `json:"category_ids"`
+	Tags        []string  `json:"tags"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	CreatedBy   string    `json:"created_by" db:"created_by"`
 	ModifiedAt  time.Time `json:"modified_at" db:"modified_at"`
@@ -22,34 +25,108 @@ type Book struct {
 
 type BookWithCategory struct {
 	Book
-	CategoryName string `json:"category_name" db:"category_name"`
+	CategoryName string            `json:"category_name" db:"category_name"`
+	Categories   []BookCategory    `json:"categories"`
+	Thumbnails   map[string]string `json:"thumbnails,omitempty"`
+	Availability BookAvailability  `json:"availability"`
+
+	// Locale adalah bahasa judul yang ditampilkan; deskripsi dan nama
+	// kategori tanpa terjemahan tetap dalam bahasa default
+	Locale string `json:"locale,omitempty"`
+
+	// ThicknessLabel adalah label Thickness dalam bahasa request
+	ThicknessLabel string `json:"thickness_label,omitempty"`
+
+	// RatingAverage dan RatingCount dihitung dari ulasan yang tidak disembunyikan
+	RatingAverage float64 `json:"rating_average" db:"rating_average"`
+	RatingCount   int     `json:"rating_count" db:"rating_count"`
+
+	// Work adalah karya yang dimiliki buku ini sebagai salah satu edisinya
+	Work   *WorkSummary      `json:"work"`
+	Series []BookSeriesEntry `json:"series"`
+
+	EffectivePrice *EffectivePrice `json:"effective_price,omitempty"`
+	ConvertedPrice *ConvertedPrice `json:"converted_price,omitempty"`
+}
+
+// ConvertedPrice adalah harga buku yang dikonversi ke mata uang yang diminta
+// klien. Amount dalam minor unit mata uang tersebut (sen untuk USD);
+// EffectiveAmount adalah harga setelah diskon dalam mata uang yang sama.
+type ConvertedPrice struct {
+	Currency         string `json:"currency"`
+	Amount           int64  `json:"amount"`
+	Display          string `json:"display"`
+	EffectiveAmount  int64  `json:"effective_amount"`
+	EffectiveDisplay string `json:"effective_display"`
+	Rate             string `json:"rate"`
+}
+
+// BookViewOptions mengatur tampilan data buku, misalnya mata uang tujuan
+// konversi harga (kosong berarti tanpa konversi) dan bahasa konten (kosong
+// berarti bahasa default)
+type BookViewOptions struct {
+	Currency string
+	Locale   string
+}
+
+// BookCategory adalah kategori yang terhubung ke sebuah buku. Kategori utama
+// adalah kategori yang tersimpan di books.category_id.
+type BookCategory struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// BookThickness adalah data minimal untuk menghitung ulang ketebalan buku
+type BookThickness struct {
+	ID        int    `json:"id"`
+	TotalPage int    `json:"total_page"`
+	Thickness string `json:"thickness"`
+}
+
+// BookFilter berisi kriteria penyaringan daftar buku
+// Sort bernilai "" (urut ID) atau "rating" (rating tertinggi lebih dulu)
+type BookFilter struct {
+	Tags        []string
+	TagMatchAny bool
+	Sort        string
 }
 
+// Price ditulis dalam minor unit mata uangnya (sen untuk USD, rupiah penuh
+// untuk IDR). Currency kosong berarti mata uang default saat create, dan
+// mata uang yang sudah ada saat update.
+//
+// CategoryIDs dan Tags pada request update bersifat opsional: jika tidak
+// dikirim (null) nilai yang sudah ada dipertahankan, sedangkan array kosong
+// menghapus semua kategori tambahan atau tag.
 type CreateBookRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=255"`
-	Description string `json:"description"`
-	ImageURL    string `json:"image_url"`
-	ReleaseYear int    `json:"release_year" validate:"required,min=1980,max=2024"`
-	Price       int    `json:"price" validate:"required,min=0"`
-	TotalPage   int    `json:"total_page" validate:"required,min=1"`
-	CategoryID  int    `json:"category_id" validate:"required"`
+	Title       string   `json:"title" validate:"required,book_title"`
+	Description string   `json:"description"`
+	ImageURL    string   `json:"image_url"`
+	ReleaseYear int      `json:"release_year" validate:"required,release_year"`
+	Price       *int     `json:"price" validate:"required,book_price"`
+	Currency    string   `json:"currency" validate:"omitempty,currency"`
+	TotalPage   int      `json:"total_page" validate:"required,total_page"`
+	CategoryID  int      `json:"category_id" validate:"required"`
+	CategoryIDs []int    `json:"category_ids" validate:"omitempty,dive,min=1"`
+	Tags        []string `json:"tags" validate:"omitempty,dive,min=1,max=50"`
 }
 
 type UpdateBookRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=255"`
-	Description string `json:"description"`
-	ImageURL    string `json:"image_url"`
-	ReleaseYear int    `json:"release_year" validate:"required,min=1980,max=2024"`
-	Price       int    `json:"price" validate:"required,min=0"`
-	TotalPage   int    `json:"total_page" validate:"required,min=1"`
-	CategoryID  int    `json:"category_id" validate:"required"`
+	Title       string   `json:"title" validate:"required,book_title"`
+	Description string   `json:"description"`
+	ImageURL    string   `json:"image_url"`
+	ReleaseYear int      `json:"release_year" validate:"required,release_year"`
+	Price       *int     `json:"price" validate:"required,book_price"`
+	Currency    string   `json:"currency" validate:"omitempty,currency"`
+	TotalPage   int      `json:"total_page" validate:"required,total_page"`
+	CategoryID  int      `json:"category_id" validate:"required"`
+	CategoryIDs []int    `json:"category_ids" validate:"omitempty,dive,min=1"`
+	Tags        []string `json:"tags" validate:"omitempty,dive,min=1,max=50"`
 }
 
 // CalculateThickness menghitung ketebalan buku berdasarkan total halaman
-func (b *Book) CalculateThickness() {
-	if b.TotalPage >= 100 {
-		b.Thickness = "tebal"
-	} else {
-		b.Thickness = "tipis"
-	}
+// menurut skema yang dikonfigurasi
+func (b *Book) CalculateThickness(scheme ThicknessScheme) {
+	b.Thickness = scheme.Classify(b.TotalPage)
 }
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// BookFile adalah file digital (PDF, EPUB, MOBI) yang dilampirkan ke buku
+type BookFile struct {
+	ID             int       `json:"id" db:"id"`
+	BookID         int       `json:"book_id" db:"book_id"`
+	Format         string    `json:"format" db:"format"`
+	FileName       string    `json:"file_name" db:"file_name"`
+	StorageKey     string    `json:"-" db:"storage_key"`
+	ContentType    string    `json:"content_type" db:"content_type"`
+	SizeBytes      int64     `json:"size_bytes" db:"size_bytes"`
+	ChecksumSHA256 string    `json:"checksum_sha256" db:"checksum_sha256"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	CreatedBy      string    `json:"created_by" db:"created_by"`
+}
+
+// FileDownload adalah catatan audit satu permintaan unduhan file
+type FileDownload struct {
+	ID           int       `json:"id" db:"id"`
+	FileID       *int      `json:"file_id" db:"file_id"`
+	BookID       int       `json:"book_id" db:"book_id"`
+	FileName     string    `json:"file_name" db:"file_name"`
+	UserID       *int      `json:"user_id" db:"user_id"`
+	Username     string    `json:"username" db:"username"`
+	RangeHeader  string    `json:"range_header" db:"range_header"`
+	StatusCode   int       `json:"status_code" db:"status_code"`
+	BytesSent    int64     `json:"bytes_sent" db:"bytes_sent"`
+	IPAddress    string    `json:"ip_address" db:"ip_address"`
+	UserAgent    string    `json:"user_agent" db:"user_agent"`
+	DownloadedAt time.Time `json:"downloaded_at" db:"downloaded_at"`
+}
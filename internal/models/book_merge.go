@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DuplicateBook adalah ringkasan buku yang dipakai pendeteksi duplikat
+type DuplicateBook struct {
+	ID          int    `json:"id" db:"id"`
+	Title       string `json:"title" db:"title"`
+	ReleaseYear int    `json:"release_year" db:"release_year"`
+	TotalPage   int    `json:"total_page" db:"total_page"`
+}
+
+// DuplicateCandidate adalah pasangan buku yang kemungkinan merupakan data
+// ganda. Score (0-1) menggabungkan kemiripan judul yang dinormalisasi, tahun
+// terbit dan jumlah halaman.
+type DuplicateCandidate struct {
+	Book       DuplicateBook `json:"book"`
+	Duplicate  DuplicateBook `json:"duplicate"`
+	Score      float64       `json:"score"`
+	TitleScore float64       `json:"title_score"`
+	YearScore  float64       `json:"year_score"`
+	PageScore  float64       `json:"page_score"`
+}
+
+// MergeBookRequest menggabungkan SourceBookID ke buku tujuan. Buku sumber
+// dihapus setelah semua datanya dipindahkan.
+type MergeBookRequest struct {
+	SourceBookID int `json:"source_book_id" validate:"required,min=1"`
+}
+
+// BookMerge adalah catatan audit penggabungan buku. SourceSnapshot berisi
+// data buku sumber sebelum dihapus dan Moved berisi jumlah data yang
+// dipindahkan per jenis.
+type BookMerge struct {
+	ID             int             `json:"id" db:"id"`
+	TargetBookID   int             `json:"target_book_id" db:"target_book_id"`
+	SourceBookID   int             `json:"source_book_id" db:"source_book_id"`
+	SourceTitle    string          `json:"source_title" db:"source_title"`
+	SourceSnapshot json.RawMessage `json:"source_snapshot" db:"source_snapshot"`
+	Moved          map[string]int  `json:"moved" db:"moved"`
+	MergedAt       time.Time       `json:"merged_at" db:"merged_at"`
+	MergedBy       string          `json:"merged_by" db:"merged_by"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// BookMetadataDraft adalah hasil pembacaan metadata file EPUB/PDF dalam bentuk
+// CreateBookRequest yang sudah terisi sebagian. Klien melengkapi field yang
+// kosong (harga, kategori) sebelum menyimpannya.
+type BookMetadataDraft struct {
+	Format             string            `json:"format"`
+	Draft              CreateBookRequest `json:"draft"`
+	PageCountEstimated bool              `json:"page_count_estimated"`
+	HasCover           bool              `json:"has_cover"`
+}
+
+// BookMetadataResult adalah hasil penerapan metadata file ke buku
+type BookMetadataResult struct {
+	Book  *Book      `json:"book"`
+	Cover *BookCover `json:"cover,omitempty"`
+}
@@ -5,18 +5,55 @@ import (
 )
 
 type Category struct {
-	ID         int       `json:"id" db:"id"`
-	Name       string    `json:"name" db:"name" validate:"required,min=1,max=255"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	CreatedBy  string    `json:"created_by" db:"created_by"`
-	ModifiedAt time.Time `json:"modified_at" db:"modified_at"`
-	ModifiedBy string    `json:"modified_by" db:"modified_by"`
+	ID         int                `json:"id" db:"id"`
+	Name       string             `json:"name" db:"name" validate:"required,min=1,max=255"`
+	Slug       string             `json:"slug" db:"slug"`
+	ParentID   *int               `json:"parent_id" db:"parent_id"`
+	Path       []CategoryPathItem `json:"path"`
+	Locale     string             `json:"locale,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" db:"created_at"`
+	CreatedBy  string             `json:"created_by" db:"created_by"`
+	ModifiedAt time.Time          `json:"modified_at" db:"modified_at"`
+	ModifiedBy string             `json:"modified_by" db:"modified_by"`
+}
+
+// CategoryPathItem adalah satu langkah breadcrumb dari kategori akar sampai
+// kategori itu sendiri
+type CategoryPathItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategoryNode adalah kategori beserta sub-kategorinya pada pohon kategori
+type CategoryNode struct {
+	Category
+	Children []CategoryNode `json:"children"`
 }
 
 type CreateCategoryRequest struct {
-	Name string `json:"name" validate:"required,min=1,max=255"`
+	Name     string `json:"name" validate:"required,min=1,max=255"`
+	ParentID *int   `json:"parent_id" validate:"omitempty,min=1"`
 }
 
 type UpdateCategoryRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=255"`
 }
+
+// MoveCategoryRequest memindahkan kategori beserta seluruh sub-kategorinya.
+// ParentID null menjadikan kategori sebagai kategori akar.
+type MoveCategoryRequest struct {
+	ParentID *int `json:"parent_id" validate:"omitempty,min=1"`
+}
+
+type MergeCategoryRequest struct {
+	TargetID int `json:"target_id" validate:"required,min=1"`
+}
+
+// CategoryReassignResult merangkum isi kategori yang dipindahkan ketika
+// kategori dihapus dengan reassign_to atau digabung ke kategori lain
+type CategoryReassignResult struct {
+	SourceID           int  `json:"source_id"`
+	TargetID           *int `json:"target_id"`
+	MovedBooks         int  `json:"moved_books"`
+	MovedSubcategories int  `json:"moved_subcategories"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// CoverImage adalah satu varian sampul buku yang tersimpan: gambar asli
+// ("original") atau thumbnail dengan lebar tertentu (misalnya "w300")
+type CoverImage struct {
+	Variant     string    `json:"variant" db:"variant"`
+	URL         string    `json:"url" db:"url"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Width       int       `json:"width" db:"width"`
+	Height      int       `json:"height" db:"height"`
+	SizeBytes   int64     `json:"size_bytes" db:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	CreatedBy   string    `json:"created_by" db:"created_by"`
+}
+
+type BookCover struct {
+	BookID   int          `json:"book_id"`
+	ImageURL string       `json:"image_url"`
+	Images   []CoverImage `json:"images"`
+}
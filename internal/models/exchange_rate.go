@@ -0,0 +1,26 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ExchangeRate menyatakan kurs 1 BaseCurrency = Rate QuoteCurrency. Rate
+// disimpan sebagai teks desimal agar tidak kehilangan presisi.
+type ExchangeRate struct {
+	BaseCurrency  string    `json:"base_currency" db:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency" db:"quote_currency"`
+	Rate          string    `json:"rate" db:"rate"`
+	Source        string    `json:"source" db:"source"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	UpdatedBy     string    `json:"updated_by" db:"updated_by"`
+}
+
+// SetExchangeRateRequest menerima kurs sebagai angka atau string desimal
+type SetExchangeRateRequest struct {
+	Rate json.Number `json:"rate" validate:"required"`
+}
+
+type ExchangeRateImportResult struct {
+	Imported int `json:"imported"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// Hold adalah antrean anggota untuk sebuah judul. Hold "waiting" menunggu
+// giliran sesuai urutan Position (FIFO); hold "ready" sudah mendapat
+// eksemplar yang disisihkan sampai ExpiresAt. Hold ditutup dengan status
+// "fulfilled", "cancelled" atau "expired".
+type Hold struct {
+	ID         int        `json:"id" db:"id"`
+	BookID     int        `json:"book_id" db:"book_id"`
+	BookTitle  string     `json:"book_title" db:"book_title"`
+	PatronID   int        `json:"patron_id" db:"patron_id"`
+	PatronName string     `json:"patron_name" db:"patron_name"`
+	ItemID     *int       `json:"item_id" db:"item_id"`
+	Barcode    *string    `json:"barcode" db:"barcode"`
+	Status     string     `json:"status" db:"status"`
+	Position   *int       `json:"position"`
+	PlacedAt   time.Time  `json:"placed_at" db:"placed_at"`
+	ReadyAt    *time.Time `json:"ready_at" db:"ready_at"`
+	ExpiresAt  *time.Time `json:"expires_at" db:"expires_at"`
+	ClosedAt   *time.Time `json:"closed_at" db:"closed_at"`
+	CreatedBy  string     `json:"created_by" db:"created_by"`
+	ClosedBy   *string    `json:"closed_by" db:"closed_by"`
+}
+
+type PlaceHoldRequest struct {
+	PatronID int `json:"patron_id" validate:"required,min=1"`
+}
+
+// HoldFilter berisi kriteria penyaringan daftar hold. Status "open" berarti
+// hold yang masih "waiting" atau "ready".
+type HoldFilter struct {
+	BookID   int
+	PatronID int
+	Status   string
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+// Item adalah satu eksemplar fisik dari sebuah judul buku
+type Item struct {
+	ID              int       `json:"id" db:"id"`
+	BookID          int       `json:"book_id" db:"book_id"`
+	BookTitle       string    `json:"book_title" db:"book_title"`
+	Barcode         string    `json:"barcode" db:"barcode"`
+	AcquisitionDate *string   `json:"acquisition_date" db:"acquisition_date"`
+	Condition       string    `json:"condition" db:"condition"`
+	ShelfLocation   string    `json:"shelf_location" db:"shelf_location"`
+	Status          string    `json:"status" db:"status"`
+	Notes           string    `json:"notes" db:"notes"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	CreatedBy       string    `json:"created_by" db:"created_by"`
+	ModifiedAt      time.Time `json:"modified_at" db:"modified_at"`
+	ModifiedBy      string    `json:"modified_by" db:"modified_by"`
+}
+
+// BookAvailability adalah jumlah eksemplar sebuah judul per status
+type BookAvailability struct {
+	Total     int `json:"total"`
+	Available int `json:"available"`
+	OnLoan    int `json:"on_loan"`
+	OnHold    int `json:"on_hold"`
+	Lost      int `json:"lost"`
+	Damaged   int `json:"damaged"`
+}
+
+// AcquisitionDate ditulis dengan format YYYY-MM-DD. Condition default "good"
+// dan Status default "available"; status "on_loan" dan "on_hold" hanya
+// diatur lewat peminjaman dan hold.
+type CreateItemRequest struct {
+	Barcode         string `json:"barcode" validate:"required,min=1,max=50"`
+	AcquisitionDate string `json:"acquisition_date" validate:"omitempty,datetime=2006-01-02"`
+	Condition       string `json:"condition" validate:"omitempty,oneof=new good fair poor"`
+	ShelfLocation   string `json:"shelf_location" validate:"max=100"`
+	Status          string `json:"status" validate:"omitempty,oneof=available lost damaged"`
+	Notes           string `json:"notes"`
+}
+
+// Status eksemplar yang sedang dipinjam atau disisihkan untuk hold tidak
+// dapat diubah secara manual
+type UpdateItemRequest struct {
+	Barcode         string `json:"barcode" validate:"required,min=1,max=50"`
+	AcquisitionDate string `json:"acquisition_date" validate:"omitempty,datetime=2006-01-02"`
+	Condition       string `json:"condition" validate:"required,oneof=new good fair poor"`
+	ShelfLocation   string `json:"shelf_location" validate:"max=100"`
+	Status          string `json:"status" validate:"required,oneof=available on_loan on_hold lost damaged"`
+	Notes           string `json:"notes"`
+}
+
+// ItemFilter berisi kriteria penyaringan daftar eksemplar
+type ItemFilter struct {
+	BookID        int
+	Status        string
+	ShelfLocation string
+}
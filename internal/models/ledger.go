@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// LedgerEntry adalah satu baris buku besar keuangan anggota: "charge"
+// (denda), "payment" (pembayaran) atau "waiver" (penghapusan denda). Amount
+// selalu positif dalam minor unit Currency.
+type LedgerEntry struct {
+	ID         int       `json:"id" db:"id"`
+	PatronID   int       `json:"patron_id" db:"patron_id"`
+	LoanID     *int      `json:"loan_id" db:"loan_id"`
+	EntryType  string    `json:"entry_type" db:"entry_type"`
+	Amount     int       `json:"amount" db:"amount"`
+	Currency   string    `json:"currency" db:"currency"`
+	Note       string    `json:"note" db:"note"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	CreatedBy  string    `json:"created_by" db:"created_by"`
+	ModifiedAt time.Time `json:"modified_at" db:"modified_at"`
+}
+
+// PatronBalance adalah ringkasan buku besar anggota; Balance adalah denda
+// yang belum dibayar atau dihapus
+type PatronBalance struct {
+	PatronID int    `json:"patron_id"`
+	Currency string `json:"currency"`
+	Charges  int    `json:"charges"`
+	Payments int    `json:"payments"`
+	Waivers  int    `json:"waivers"`
+	Balance  int    `json:"balance"`
+}
+
+// LedgerEntryRequest dipakai untuk mencatat pembayaran atau penghapusan
+// denda; Amount tidak boleh melebihi saldo anggota
+type LedgerEntryRequest struct {
+	Amount int    `json:"amount" validate:"required,min=1"`
+	LoanID *int   `json:"loan_id" validate:"omitempty,min=1"`
+	Note   string `json:"note" validate:"max=255"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+)
+
+// Loan adalah peminjaman satu eksemplar oleh satu anggota. Status dihitung
+// dari ReturnedAt dan DueAt: "active", "overdue" atau "returned". Fine adalah
+// denda keterlambatan yang sudah dibebankan, dalam minor unit FineCurrency.
+type Loan struct {
+	ID           int        `json:"id" db:"id"`
+	ItemID       int        `json:"item_id" db:"item_id"`
+	Barcode      string     `json:"barcode" db:"barcode"`
+	BookID       int        `json:"book_id" db:"book_id"`
+	BookTitle    string     `json:"book_title" db:"book_title"`
+	PatronID     int        `json:"patron_id" db:"patron_id"`
+	PatronName   string     `json:"patron_name" db:"patron_name"`
+	CheckedOutAt time.Time  `json:"checked_out_at" db:"checked_out_at"`
+	DueAt        time.Time  `json:"due_at" db:"due_at"`
+	ReturnedAt   *time.Time `json:"returned_at" db:"returned_at"`
+	LoanDays     int        `json:"loan_days" db:"loan_days"`
+	Renewals     int        `json:"renewals" db:"renewals"`
+	MaxRenewals  int        `json:"max_renewals" db:"max_renewals"`
+	Status       string     `json:"status"`
+	Fine         int        `json:"fine"`
+	FinePerDay   int        `json:"fine_per_day" db:"fine_per_day"`
+	FineGrace    int        `json:"fine_grace_days" db:"fine_grace_days"`
+	FineCap      int        `json:"fine_cap" db:"fine_cap"`
+	FineCurrency string     `json:"fine_currency" db:"fine_currency"`
+	CheckedOutBy string     `json:"checked_out_by" db:"checked_out_by"`
+	ReturnedBy   *string    `json:"returned_by" db:"returned_by"`
+}
+
+// CheckoutRequest menunjuk eksemplar lewat ItemID atau Barcode
+type CheckoutRequest struct {
+	PatronID int    `json:"patron_id" validate:"required,min=1"`
+	ItemID   int    `json:"item_id" validate:"omitempty,min=1"`
+	Barcode  string `json:"barcode" validate:"required_without=ItemID,max=50"`
+}
+
+// LoanFilter berisi kriteria penyaringan daftar peminjaman. Status bernilai
+// "active" (belum dikembalikan, termasuk yang terlambat), "overdue" atau
+// "returned".
+type LoanFilter struct {
+	PatronID int
+	ItemID   int
+	Status   string
+}
+
+// LoanPolicy menentukan lama pinjam, batas perpanjangan dan denda
+// keterlambatan. Kebijakan berlaku untuk kategori utama buku; kategori tanpa
+// kebijakan mewarisi kebijakan kategori induk terdekat, lalu nilai default
+// konfigurasi. Aturan denda yang kosong memakai nilai default konfigurasi.
+// Denda per hari dihitung setelah FineGraceDays hari keterlambatan dan
+// dibatasi FineCap (0 berarti tanpa batas).
+type LoanPolicy struct {
+	CategoryID       int        `json:"category_id" db:"category_id"`
+	SourceCategoryID *int       `json:"source_category_id"`
+	LoanDays         int        `json:"loan_days" db:"loan_days"`
+	MaxRenewals      int        `json:"max_renewals" db:"max_renewals"`
+	FinePerDay       *int       `json:"fine_per_day" db:"fine_per_day"`
+	FineGraceDays    *int       `json:"fine_grace_days" db:"fine_grace_days"`
+	FineCap          *int       `json:"fine_cap" db:"fine_cap"`
+	Inherited        bool       `json:"inherited"`
+	ModifiedAt       *time.Time `json:"modified_at,omitempty" db:"modified_at"`
+	ModifiedBy       string     `json:"modified_by,omitempty" db:"modified_by"`
+}
+
+// Denda ditulis dalam minor unit DEFAULT_CURRENCY
+type SetLoanPolicyRequest struct {
+	LoanDays      int  `json:"loan_days" validate:"required,min=1,max=365"`
+	MaxRenewals   int  `json:"max_renewals" validate:"min=0,max=100"`
+	FinePerDay    *int `json:"fine_per_day" validate:"omitempty,min=0"`
+	FineGraceDays *int `json:"fine_grace_days" validate:"omitempty,min=0,max=365"`
+	FineCap       *int `json:"fine_cap" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// Patron adalah anggota perpustakaan yang dapat meminjam eksemplar. Balance
+// adalah denda yang belum dibayar.
+type Patron struct {
+	ID          int       `json:"id" db:"id"`
+	CardNumber  string    `json:"card_number" db:"card_number"`
+	Name        string    `json:"name" db:"name"`
+	Email       string    `json:"email" db:"email"`
+	Phone       string    `json:"phone" db:"phone"`
+	Status      string    `json:"status" db:"status"`
+	ActiveLoans int       `json:"active_loans"`
+	Balance     int       `json:"balance"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	CreatedBy   string    `json:"created_by" db:"created_by"`
+	ModifiedAt  time.Time `json:"modified_at" db:"modified_at"`
+	ModifiedBy  string    `json:"modified_by" db:"modified_by"`
+}
+
+// Status default "active"; anggota "suspended" tidak dapat meminjam
+type PatronRequest struct {
+	CardNumber string `json:"card_number" validate:"required,min=1,max=50"`
+	Name       string `json:"name" validate:"required,min=1,max=255"`
+	Email      string `json:"email" validate:"omitempty,email,max=255"`
+	Phone      string `json:"phone" validate:"max=50"`
+	Status     string `json:"status" validate:"omitempty,oneof=active suspended"`
+}
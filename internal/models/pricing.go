@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+)
+
+// PriceHistory mencatat setiap perubahan harga buku, baik dari update
+// manual maupun dari perubahan harga terjadwal
+type PriceHistory struct {
+	ID          int       `json:"id" db:"id"`
+	BookID      int       `json:"book_id" db:"book_id"`
+	OldPrice    *int      `json:"old_price" db:"old_price"`
+	OldCurrency string    `json:"old_currency" db:"old_currency"`
+	NewPrice    int       `json:"new_price" db:"new_price"`
+	NewCurrency string    `json:"new_currency" db:"new_currency"`
+	Source      string    `json:"source" db:"source"`
+	ChangedAt   time.Time `json:"changed_at" db:"changed_at"`
+	ChangedBy   string    `json:"changed_by" db:"changed_by"`
+}
+
+// Discount berlaku untuk satu buku atau semua buku dalam satu kategori
+// selama StartsAt <= sekarang < EndsAt. Diskon "percentage" memakai
+// Percentage, diskon "fixed" memakai Amount dalam minor unit Currency.
+type Discount struct {
+	ID           int       `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	BookID       *int      `json:"book_id" db:"book_id"`
+	CategoryID   *int      `json:"category_id" db:"category_id"`
+	DiscountType string    `json:"discount_type" db:"discount_type"`
+	Percentage   *float64  `json:"percentage,omitempty" db:"percentage"`
+	Amount       *int      `json:"amount,omitempty" db:"amount"`
+	Currency     string    `json:"currency,omitempty" db:"currency"`
+	StartsAt     time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt       time.Time `json:"ends_at" db:"ends_at"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	CreatedBy    string    `json:"created_by" db:"created_by"`
+	ModifiedAt   time.Time `json:"modified_at" db:"modified_at"`
+	ModifiedBy   string    `json:"modified_by" db:"modified_by"`
+}
+
+type DiscountRequest struct {
+	Name         string    `json:"name" validate:"required,min=1,max=100"`
+	BookID       *int      `json:"book_id" validate:"omitempty,min=1"`
+	CategoryID   *int      `json:"category_id" validate:"omitempty,min=1"`
+	DiscountType string    `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	Percentage   *float64  `json:"percentage" validate:"omitempty,gt=0,lte=100"`
+	Amount       *int      `json:"amount" validate:"omitempty,min=1"`
+	Currency     string    `json:"currency" validate:"omitempty,currency"`
+	StartsAt     time.Time `json:"starts_at" validate:"required"`
+	EndsAt       time.Time `json:"ends_at" validate:"required"`
+}
+
+// DiscountFilter berisi kriteria penyaringan daftar diskon
+type DiscountFilter struct {
+	BookID     int
+	CategoryID int
+	ActiveOnly bool
+}
+
+// EffectivePrice adalah harga setelah diskon aktif terbaik diterapkan. Bila
+// tidak ada diskon aktif, Amount sama dengan harga buku.
+type EffectivePrice struct {
+	Amount       int        `json:"amount"`
+	Currency     string     `json:"currency"`
+	DiscountID   *int       `json:"discount_id,omitempty"`
+	DiscountName string     `json:"discount_name,omitempty"`
+	DiscountEnds *time.Time `json:"discount_ends_at,omitempty"`
+}
+
+type ScheduledPriceChange struct {
+	ID          int        `json:"id" db:"id"`
+	BookID      int        `json:"book_id" db:"book_id"`
+	Price       int        `json:"price" db:"price"`
+	Currency    string     `json:"currency" db:"currency"`
+	EffectiveAt time.Time  `json:"effective_at" db:"effective_at"`
+	Status      string     `json:"status" db:"status"`
+	AppliedAt   *time.Time `json:"applied_at" db:"applied_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	CreatedBy   string     `json:"created_by" db:"created_by"`
+}
+
+type CreateScheduledPriceChangeRequest struct {
+	Price       *int      `json:"price" validate:"required,book_price"`
+	Currency    string    `json:"currency" validate:"omitempty,currency"`
+	EffectiveAt time.Time `json:"effective_at" validate:"required"`
+}
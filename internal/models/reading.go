@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+// ReadingList adalah rak atau daftar bacaan milik seorang pengguna. Rak
+// bawaan (Shelf "want_to_read", "reading", "read") dibuat otomatis; daftar
+// dengan Shelf kosong adalah daftar buatan pengguna. Daftar "public" dapat
+// dilihat pengguna lain, sedangkan ShareToken memberi akses tanpa login
+// lewat tautan berbagi.
+type ReadingList struct {
+	ID          int               `json:"id" db:"id"`
+	UserID      int               `json:"user_id" db:"user_id"`
+	Username    string            `json:"username" db:"username"`
+	Shelf       string            `json:"shelf,omitempty" db:"shelf"`
+	Name        string            `json:"name" db:"name"`
+	Description string            `json:"description" db:"description"`
+	Visibility  string            `json:"visibility" db:"visibility"`
+	ShareToken  string            `json:"share_token,omitempty" db:"share_token"`
+	BookCount   int               `json:"book_count" db:"book_count"`
+	Books       []ReadingListBook `json:"books,omitempty"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+	ModifiedAt  time.Time         `json:"modified_at" db:"modified_at"`
+}
+
+// ReadingListBook adalah buku di dalam daftar bacaan, diurutkan menurut Position
+type ReadingListBook struct {
+	BookID    int       `json:"book_id" db:"book_id"`
+	Title     string    `json:"title" db:"title"`
+	TotalPage int       `json:"total_page" db:"total_page"`
+	Position  int       `json:"position" db:"position"`
+	Note      string    `json:"note" db:"note"`
+	AddedAt   time.Time `json:"added_at" db:"added_at"`
+}
+
+// Visibility default "private". Nama rak bawaan tidak dapat diubah.
+type ReadingListRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=255"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility" validate:"omitempty,oneof=private public"`
+}
+
+type AddReadingListBookRequest struct {
+	BookID int    `json:"book_id" validate:"required,min=1"`
+	Note   string `json:"note"`
+}
+
+// BookIDs berisi seluruh buku di daftar dalam urutan yang baru
+type ReorderReadingListRequest struct {
+	BookIDs []int `json:"book_ids" validate:"required,min=1"`
+}
+
+// ReadingProgress adalah kemajuan membaca seorang pengguna untuk sebuah buku.
+// Percent dihitung dari CurrentPage terhadap TotalPage buku. StartedAt dan
+// FinishedAt ditulis dengan format YYYY-MM-DD.
+type ReadingProgress struct {
+	UserID      int       `json:"user_id" db:"user_id"`
+	BookID      int       `json:"book_id" db:"book_id"`
+	BookTitle   string    `json:"book_title" db:"book_title"`
+	CurrentPage int       `json:"current_page" db:"current_page"`
+	TotalPage   int       `json:"total_page" db:"total_page"`
+	Percent     float64   `json:"percent"`
+	StartedAt   *string   `json:"started_at" db:"started_at"`
+	FinishedAt  *string   `json:"finished_at" db:"finished_at"`
+	ModifiedAt  time.Time `json:"modified_at" db:"modified_at"`
+}
+
+// StartedAt default hari ini saat mulai membaca (CurrentPage > 0) dan
+// FinishedAt default hari ini saat halaman terakhir tercapai
+type ReadingProgressRequest struct {
+	CurrentPage int    `json:"current_page" validate:"min=0"`
+	StartedAt   string `json:"started_at" validate:"omitempty,datetime=2006-01-02"`
+	FinishedAt  string `json:"finished_at" validate:"omitempty,datetime=2006-01-02"`
+}
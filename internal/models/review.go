@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// Review adalah ulasan dan rating (1-5) seorang pengguna untuk sebuah buku.
+// Setiap pengguna hanya memiliki satu ulasan per buku. Ulasan "hidden"
+// disembunyikan moderator dan tidak dihitung dalam rating buku.
+type Review struct {
+	ID               int        `json:"id" db:"id"`
+	BookID           int        `json:"book_id" db:"book_id"`
+	BookTitle        string     `json:"book_title" db:"book_title"`
+	UserID           int        `json:"user_id" db:"user_id"`
+	Username         string     `json:"username" db:"username"`
+	Rating           int        `json:"rating" db:"rating"`
+	Title            string     `json:"title" db:"title"`
+	Body             string     `json:"body" db:"body"`
+	Status           string     `json:"status" db:"status"`
+	ModerationReason string     `json:"moderation_reason,omitempty" db:"moderation_reason"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty" db:"moderated_at"`
+	ModeratedBy      string     `json:"moderated_by,omitempty" db:"moderated_by"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt       time.Time  `json:"modified_at" db:"modified_at"`
+}
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Title  string `json:"title" validate:"max=255"`
+	Body   string `json:"body" validate:"max=10000"`
+}
+
+type ModerateReviewRequest struct {
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+// ReviewFilter berisi kriteria penyaringan daftar ulasan. Status kosong
+// berarti semua status.
+type ReviewFilter struct {
+	BookID int
+	UserID int
+	Status string
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+// Series adalah seri buku dengan nomor volume berurutan. Volumes hanya diisi
+// pada detail seri.
+type Series struct {
+	ID          int            `json:"id" db:"id"`
+	Name        string         `json:"name" db:"name"`
+	Description string         `json:"description" db:"description"`
+	VolumeCount int            `json:"volume_count" db:"volume_count"`
+	Volumes     []SeriesVolume `json:"volumes,omitempty"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	CreatedBy   string         `json:"created_by" db:"created_by"`
+	ModifiedAt  time.Time      `json:"modified_at" db:"modified_at"`
+	ModifiedBy  string         `json:"modified_by" db:"modified_by"`
+}
+
+// SeriesVolume adalah buku di dalam seri, diurutkan menurut VolumeNumber
+type SeriesVolume struct {
+	BookID       int     `json:"book_id" db:"book_id"`
+	Title        string  `json:"title" db:"title"`
+	ReleaseYear  int     `json:"release_year" db:"release_year"`
+	VolumeNumber float64 `json:"volume_number" db:"volume_number"`
+}
+
+// BookSeriesEntry adalah keanggotaan buku dalam sebuah seri beserta volume
+// sebelum dan sesudahnya
+type BookSeriesEntry struct {
+	SeriesID     int           `json:"series_id" db:"series_id"`
+	SeriesName   string        `json:"series_name" db:"series_name"`
+	VolumeNumber float64       `json:"volume_number" db:"volume_number"`
+	Previous     *SeriesVolume `json:"previous,omitempty"`
+	Next         *SeriesVolume `json:"next,omitempty"`
+}
+
+type SeriesRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=255"`
+	Description string `json:"description"`
+}
+
+// VolumeNumber boleh pecahan (misalnya 2.5), dengan paling banyak dua desimal
+type SeriesBookRequest struct {
+	BookID       int     `json:"book_id" validate:"required,min=1"`
+	VolumeNumber float64 `json:"volume_number" validate:"required,gt=0,lt=10000"`
+}
+
+type SeriesVolumeRequest struct {
+	VolumeNumber float64 `json:"volume_number" validate:"required,gt=0,lt=10000"`
+}
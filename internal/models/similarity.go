@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// SimilarBook adalah buku yang mirip dengan sebuah buku beserta skor
+// kemiripannya (0-1). Skor gabungan berasal dari kesamaan kategori, teks
+// judul/deskripsi, kedekatan tahun terbit dan aktivitas pembaca (dipinjam
+// atau diberi rating tinggi oleh orang yang sama). ActivityScore kosong bila
+// kedua buku belum memiliki aktivitas pembaca.
+type SimilarBook struct {
+	BookID        int       `json:"book_id" db:"similar_book_id"`
+	Title         string    `json:"title" db:"title"`
+	ReleaseYear   int       `json:"release_year" db:"release_year"`
+	CategoryID    int       `json:"category_id" db:"category_id"`
+	CategoryName  string    `json:"category_name" db:"category_name"`
+	RatingAverage float64   `json:"rating_average" db:"rating_average"`
+	Score         float64   `json:"score" db:"score"`
+	CategoryScore float64   `json:"category_score" db:"category_score"`
+	TextScore     float64   `json:"text_score" db:"text_score"`
+	YearScore     float64   `json:"year_score" db:"year_score"`
+	ActivityScore *float64  `json:"activity_score" db:"activity_score"`
+	ComputedAt    time.Time `json:"computed_at" db:"computed_at"`
+}
+
+// SimilarityInput adalah data sebuah buku yang dipakai untuk menghitung
+// kemiripan
+type SimilarityInput struct {
+	BookID      int
+	Title       string
+	Description string
+	ReleaseYear int
+	CategoryIDs []int
+	Tags        []string
+}
+
+// SimilarityScore adalah hasil perhitungan kemiripan satu pasang buku
+type SimilarityScore struct {
+	BookID        int
+	SimilarBookID int
+	Score         float64
+	CategoryScore float64
+	TextScore     float64
+	YearScore     float64
+	ActivityScore *float64
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+type Tag struct {
+	ID         int       `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name" validate:"required,min=1,max=50"`
+	BookCount  int       `json:"book_count" db:"book_count"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	CreatedBy  string    `json:"created_by" db:"created_by"`
+	ModifiedAt time.Time `json:"modified_at" db:"modified_at"`
+	ModifiedBy string    `json:"modified_by" db:"modified_by"`
+}
+
+// TagCloudEntry adalah satu tag pada tag cloud. Weight bernilai 1 sampai 5
+// sesuai jumlah buku relatif terhadap tag lain.
+type TagCloudEntry struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Count  int    `json:"count"`
+	Weight int    `json:"weight"`
+}
+
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+type UpdateTagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
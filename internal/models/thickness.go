@@ -0,0 +1,143 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ThicknessBand adalah satu kelas ketebalan dengan rentang halaman
+// MinPages..MaxPages (inklusif). MaxPages 0 berarti tanpa batas atas.
+type ThicknessBand struct {
+	Label    string `json:"label"`
+	MinPages int    `json:"min_pages"`
+	MaxPages int    `json:"max_pages,omitempty"`
+}
+
+// ThicknessScheme adalah daftar kelas ketebalan berurutan yang menutup semua
+// jumlah halaman tanpa celah atau tumpang tindih
+type ThicknessScheme []ThicknessBand
+
+// DefaultThicknessScheme sama dengan aturan lama: tipis di bawah 100 halaman
+var DefaultThicknessScheme = ThicknessScheme{
+	{Label: "tipis", MinPages: 1, MaxPages: 99},
+	{Label: "tebal", MinPages: 100},
+}
+
+var thicknessLabelPattern = regexp.MustCompile(`^[a-z0-9_-]{1,30}$`)
+
+// ParseThicknessScheme membaca skema seperti "tipis:1-99,sedang:100-299,tebal:300-"
+func ParseThicknessScheme(value string) (ThicknessScheme, error) {
+	var scheme ThicknessScheme
+	for _, part := range strings.Split(value, ",") {
+		label, pages, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid thickness band %q, expected label:min-max", part)
+		}
+
+		minStr, maxStr, ok := strings.Cut(strings.TrimSpace(pages), "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid page range in thickness band %q", part)
+		}
+
+		band := ThicknessBand{Label: strings.ToLower(strings.TrimSpace(label))}
+
+		var err error
+		if band.MinPages, err = strconv.Atoi(strings.TrimSpace(minStr)); err != nil {
+			return nil, fmt.Errorf("invalid page range in thickness band %q", part)
+		}
+
+		if maxStr = strings.TrimSpace(maxStr); maxStr != "" {
+			if band.MaxPages, err = strconv.Atoi(maxStr); err != nil {
+				return nil, fmt.Errorf("invalid page range in thickness band %q", part)
+			}
+		}
+
+		scheme = append(scheme, band)
+	}
+
+	if err := scheme.Validate(); err != nil {
+		return nil, err
+	}
+
+	return scheme, nil
+}
+
+// Validate memastikan kelas dimulai dari halaman 1, bersambung tanpa celah,
+// labelnya unik, dan kelas terakhir tidak memiliki batas atas
+func (s ThicknessScheme) Validate() error {
+	if len(s) == 0 {
+		return fmt.Errorf("thickness scheme must have at least one band")
+	}
+
+	labels := make(map[string]bool)
+	for i, band := range s {
+		if !thicknessLabelPattern.MatchString(band.Label) {
+			return fmt.Errorf("invalid thickness label %q", band.Label)
+		}
+
+		if labels[band.Label] {
+			return fmt.Errorf("duplicate thickness label %q", band.Label)
+		}
+		labels[band.Label] = true
+
+		if i == 0 && band.MinPages != 1 {
+			return fmt.Errorf("first thickness band must start at 1 page")
+		}
+
+		if i > 0 && band.MinPages != s[i-1].MaxPages+1 {
+			return fmt.Errorf("thickness band %q must start right after the previous band", band.Label)
+		}
+
+		last := i == len(s)-1
+		if last && band.MaxPages != 0 {
+			return fmt.Errorf("last thickness band must not have an upper limit")
+		}
+
+		if !last && band.MaxPages < band.MinPages {
+			return fmt.Errorf("thickness band %q has an invalid page range", band.Label)
+		}
+	}
+
+	return nil
+}
+
+// Classify mengembalikan label kelas untuk jumlah halaman tertentu
+func (s ThicknessScheme) Classify(totalPage int) string {
+	for _, band := range s {
+		if band.MaxPages == 0 || totalPage <= band.MaxPages {
+			return band.Label
+		}
+	}
+
+	return ""
+}
+
+// Labels mengembalikan label semua kelas sesuai urutan
+func (s ThicknessScheme) Labels() []string {
+	labels := make([]string, len(s))
+	for i, band := range s {
+		labels[i] = band.Label
+	}
+	return labels
+}
+
+func (s ThicknessScheme) String() string {
+	parts := make([]string, len(s))
+	for i, band := range s {
+		if band.MaxPages == 0 {
+			parts[i] = fmt.Sprintf("%s:%d-", band.Label, band.MinPages)
+		} else {
+			parts[i] = fmt.Sprintf("%s:%d-%d", band.Label, band.MinPages, band.MaxPages)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ThicknessRecalculation adalah ringkasan hasil hitung ulang ketebalan buku
+type ThicknessRecalculation struct {
+	TotalBooks   int            `json:"total_books"`
+	ChangedBooks int            `json:"changed_books"`
+	Counts       map[string]int `json:"counts"`
+}
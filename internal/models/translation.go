@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// BookTranslation adalah judul dan deskripsi buku dalam bahasa selain bahasa
+// default. Deskripsi kosong berarti deskripsi bahasa default yang dipakai.
+type BookTranslation struct {
+	BookID      int       `json:"book_id" db:"book_id"`
+	Locale      string    `json:"locale" db:"locale"`
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	CreatedBy   string    `json:"created_by" db:"created_by"`
+	ModifiedAt  time.Time `json:"modified_at" db:"modified_at"`
+	ModifiedBy  string    `json:"modified_by" db:"modified_by"`
+}
+
+// CategoryTranslation adalah nama kategori dalam bahasa selain bahasa default
+type CategoryTranslation struct {
+	CategoryID int       `json:"category_id" db:"category_id"`
+	Locale     string    `json:"locale" db:"locale"`
+	Name       string    `json:"name" db:"name"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	CreatedBy  string    `json:"created_by" db:"created_by"`
+	ModifiedAt time.Time `json:"modified_at" db:"modified_at"`
+	ModifiedBy string    `json:"modified_by" db:"modified_by"`
+}
+
+type BookTranslationRequest struct {
+	Title       string `json:"title" validate:"required,book_title"`
+	Description string `json:"description"`
+}
+
+type CategoryTranslationRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// Work adalah karya yang mengelompokkan berbagai edisi dan terjemahan dari
+// judul yang sama. Editions hanya diisi pada detail karya.
+type Work struct {
+	ID           int           `json:"id" db:"id"`
+	Title        string        `json:"title" db:"title"`
+	Description  string        `json:"description" db:"description"`
+	EditionCount int           `json:"edition_count" db:"edition_count"`
+	Editions     []WorkEdition `json:"editions,omitempty"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	CreatedBy    string        `json:"created_by" db:"created_by"`
+	ModifiedAt   time.Time     `json:"modified_at" db:"modified_at"`
+	ModifiedBy   string        `json:"modified_by" db:"modified_by"`
+}
+
+// WorkEdition adalah buku yang merupakan salah satu edisi sebuah karya
+type WorkEdition struct {
+	BookID      int    `json:"book_id" db:"book_id"`
+	Title       string `json:"title" db:"title"`
+	ReleaseYear int    `json:"release_year" db:"release_year"`
+	TotalPage   int    `json:"total_page" db:"total_page"`
+}
+
+// WorkSummary adalah karya sebuah buku seperti yang ditampilkan pada detail buku
+type WorkSummary struct {
+	ID    int    `json:"id" db:"id"`
+	Title string `json:"title" db:"title"`
+}
+
+type WorkRequest struct {
+	Title       string `json:"title" validate:"required,min=1,max=1000"`
+	Description string `json:"description"`
+}
+
+type WorkBookRequest struct {
+	BookID int `json:"book_id" validate:"required,min=1"`
+}
+
+// BookRelation adalah hubungan antarbuku dilihat dari sisi sebuah buku.
+// RelationType arah keluar adalah "translation_of", "sequel_to" atau
+// "revised_edition_of"; arah masuk ditampilkan sebagai kebalikannya:
+// "translated_as", "prequel_to" atau "revised_as".
+type BookRelation struct {
+	ID               int       `json:"id" db:"id"`
+	RelationType     string    `json:"relation_type" db:"relation_type"`
+	Direction        string    `json:"direction"`
+	RelatedBookID    int       `json:"related_book_id" db:"related_book_id"`
+	RelatedBookTitle string    `json:"related_book_title" db:"related_book_title"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	CreatedBy        string    `json:"created_by" db:"created_by"`
+}
+
+// BookRelationRequest menyatakan bahwa buku ini adalah RelationType dari
+// RelatedBookID, misalnya terjemahan dari buku tersebut
+type BookRelationRequest struct {
+	RelatedBookID int    `json:"related_book_id" validate:"required,min=1"`
+	RelationType  string `json:"relation_type" validate:"required,oneof=translation_of sequel_to revised_edition_of"`
+}
@@ -0,0 +1,403 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	paramPattern    = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(true|false)\s+"([^"]*)"(.*)$`)
+	responsePattern = regexp.MustCompile(`^(\d+)\s+\{(\w+)\}\s+(\S+)(?:\s+"([^"]*)")?$`)
+	routerPattern   = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+	attrPattern     = regexp.MustCompile(`(\w+)\(([^)]*)\)`)
+	typeArgPattern  = regexp.MustCompile(`^([\w.]+)\{(\w+)=([\w.\[\]]+)\}$`)
+)
+
+// Generate menyusun dokumen OpenAPI 3 dari anotasi godoc gaya swag: anotasi
+// umum (@title, @securityDefinitions, ...) di cmd/main.go dan anotasi operasi
+// di internal/controllers. root adalah direktori modul. Schema disusun dari
+// tipe di internal/models dan internal/utils.
+func Generate(root string) ([]byte, error) {
+	types, err := loadTypes(root, filepath.Join("internal", "models"), filepath.Join("internal", "utils"))
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Paths:   make(map[string]PathItem),
+	}
+	if err := parseGeneralInfo(filepath.Join(root, "cmd", "main.go"), doc); err != nil {
+		return nil, err
+	}
+	if err := parseOperations(filepath.Join(root, "internal", "controllers"), types, doc); err != nil {
+		return nil, err
+	}
+	doc.Components.Schemas = types.schemas
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// annotations mengembalikan anotasi dari komentar sebagai pasangan nama dan
+// isi, mis. {"@Router", "/api/books [get]"}
+func annotations(group *ast.CommentGroup) [][2]string {
+	if group == nil {
+		return nil
+	}
+
+	var result [][2]string
+	for _, comment := range group.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		name, value, _ := strings.Cut(line, " ")
+		result = append(result, [2]string{name, strings.TrimSpace(value)})
+	}
+
+	return result
+}
+
+// parseGeneralInfo membaca anotasi umum pada komentar func main
+func parseGeneralInfo(path string, doc *Document) error {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "main" {
+			continue
+		}
+
+		var scheme *SecurityScheme
+		var schemeName string
+		for _, annotation := range annotations(fn.Doc) {
+			name, value := annotation[0], annotation[1]
+			switch {
+			case name == "@title":
+				doc.Info.Title = value
+			case name == "@version":
+				doc.Info.Version = value
+			case name == "@description" && scheme != nil:
+				scheme.Description = value
+			case name == "@description":
+				doc.Info.Description = strings.TrimSpace(doc.Info.Description + "\n" + value)
+			case name == "@securityDefinitions.apikey":
+				if scheme != nil {
+					doc.Components.SecuritySchemes[schemeName] = *scheme
+				}
+				if doc.Components.SecuritySchemes == nil {
+					doc.Components.SecuritySchemes = make(map[string]SecurityScheme)
+				}
+				scheme, schemeName = &SecurityScheme{Type: "apiKey"}, value
+			case name == "@in" && scheme != nil:
+				scheme.In = value
+			case name == "@name" && scheme != nil:
+				scheme.Name = value
+			}
+		}
+		if scheme != nil {
+			doc.Components.SecuritySchemes[schemeName] = *scheme
+		}
+
+		if doc.Info.Title == "" || doc.Info.Version == "" {
+			return fmt.Errorf("%s: @title and @version are required", path)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%s: func main not found", path)
+}
+
+// operationSource adalah handler yang memiliki anotasi @Router
+type operationSource struct {
+	controller string
+	handler    string
+	path       string
+	method     string
+	operation  *Operation
+}
+
+// parseOperations membaca anotasi setiap handler controller. operationId
+// memakai nama handler; nama yang dipakai lebih dari satu controller diberi
+// awalan nama controller-nya.
+func parseOperations(dir string, types *typeRegistry, doc *Document) error {
+	files, err := parseDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var sources []operationSource
+	handlerCount := make(map[string]int)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil {
+				continue
+			}
+
+			source, err := parseOperation(fn, types)
+			if err != nil {
+				return fmt.Errorf("%s: %v", fn.Name.Name, err)
+			}
+			if source == nil {
+				continue
+			}
+			sources = append(sources, *source)
+			handlerCount[source.handler]++
+		}
+	}
+
+	for _, source := range sources {
+		source.operation.OperationID = source.handler
+		if handlerCount[source.handler] > 1 {
+			source.operation.OperationID = strings.TrimSuffix(source.controller, "Controller") + source.handler
+		}
+
+		item, ok := doc.Paths[source.path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[source.path] = item
+		}
+		if _, exists := item[source.method]; exists {
+			return fmt.Errorf("%s: duplicate route %s %s", source.handler, strings.ToUpper(source.method), source.path)
+		}
+		item[source.method] = source.operation
+	}
+
+	return nil
+}
+
+// parseOperation mengubah anotasi satu handler menjadi operasi; handler
+// tanpa @Router dilewati
+func parseOperation(fn *ast.FuncDecl, types *typeRegistry) (*operationSource, error) {
+	source := &operationSource{
+		controller: receiverName(fn),
+		handler:    fn.Name.Name,
+		operation:  &Operation{Responses: make(map[string]Response)},
+	}
+	operation := source.operation
+	consumes := "application/json"
+	produces := "application/json"
+
+	for _, annotation := range annotations(fn.Doc) {
+		name, value := annotation[0], annotation[1]
+		switch name {
+		case "@Summary":
+			operation.Summary = value
+		case "@Description":
+			operation.Description = strings.TrimSpace(operation.Description + "\n" + value)
+		case "@Tags":
+			for _, tag := range strings.Split(value, ",") {
+				operation.Tags = append(operation.Tags, strings.TrimSpace(tag))
+			}
+		case "@Accept":
+			consumes = mimeType(value)
+		case "@Produce":
+			produces = mimeType(value)
+		case "@Security":
+			operation.Security = append(operation.Security, map[string][]string{value: {}})
+		case "@Param":
+			if err := parseParam(value, consumes, types, operation); err != nil {
+				return nil, err
+			}
+		case "@Success", "@Failure":
+			if err := parseResponse(value, produces, types, operation); err != nil {
+				return nil, err
+			}
+		case "@Router":
+			match := routerPattern.FindStringSubmatch(value)
+			if match == nil {
+				return nil, fmt.Errorf("invalid @Router %q", value)
+			}
+			source.path, source.method = match[1], strings.ToLower(match[2])
+		}
+	}
+
+	if source.path == "" {
+		return nil, nil
+	}
+	if len(operation.Responses) == 0 {
+		return nil, fmt.Errorf("no @Success or @Failure for %s", source.path)
+	}
+
+	return source, nil
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// parseParam menangani @Param nama lokasi tipe wajib "deskripsi" [atribut].
+// Parameter body dan formData menjadi requestBody.
+func parseParam(value, consumes string, types *typeRegistry, operation *Operation) error {
+	match := paramPattern.FindStringSubmatch(value)
+	if match == nil {
+		return fmt.Errorf("invalid @Param %q", value)
+	}
+	name, in, typeName, description := match[1], match[2], match[3], match[5]
+	required := match[4] == "true"
+
+	schema, err := types.schemaForName(typeName)
+	if err != nil {
+		return err
+	}
+
+	switch in {
+	case "body":
+		operation.RequestBody = &RequestBody{
+			Description: description,
+			Required:    required,
+			Content:     map[string]MediaType{consumes: {Schema: schema}},
+		}
+		return nil
+	case "formData":
+		if operation.RequestBody == nil {
+			operation.RequestBody = &RequestBody{
+				Content: map[string]MediaType{consumes: {Schema: &Schema{Type: "object", Properties: make(map[string]*Schema)}}},
+			}
+		}
+		form := operation.RequestBody.Content[consumes].Schema
+		schema.Description = description
+		form.Properties[name] = schema
+		if required {
+			form.Required = append(form.Required, name)
+			operation.RequestBody.Required = true
+		}
+		return nil
+	}
+
+	for _, attr := range attrPattern.FindAllStringSubmatch(match[6], -1) {
+		switch attr[1] {
+		case "Enums":
+			for _, enum := range strings.Split(attr[2], ",") {
+				schema.Enum = append(schema.Enum, typedValue(schema.Type, strings.TrimSpace(enum)))
+			}
+		case "default":
+			schema.Default = typedValue(schema.Type, attr[2])
+		}
+	}
+
+	operation.Parameters = append(operation.Parameters, Parameter{
+		Name:        name,
+		In:          in,
+		Description: description,
+		Required:    required || in == "path",
+		Schema:      schema,
+	})
+	return nil
+}
+
+// parseResponse menangani @Success/@Failure kode {jenis} tipe ["deskripsi"].
+// Tipe utils.Response{data=models.X} menjadi utils.Response dengan field data
+// bertipe models.X. Respons kesalahan utils.Response juga dapat dikirim
+// sebagai Problem Details (application/problem+json).
+func parseResponse(value, produces string, types *typeRegistry, operation *Operation) error {
+	match := responsePattern.FindStringSubmatch(value)
+	if match == nil {
+		return fmt.Errorf("invalid response %q", value)
+	}
+	code, kind, typeName, description := match[1], match[2], match[3], match[4]
+
+	status, _ := strconv.Atoi(code)
+	if description == "" {
+		description = http.StatusText(status)
+	}
+	response := Response{Description: description, Content: make(map[string]MediaType)}
+
+	switch kind {
+	case "file":
+		response.Content[produces] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	case "string":
+		response.Content["text/plain"] = MediaType{Schema: &Schema{Type: "string"}}
+	case "object":
+		schema, err := responseSchema(typeName, types)
+		if err != nil {
+			return err
+		}
+		response.Content["application/json"] = MediaType{Schema: schema}
+
+		if status >= 400 && typeName == "utils.Response" {
+			problem, err := types.ref("utils.Problem")
+			if err != nil {
+				return err
+			}
+			response.Content["application/problem+json"] = MediaType{Schema: problem}
+		}
+	default:
+		return fmt.Errorf("unsupported response kind {%s}", kind)
+	}
+
+	operation.Responses[code] = response
+	return nil
+}
+
+func responseSchema(typeName string, types *typeRegistry) (*Schema, error) {
+	match := typeArgPattern.FindStringSubmatch(typeName)
+	if match == nil {
+		return types.schemaForName(typeName)
+	}
+
+	base, err := types.schemaForName(match[1])
+	if err != nil {
+		return nil, err
+	}
+	field, err := types.schemaForName(match[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schema{AllOf: []*Schema{
+		base,
+		{Type: "object", Properties: map[string]*Schema{match[2]: field}},
+	}}, nil
+}
+
+func mimeType(value string) string {
+	switch value {
+	case "json":
+		return "application/json"
+	case "mpfd":
+		return "multipart/form-data"
+	}
+	return value
+}
+
+// Routes mengembalikan daftar "METHOD path" yang terdokumentasi, terurut
+func (d *Document) Routes() []string {
+	var routes []string
+	for path, item := range d.Paths {
+		for method := range item {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
@@ -0,0 +1,367 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// typeRegistry menyimpan deklarasi tipe dari package yang dirujuk anotasi
+// (mis. models.Book) dan menyusun components.schemas hanya untuk tipe yang
+// benar-benar dipakai
+type typeRegistry struct {
+	types   map[string]*ast.TypeSpec
+	docs    map[string]string
+	schemas map[string]*Schema
+}
+
+// loadTypes membaca deklarasi tipe dari direktori package (relatif terhadap
+// root); nama tipe diberi awalan nama package-nya
+func loadTypes(root string, dirs ...string) (*typeRegistry, error) {
+	registry := &typeRegistry{
+		types:   make(map[string]*ast.TypeSpec),
+		docs:    make(map[string]string),
+		schemas: make(map[string]*Schema),
+	}
+
+	for _, dir := range dirs {
+		files, err := parseDir(filepath.Join(root, dir))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					name := file.Name.Name + "." + typeSpec.Name.Name
+					registry.types[name] = typeSpec
+
+					doc := typeSpec.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					registry.docs[name] = commentText(doc)
+				}
+			}
+		}
+	}
+
+	return registry, nil
+}
+
+// parseDir mem-parse semua file Go di dir selain file test, berurutan
+// menurut nama file agar hasilnya stabil
+func parseDir(dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// ref mengembalikan $ref ke tipe bernama (mis. "models.Book") dan
+// mendaftarkan schema-nya di components bila belum ada
+func (r *typeRegistry) ref(name string) (*Schema, error) {
+	if _, ok := r.schemas[name]; !ok {
+		spec, ok := r.types[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %s", name)
+		}
+
+		// Daftarkan lebih dulu agar tipe yang merujuk dirinya sendiri
+		// (mis. CategoryNode.Children) tidak berulang tanpa akhir
+		schema := &Schema{}
+		r.schemas[name] = schema
+
+		pkg := name[:strings.Index(name, ".")]
+		resolved, err := r.schemaFor(spec.Type, pkg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		*schema = *resolved
+		if schema.Description == "" {
+			schema.Description = r.docs[name]
+		}
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}, nil
+}
+
+// schemaForName menyusun schema dari nama tipe di anotasi, mis. "int",
+// "models.Book" atau "[]models.Book"
+func (r *typeRegistry) schemaForName(name string) (*Schema, error) {
+	if strings.HasPrefix(name, "[]") {
+		items, err := r.schemaForName(name[2:])
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	}
+
+	switch name {
+	case "file":
+		return &Schema{Type: "string", Format: "binary"}, nil
+	case "number":
+		return &Schema{Type: "number"}, nil
+	}
+	if schema := primitive(name); schema != nil {
+		return schema, nil
+	}
+
+	return r.ref(name)
+}
+
+func (r *typeRegistry) schemaFor(expr ast.Expr, pkg string) (*Schema, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if schema := primitive(t.Name); schema != nil {
+			return schema, nil
+		}
+		return r.ref(pkg + "." + t.Name)
+	case *ast.StarExpr:
+		schema, err := r.schemaFor(t.X, pkg)
+		if err != nil {
+			return nil, err
+		}
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema, nil
+	case *ast.ArrayType:
+		items, err := r.schemaFor(t.Elt, pkg)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case *ast.MapType:
+		values, err := r.schemaFor(t.Value, pkg)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case *ast.InterfaceType:
+		return &Schema{}, nil
+	case *ast.SelectorExpr:
+		return r.qualifiedSchema(t)
+	case *ast.StructType:
+		return r.structSchema(t, pkg)
+	default:
+		return nil, fmt.Errorf("unsupported type expression %T", expr)
+	}
+}
+
+// qualifiedSchema menangani tipe dari package lain, mis. time.Time
+func (r *typeRegistry) qualifiedSchema(t *ast.SelectorExpr) (*Schema, error) {
+	ident, ok := t.X.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type expression %T", t.X)
+	}
+
+	name := ident.Name + "." + t.Sel.Name
+	switch name {
+	case "time.Time":
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case "json.RawMessage":
+		return &Schema{}, nil
+	case "json.Number":
+		return &Schema{Type: "number"}, nil
+	}
+
+	return r.ref(name)
+}
+
+// structSchema menyusun schema object dari field struct sesuai tag json.
+// Struct yang di-embed tanpa nama json digabung ke object induknya, dan
+// validate:"required" menjadi daftar required.
+func (r *typeRegistry) structSchema(st *ast.StructType, pkg string) (*Schema, error) {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			value, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(value)
+		}
+
+		jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		if len(field.Names) == 0 && jsonName == "" {
+			embedded, err := r.embeddedSchema(field.Type, pkg)
+			if err != nil {
+				return nil, err
+			}
+			for name, property := range embedded.Properties {
+				schema.Properties[name] = property
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(jsonName)}
+		}
+		for _, name := range names {
+			if len(field.Names) > 0 && !ast.IsExported(name.Name) {
+				continue
+			}
+
+			property, err := r.schemaFor(field.Type, pkg)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %v", name.Name, err)
+			}
+			if property.Ref == "" {
+				property.Description = commentText(field.Doc)
+				if property.Description == "" {
+					property.Description = commentText(field.Comment)
+				}
+			}
+
+			propertyName := jsonName
+			if propertyName == "" {
+				propertyName = name.Name
+			}
+			if applyValidation(property, tag.Get("validate")) {
+				schema.Required = append(schema.Required, propertyName)
+			}
+			schema.Properties[propertyName] = property
+		}
+	}
+
+	return schema, nil
+}
+
+// embeddedSchema mengembalikan schema object dari struct yang di-embed
+func (r *typeRegistry) embeddedSchema(expr ast.Expr, pkg string) (*Schema, error) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported embedded field %T", expr)
+	}
+
+	spec, ok := r.types[pkg+"."+ident.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s.%s", pkg, ident.Name)
+	}
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("embedded type %s.%s is not a struct", pkg, ident.Name)
+	}
+
+	return r.structSchema(st, pkg)
+}
+
+// applyValidation menerapkan aturan validate yang bisa dinyatakan di
+// OpenAPI (oneof menjadi enum) dan melaporkan apakah field wajib diisi.
+// Pointer yang wajib diisi menolak null sehingga tidak nullable.
+// Aturan setelah dive berlaku untuk isi slice sehingga diabaikan.
+func applyValidation(schema *Schema, rules string) bool {
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			return required
+		case "required":
+			required = true
+			schema.Nullable = false
+		case "oneof":
+			if schema.Ref != "" {
+				continue
+			}
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, typedValue(schema.Type, value))
+			}
+		}
+	}
+
+	return required
+}
+
+// typedValue mengubah nilai teks dari anotasi menjadi nilai sesuai tipe
+// schema; nilai yang tidak bisa diubah dibiarkan berupa teks
+func typedValue(schemaType, value string) interface{} {
+	switch schemaType {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+
+	return value
+}
+
+func primitive(name string) *Schema {
+	switch name {
+	case "string":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32":
+		return &Schema{Type: "integer"}
+	case "int64", "uint64":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "float32", "float64":
+		return &Schema{Type: "number"}
+	case "any":
+		return &Schema{}
+	}
+
+	return nil
+}
+
+// commentText mengembalikan isi komentar dalam satu baris
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
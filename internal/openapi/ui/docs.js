@@ -0,0 +1,475 @@
+// Self-contained viewer for /openapi.json. It has no external dependencies so
+// /docs works offline and under a strict Content-Security-Policy.
+(function () {
+  "use strict";
+
+  var METHODS = ["get", "post", "put", "patch", "delete"];
+  var spec;
+
+  // The bearer token is only kept in memory so it does not outlive the page
+  var token = "";
+
+  function el(tag, attrs) {
+    var node = document.createElement(tag);
+    Object.keys(attrs || {}).forEach(function (name) {
+      var value = attrs[name];
+      if (value === undefined || value === null || value === false) {
+        return;
+      }
+      if (name === "text") {
+        node.textContent = value;
+      } else if (name === "className") {
+        node.className = value;
+      } else {
+        node.setAttribute(name, value === true ? "" : value);
+      }
+    });
+    for (var i = 2; i < arguments.length; i++) {
+      var child = arguments[i];
+      if (child === undefined || child === null || child === false) {
+        continue;
+      }
+      node.appendChild(typeof child === "string" ? document.createTextNode(child) : child);
+    }
+    return node;
+  }
+
+  function refName(schema) {
+    return schema && schema.$ref ? schema.$ref.replace("#/components/schemas/", "") : "";
+  }
+
+  function resolve(schema) {
+    var name = refName(schema);
+    if (!name) {
+      return schema || {};
+    }
+    return spec.components.schemas[name] || {};
+  }
+
+  // merge flattens allOf into a single object schema
+  function merge(schema) {
+    schema = resolve(schema);
+    if (!schema.allOf) {
+      return schema;
+    }
+    var result = { type: "object", properties: {}, required: [], description: schema.description };
+    schema.allOf.forEach(function (part) {
+      part = merge(part);
+      Object.keys(part.properties || {}).forEach(function (name) {
+        result.properties[name] = part.properties[name];
+      });
+      result.required = result.required.concat(part.required || []);
+      result.description = result.description || part.description;
+    });
+    return result;
+  }
+
+  function typeLabel(schema) {
+    var name = refName(schema);
+    if (name) {
+      return name;
+    }
+    schema = merge(schema);
+    var label;
+    if (schema.type === "array") {
+      label = typeLabel(schema.items || {}) + "[]";
+    } else if (schema.type) {
+      label = schema.type + (schema.format ? " (" + schema.format + ")" : "");
+    } else if (schema.properties) {
+      label = "object";
+    } else {
+      label = "any";
+    }
+    return schema.nullable ? label + ", nullable" : label;
+  }
+
+  function schemaView(schema, depth, seen) {
+    var name = refName(schema);
+    schema = merge(schema);
+    if (schema.type === "array") {
+      return schemaView(schema.items || {}, depth, seen);
+    }
+    if (!schema.properties || depth > 4 || (name && seen.indexOf(name) >= 0)) {
+      return null;
+    }
+    seen = name ? seen.concat(name) : seen;
+
+    var required = schema.required || [];
+    var list = el("ul", { className: "schema" });
+    Object.keys(schema.properties).sort().forEach(function (prop) {
+      var property = schema.properties[prop];
+      var resolved = merge(property);
+      list.appendChild(el("li", null,
+        el("code", { text: prop }), " ",
+        el("span", { className: "type", text: typeLabel(property) }),
+        required.indexOf(prop) >= 0 ? el("span", { className: "required", text: " required" }) : null,
+        resolved.enum ? el("span", { className: "enum", text: " [" + resolved.enum.join(", ") + "]" }) : null,
+        resolved.description ? " — " + resolved.description : null,
+        schemaView(property, depth + 1, seen)
+      ));
+    });
+    return list;
+  }
+
+  function example(schema, depth, seen) {
+    var name = refName(schema);
+    schema = merge(schema);
+    if (schema.default !== undefined) {
+      return schema.default;
+    }
+    if (schema.enum) {
+      return schema.enum[0];
+    }
+    if (depth > 4 || (name && seen.indexOf(name) >= 0)) {
+      return null;
+    }
+    seen = name ? seen.concat(name) : seen;
+
+    switch (schema.type) {
+      case "array":
+        return [example(schema.items || {}, depth + 1, seen)];
+      case "integer":
+      case "number":
+        return 0;
+      case "boolean":
+        return false;
+      case "string":
+        return schema.format === "date-time" ? new Date().toISOString() : "string";
+    }
+    if (schema.properties) {
+      var value = {};
+      Object.keys(schema.properties).forEach(function (prop) {
+        value[prop] = example(schema.properties[prop], depth + 1, seen);
+      });
+      return value;
+    }
+    return null;
+  }
+
+  function contentView(content) {
+    var wrapper = el("div");
+    Object.keys(content || {}).forEach(function (mediaType) {
+      var schema = content[mediaType].schema || {};
+      wrapper.appendChild(el("div", null,
+        el("code", { text: mediaType }), " ",
+        el("span", { className: "type", text: typeLabel(schema) })
+      ));
+      var view = schemaView(schema, 0, []);
+      if (view) {
+        wrapper.appendChild(view);
+      }
+    });
+    return wrapper;
+  }
+
+  function parametersView(parameters) {
+    var table = el("table", null, el("tr", null,
+      el("th", { text: "Name" }), el("th", { text: "In" }), el("th", { text: "Type" }), el("th", { text: "Description" })
+    ));
+    parameters.forEach(function (param) {
+      var schema = param.schema || {};
+      table.appendChild(el("tr", null,
+        el("td", null, el("code", { text: param.name }), param.required ? el("span", { className: "required", text: " required" }) : null),
+        el("td", { text: param.in }),
+        el("td", null,
+          el("span", { className: "type", text: typeLabel(schema) }),
+          schema.enum ? el("span", { className: "enum", text: " [" + schema.enum.join(", ") + "]" }) : null,
+          schema.default !== undefined ? " default " + schema.default : null
+        ),
+        el("td", { text: param.description || "" })
+      ));
+    });
+    return table;
+  }
+
+  function responsesView(responses) {
+    var table = el("table", null, el("tr", null, el("th", { text: "Status" }), el("th", { text: "Description" })));
+    Object.keys(responses).sort().forEach(function (code) {
+      var response = responses[code];
+      table.appendChild(el("tr", null,
+        el("td", null, el("code", { text: code })),
+        el("td", null, response.description, contentView(response.content))
+      ));
+    });
+    return table;
+  }
+
+  function parameterInput(param) {
+    var schema = param.schema || {};
+    if (schema.enum) {
+      var select = el("select", { "data-param": param.name },
+        param.required ? null : el("option", { value: "", text: "" }));
+      schema.enum.forEach(function (value) {
+        select.appendChild(el("option", { value: String(value), text: String(value) }));
+      });
+      if (schema.default !== undefined) {
+        select.value = String(schema.default);
+      }
+      return select;
+    }
+    return el("input", {
+      type: "text",
+      "data-param": param.name,
+      value: schema.default !== undefined ? String(schema.default) : "",
+      placeholder: typeLabel(schema)
+    });
+  }
+
+  function tryItView(path, method, operation) {
+    var parameters = operation.parameters || [];
+    var body = operation.requestBody;
+    var mediaType = body ? Object.keys(body.content)[0] : "";
+    var form = el("form", { className: "try" }, el("h4", { text: "Try it out" }));
+
+    var inputs = {};
+    if (parameters.length) {
+      var table = el("table");
+      parameters.forEach(function (param) {
+        inputs[param.in + ":" + param.name] = parameterInput(param);
+        table.appendChild(el("tr", null,
+          el("td", null, el("code", { text: param.name }), " (" + param.in + ")"),
+          el("td", null, inputs[param.in + ":" + param.name])
+        ));
+      });
+      form.appendChild(table);
+    }
+
+    var bodyInput;
+    var fileInputs = {};
+    if (mediaType === "multipart/form-data") {
+      var fields = merge(body.content[mediaType].schema).properties || {};
+      var fieldTable = el("table");
+      Object.keys(fields).forEach(function (name) {
+        var field = fields[name];
+        fileInputs[name] = el("input", { type: field.format === "binary" ? "file" : "text" });
+        fieldTable.appendChild(el("tr", null,
+          el("td", null, el("code", { text: name })),
+          el("td", null, fileInputs[name])
+        ));
+      });
+      form.appendChild(fieldTable);
+    } else if (mediaType) {
+      bodyInput = el("textarea", { spellcheck: "false" });
+      bodyInput.value = JSON.stringify(example(body.content[mediaType].schema, 0, []), null, 2);
+      form.appendChild(el("div", null, el("code", { text: mediaType }), bodyInput));
+    }
+
+    var output = el("div", { className: "hidden" });
+    form.appendChild(el("p", null, el("button", { type: "submit", className: "primary", text: "Execute" })));
+    form.appendChild(output);
+
+    form.addEventListener("submit", function (event) {
+      event.preventDefault();
+
+      var url = path;
+      var query = [];
+      var headers = {};
+      var missing = [];
+      parameters.forEach(function (param) {
+        var value = inputs[param.in + ":" + param.name].value;
+        if (value === "") {
+          if (param.required) {
+            missing.push(param.name);
+          }
+          return;
+        }
+        if (param.in === "path") {
+          url = url.replace("{" + param.name + "}", encodeURIComponent(value));
+        } else if (param.in === "query") {
+          query.push(encodeURIComponent(param.name) + "=" + encodeURIComponent(value));
+        } else if (param.in === "header") {
+          headers[param.name] = value;
+        }
+      });
+      if (missing.length) {
+        showResult(output, null, "Missing required parameters: " + missing.join(", "));
+        return;
+      }
+      if (query.length) {
+        url += "?" + query.join("&");
+      }
+
+      if (operation.security && token) {
+        headers.Authorization = /^bearer /i.test(token) ? token : "Bearer " + token;
+      }
+
+      var payload;
+      if (bodyInput) {
+        headers["Content-Type"] = mediaType;
+        payload = bodyInput.value;
+      } else if (mediaType === "multipart/form-data") {
+        payload = new FormData();
+        Object.keys(fileInputs).forEach(function (name) {
+          var input = fileInputs[name];
+          if (input.type === "file" && input.files.length) {
+            payload.append(name, input.files[0]);
+          } else if (input.type !== "file" && input.value !== "") {
+            payload.append(name, input.value);
+          }
+        });
+      }
+
+      fetch(url, { method: method.toUpperCase(), headers: headers, body: payload })
+        .then(function (response) {
+          var type = response.headers.get("Content-Type") || "";
+          var read = /json|text/.test(type) ? response.text() : response.blob();
+          return read.then(function (data) {
+            showResult(output, response, data, method.toUpperCase() + " " + url);
+          });
+        })
+        .catch(function (error) {
+          showResult(output, null, String(error));
+        });
+    });
+
+    return form;
+  }
+
+  function showResult(output, response, data, request) {
+    output.textContent = "";
+    output.className = "";
+    if (!response) {
+      output.appendChild(el("pre", { text: data }));
+      return;
+    }
+
+    output.appendChild(el("p", null,
+      el("span", { className: "response-status", text: response.status + " " + response.statusText }), " ",
+      el("code", { text: request })
+    ));
+
+    var type = response.headers.get("Content-Type") || "";
+    if (typeof data === "string") {
+      var text = data;
+      if (/json/.test(type)) {
+        try {
+          text = JSON.stringify(JSON.parse(data), null, 2);
+        } catch (e) {
+          // Show the body as received
+        }
+      }
+      output.appendChild(el("pre", { text: text }));
+    } else {
+      output.appendChild(el("p", null,
+        (type || "binary") + ", " + data.size + " bytes ",
+        el("a", { href: URL.createObjectURL(data), download: "", text: "Download" })
+      ));
+    }
+  }
+
+  function operationView(path, verb, operation) {
+    var details = el("details", {
+      className: "operation",
+      "data-search": (verb + " " + path + " " + (operation.summary || "")).toLowerCase()
+    },
+      el("summary", null,
+        el("span", { className: "method method-" + verb, text: verb }),
+        el("span", { className: "path", text: path }),
+        el("span", { text: operation.summary || "" }),
+        operation.security ? el("span", { className: "lock", text: "requires token" }) : null
+      )
+    );
+
+    // Build the body on first open; the document has more than a hundred operations
+    details.addEventListener("toggle", function () {
+      if (!details.open || details.querySelector(".operation-body")) {
+        return;
+      }
+      var body = el("div", { className: "operation-body" });
+      if (operation.description) {
+        body.appendChild(el("p", { className: "description", text: operation.description }));
+      }
+      if (operation.parameters && operation.parameters.length) {
+        body.appendChild(el("h4", { text: "Parameters" }));
+        body.appendChild(parametersView(operation.parameters));
+      }
+      if (operation.requestBody) {
+        body.appendChild(el("h4", { text: "Request body" + (operation.requestBody.required ? " (required)" : "") }));
+        if (operation.requestBody.description) {
+          body.appendChild(el("p", { text: operation.requestBody.description }));
+        }
+        body.appendChild(contentView(operation.requestBody.content));
+      }
+      body.appendChild(el("h4", { text: "Responses" }));
+      body.appendChild(responsesView(operation.responses));
+      body.appendChild(tryItView(path, verb, operation));
+      details.appendChild(body);
+    });
+
+    return details;
+  }
+
+  function render() {
+    document.title = spec.info.title;
+    document.getElementById("title").textContent = spec.info.title;
+    document.getElementById("version").textContent = "Version " + spec.info.version + " · OpenAPI " + spec.openapi;
+    document.getElementById("description").textContent = spec.info.description || "";
+
+    var groups = {};
+    Object.keys(spec.paths).sort().forEach(function (path) {
+      METHODS.forEach(function (verb) {
+        var operation = spec.paths[path][verb];
+        if (!operation) {
+          return;
+        }
+        var tag = (operation.tags && operation.tags[0]) || "default";
+        (groups[tag] = groups[tag] || []).push(operationView(path, verb, operation));
+      });
+    });
+
+    var content = document.getElementById("content");
+    var nav = document.getElementById("tags");
+    Object.keys(groups).sort().forEach(function (tag) {
+      var section = el("section", { id: "tag-" + tag }, el("h2", { text: tag }));
+      groups[tag].forEach(function (operation) {
+        section.appendChild(operation);
+      });
+      content.appendChild(section);
+      nav.appendChild(el("a", { href: "#tag-" + tag, text: tag }));
+    });
+
+    document.getElementById("filter").addEventListener("input", function (event) {
+      var term = event.target.value.toLowerCase();
+      content.querySelectorAll("section").forEach(function (section) {
+        var visible = 0;
+        section.querySelectorAll("details.operation").forEach(function (operation) {
+          var match = operation.getAttribute("data-search").indexOf(term) >= 0;
+          operation.classList.toggle("hidden", !match);
+          visible += match ? 1 : 0;
+        });
+        section.classList.toggle("hidden", visible === 0);
+      });
+    });
+  }
+
+  function setupAuth() {
+    var input = document.getElementById("token");
+    document.getElementById("auth").addEventListener("submit", function (event) {
+      event.preventDefault();
+      token = input.value.trim();
+    });
+    document.getElementById("clear-token").addEventListener("click", function () {
+      input.value = "";
+      token = "";
+    });
+  }
+
+  document.addEventListener("DOMContentLoaded", function () {
+    setupAuth();
+    fetch("/openapi.json")
+      .then(function (response) {
+        if (!response.ok) {
+          throw new Error("GET /openapi.json returned " + response.status);
+        }
+        return response.json();
+      })
+      .then(function (document_) {
+        spec = document_;
+        document.getElementById("status").remove();
+        render();
+      })
+      .catch(function (error) {
+        document.getElementById("status").textContent = "Failed to load the API document: " + error.message;
+      });
+  });
+})();
@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"book-management/internal/models"
+)
+
+type BookFileRepository struct {
+	db *sql.DB
+}
+
+func NewBookFileRepository(db *sql.DB) *BookFileRepository {
+	return &BookFileRepository{db: db}
+}
+
+const bookFileSelectQuery = `
+		SELECT id, book_id, format, file_name, storage_key, content_type,
+			   size_bytes, checksum_sha256, created_at, created_by
+		FROM book_files
+`
+
+func scanBookFile(row rowScanner) (*models.BookFile, error) {
+	file := &models.BookFile{}
+	err := row.Scan(
+		&file.ID,
+		&file.BookID,
+		&file.Format,
+		&file.FileName,
+		&file.StorageKey,
+		&file.ContentType,
+		&file.SizeBytes,
+		&file.ChecksumSHA256,
+		&file.CreatedAt,
+		&file.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (r *BookFileRepository) GetByBookID(bookID int) ([]models.BookFile, error) {
+	query := bookFileSelectQuery + `
+		WHERE book_id = $1
+		ORDER BY format ASC
+	`
+
+	rows, err := r.db.Query(query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	files := []models.BookFile{}
+	for rows.Next() {
+		file, err := scanBookFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, *file)
+	}
+
+	return files, rows.Err()
+}
+
+func (r *BookFileRepository) GetByID(bookID, fileID int) (*models.BookFile, error) {
+	query := bookFileSelectQuery + `
+		WHERE book_id = $1 AND id = $2
+	`
+
+	file, err := scanBookFile(r.db.QueryRow(query, bookID, fileID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Save menyimpan file baru dan menggantikan file lama dengan format yang sama.
+// Key storage file lama (jika ada) dikembalikan agar bisa dihapus.
+func (r *BookFileRepository) Save(file *models.BookFile) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var oldKey string
+	err = tx.QueryRow(`
+		DELETE FROM book_files WHERE book_id = $1 AND format = $2
+		RETURNING storage_key
+	`, file.BookID, file.Format).Scan(&oldKey)
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO book_files (book_id, format, file_name, storage_key, content_type,
+								size_bytes, checksum_sha256, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`,
+		file.BookID,
+		file.Format,
+		file.FileName,
+		file.StorageKey,
+		file.ContentType,
+		file.SizeBytes,
+		file.ChecksumSHA256,
+		file.CreatedBy,
+	).Scan(&file.ID, &file.CreatedAt)
+	if err != nil {
+		return "", err
+	}
+
+	return oldKey, tx.Commit()
+}
+
+// Delete menghapus file dan mengembalikan key storage-nya
+func (r *BookFileRepository) Delete(bookID, fileID int) (string, error) {
+	var key string
+	err := r.db.QueryRow(`
+		DELETE FROM book_files WHERE book_id = $1 AND id = $2
+		RETURNING storage_key
+	`, bookID, fileID).Scan(&key)
+
+	return key, err
+}
+
+func (r *BookFileRepository) CreateDownload(download *models.FileDownload) error {
+	query := `
+		INSERT INTO book_file_downloads (file_id, book_id, file_name, user_id, username,
+										 range_header, status_code, bytes_sent, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, downloaded_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		download.FileID,
+		download.BookID,
+		download.FileName,
+		download.UserID,
+		download.Username,
+		download.RangeHeader,
+		download.StatusCode,
+		download.BytesSent,
+		download.IPAddress,
+		download.UserAgent,
+	).Scan(&download.ID, &download.DownloadedAt)
+}
+
+func (r *BookFileRepository) GetDownloads(fileID int) ([]models.FileDownload, error) {
+	query := `
+		SELECT id, file_id, book_id, file_name, user_id, username,
+			   COALESCE(range_header, ''), status_code, bytes_sent,
+			   COALESCE(ip_address, ''), COALESCE(user_agent, ''), downloaded_at
+		FROM book_file_downloads
+		WHERE file_id = $1
+		ORDER BY downloaded_at DESC
+	`
+
+	rows, err := r.db.Query(query, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	downloads := []models.FileDownload{}
+	for rows.Next() {
+		var download models.FileDownload
+		var fileIDValue, userID sql.NullInt64
+		err := rows.Scan(
+			&download.ID,
+			&fileIDValue,
+			&download.BookID,
+			&download.FileName,
+			&userID,
+			&download.Username,
+			&download.RangeHeader,
+			&download.StatusCode,
+			&download.BytesSent,
+			&download.IPAddress,
+			&download.UserAgent,
+			&download.DownloadedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if fileIDValue.Valid {
+			value := int(fileIDValue.Int64)
+			download.FileID = &value
+		}
+		if userID.Valid {
+			value := int(userID.Int64)
+			download.UserID = &value
+		}
+		downloads = append(downloads, download)
+	}
+
+	return downloads, rows.Err()
+}
@@ -0,0 +1,455 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"book-management/internal/models"
+)
+
+// mergeStep adalah satu perintah dalam penggabungan buku; key kosong berarti
+// jumlah baris yang terpengaruh tidak dicatat di audit
+type mergeStep struct {
+	key   string
+	query string
+	args  []interface{}
+}
+
+type BookMergeRepository struct {
+	db *sql.DB
+}
+
+func NewBookMergeRepository(db *sql.DB) *BookMergeRepository {
+	return &BookMergeRepository{db: db}
+}
+
+// GetDuplicateInputs mengembalikan ringkasan semua buku untuk pendeteksi duplikat
+func (r *BookMergeRepository) GetDuplicateInputs() ([]models.DuplicateBook, error) {
+	rows, err := r.db.Query(`SELECT id, title, release_year, total_page FROM books ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var books []models.DuplicateBook
+	for rows.Next() {
+		var book models.DuplicateBook
+		if err := rows.Scan(&book.ID, &book.Title, &book.ReleaseYear, &book.TotalPage); err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
+// GetAll mengembalikan riwayat penggabungan terbaru lebih dulu; bookID > 0
+// membatasi pada penggabungan yang melibatkan buku tersebut
+func (r *BookMergeRepository) GetAll(bookID int) ([]models.BookMerge, error) {
+	query := `
+		SELECT id, target_book_id, source_book_id, source_title, source_snapshot, moved, merged_at, merged_by
+		FROM book_merges
+		WHERE $1 = 0 OR target_book_id = $1 OR source_book_id = $1
+		ORDER BY merged_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var merges []models.BookMerge
+	for rows.Next() {
+		var merge models.BookMerge
+		var snapshot, moved []byte
+		err := rows.Scan(
+			&merge.ID,
+			&merge.TargetBookID,
+			&merge.SourceBookID,
+			&merge.SourceTitle,
+			&snapshot,
+			&moved,
+			&merge.MergedAt,
+			&merge.MergedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		merge.SourceSnapshot = json.RawMessage(snapshot)
+		if err := json.Unmarshal(moved, &merge.Moved); err != nil {
+			return nil, err
+		}
+		merges = append(merges, merge)
+	}
+
+	return merges, rows.Err()
+}
+
+// Merge memindahkan semua data buku sumber ke buku tujuan lalu menghapus buku
+// sumber dalam satu transaksi. Data yang bentrok dengan milik buku tujuan
+// diselesaikan sebagai berikut:
+//   - kategori, tag dan isi daftar bacaan digabung tanpa duplikat
+//   - karya buku sumber dipakai bila buku tujuan belum memiliki karya; seri
+//     yang sudah memuat buku tujuan mempertahankan volume buku tujuan
+//   - hubungan antarbuku digabung tanpa duplikat, dan hubungan di antara
+//     kedua buku dibuang
+//   - terjemahan buku tujuan dipertahankan untuk bahasa yang sama
+//   - sampul dan file format yang sama milik buku tujuan dipertahankan
+//   - ulasan dan kemajuan membaca dari pengguna yang sama: yang terbaru dipakai
+//   - hold aktif anggota yang sama: hold tujuan dipertahankan, kecuali hanya
+//     hold sumber yang sudah "ready"
+//   - perubahan harga terjadwal milik buku sumber dibatalkan
+//
+// Merge mengembalikan catatan audit dan storage key milik sampul/file yang
+// dibuang agar dapat dihapus setelah transaksi berhasil.
+func (r *BookMergeRepository) Merge(targetID, sourceID int, snapshot []byte, pickupDays int, username string) (*models.BookMerge, []string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	// Lock both books in ID order so concurrent merges cannot deadlock
+	rows, err := tx.Query(`SELECT id FROM books WHERE id IN ($1, $2) ORDER BY id FOR UPDATE`, targetID, sourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	locked := 0
+	for rows.Next() {
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if locked != 2 {
+		return nil, nil, sql.ErrNoRows
+	}
+
+	now := time.Now()
+	moved := make(map[string]int)
+	exec := func(key, query string, args ...interface{}) error {
+		result, err := tx.Exec(query, args...)
+		if err != nil {
+			return err
+		}
+		if key == "" {
+			return nil
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		moved[key] += int(rowsAffected)
+		return nil
+	}
+
+	// Fill empty fields of the target from the source
+	err = exec("", `
+		UPDATE books t
+		SET description = COALESCE(NULLIF(t.description, ''), s.description),
+			image_url = COALESCE(NULLIF(t.image_url, ''), s.image_url),
+			work_id = COALESCE(t.work_id, s.work_id),
+			modified_at = $3, modified_by = $4
+		FROM books s
+		WHERE t.id = $1 AND s.id = $2
+	`, targetID, sourceID, now, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	steps := []mergeStep{
+		{"categories", `
+			INSERT INTO book_categories (book_id, category_id, created_at, created_by)
+			SELECT $1, category_id, $3, $4 FROM book_categories WHERE book_id = $2
+			ON CONFLICT (book_id, category_id) DO NOTHING
+		`, []interface{}{targetID, sourceID, now, username}},
+		{"tags", `
+			INSERT INTO book_tags (book_id, tag_id, created_at, created_by)
+			SELECT $1, tag_id, $3, $4 FROM book_tags WHERE book_id = $2
+			ON CONFLICT (book_id, tag_id) DO NOTHING
+		`, []interface{}{targetID, sourceID, now, username}},
+		{"covers", `
+			UPDATE book_covers SET book_id = $1
+			WHERE book_id = $2 AND NOT EXISTS (SELECT 1 FROM book_covers WHERE book_id = $1)
+		`, []interface{}{targetID, sourceID}},
+	}
+	for _, step := range steps {
+		if err := exec(step.key, step.query, step.args...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var discarded []string
+	collect := func(query string, args ...interface{}) error {
+		rows, err := tx.Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				return err
+			}
+			discarded = append(discarded, key)
+		}
+		return rows.Err()
+	}
+
+	// Covers left on the source lost to the target's cover
+	if err := collect(`DELETE FROM book_covers WHERE book_id = $1 RETURNING storage_key`, sourceID); err != nil {
+		return nil, nil, err
+	}
+
+	// Files in a format the target already has are dropped
+	err = collect(`
+		DELETE FROM book_files s
+		WHERE s.book_id = $2 AND EXISTS (SELECT 1 FROM book_files t WHERE t.book_id = $1 AND t.format = s.format)
+		RETURNING s.storage_key
+	`, targetID, sourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	steps = []mergeStep{
+		{"files", `UPDATE book_files SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"downloads", `UPDATE book_file_downloads SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"price_history", `UPDATE price_history SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"discounts", `UPDATE discounts SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"", `
+			UPDATE scheduled_price_changes SET status = 'cancelled'
+			WHERE book_id = $1 AND status = 'pending'
+		`, []interface{}{sourceID}},
+		{"scheduled_price_changes", `UPDATE scheduled_price_changes SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"items", `UPDATE items SET book_id = $1, modified_at = $3, modified_by = $4 WHERE book_id = $2`, []interface{}{targetID, sourceID, now, username}},
+	}
+	for _, step := range steps {
+		if err := exec(step.key, step.query, step.args...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	releasedItems, err := mergeHolds(tx, targetID, sourceID, now, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := exec("holds", `UPDATE holds SET book_id = $1 WHERE book_id = $2`, targetID, sourceID); err != nil {
+		return nil, nil, err
+	}
+
+	// Copies set aside for a dropped hold, and available copies gained from
+	// the source, go to the merged queue
+	availableRows, err := tx.Query(`
+		SELECT id FROM items WHERE book_id = $1 AND status = 'available' ORDER BY id FOR UPDATE
+	`, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for availableRows.Next() {
+		var itemID int
+		if err := availableRows.Scan(&itemID); err != nil {
+			availableRows.Close()
+			return nil, nil, err
+		}
+		releasedItems = append(releasedItems, itemID)
+	}
+	availableRows.Close()
+	if err := availableRows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	for _, itemID := range releasedItems {
+		if err := releaseItem(tx, itemID, targetID, now.UTC(), pickupDays, username); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	steps = []mergeStep{
+		// Keep the most recently edited review of a user who reviewed both books
+		{"", `
+			DELETE FROM reviews r
+			USING reviews o
+			WHERE r.book_id IN ($1, $2) AND o.book_id IN ($1, $2) AND r.book_id <> o.book_id
+			  AND r.user_id = o.user_id AND (r.modified_at, r.book_id) < (o.modified_at, o.book_id)
+		`, []interface{}{targetID, sourceID}},
+		{"reviews", `UPDATE reviews SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"", `
+			DELETE FROM reading_list_books s
+			USING reading_list_books t
+			WHERE s.list_id = t.list_id AND s.book_id = $2 AND t.book_id = $1
+		`, []interface{}{targetID, sourceID}},
+		// A book stays on one shelf per user: the target's shelf wins
+		{"", `
+			DELETE FROM reading_list_books s
+			USING reading_lists sl
+			WHERE s.list_id = sl.id AND sl.shelf IS NOT NULL AND s.book_id = $2
+			  AND EXISTS (
+				SELECT 1 FROM reading_list_books t
+				JOIN reading_lists tl ON t.list_id = tl.id
+				WHERE t.book_id = $1 AND tl.user_id = sl.user_id AND tl.shelf IS NOT NULL
+			  )
+		`, []interface{}{targetID, sourceID}},
+		{"reading_lists", `UPDATE reading_list_books SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"", `
+			UPDATE reading_list_books lb SET position = o.position
+			FROM (
+				SELECT list_id, book_id, ROW_NUMBER() OVER (PARTITION BY list_id ORDER BY position, added_at, book_id) AS position
+				FROM reading_list_books
+				WHERE list_id IN (SELECT list_id FROM reading_list_books WHERE book_id = $1)
+			) o
+			WHERE lb.list_id = o.list_id AND lb.book_id = o.book_id AND lb.position <> o.position
+		`, []interface{}{targetID}},
+		{"", `
+			DELETE FROM reading_progress p
+			USING reading_progress o
+			WHERE p.book_id IN ($1, $2) AND o.book_id IN ($1, $2) AND p.book_id <> o.book_id
+			  AND p.user_id = o.user_id AND (p.modified_at, p.book_id) < (o.modified_at, o.book_id)
+		`, []interface{}{targetID, sourceID}},
+		{"reading_progress", `UPDATE reading_progress SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"", `
+			DELETE FROM series_books s
+			USING series_books t
+			WHERE s.series_id = t.series_id AND s.book_id = $2 AND t.book_id = $1
+		`, []interface{}{targetID, sourceID}},
+		{"series", `UPDATE series_books SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		// Relations between the two books would point at the merged book itself
+		{"", `
+			DELETE FROM book_relations
+			WHERE (book_id = $1 AND related_book_id = $2) OR (book_id = $2 AND related_book_id = $1)
+		`, []interface{}{targetID, sourceID}},
+		// Drop source relations the target already has, in either direction
+		{"", `
+			DELETE FROM book_relations s
+			WHERE s.book_id = $2 AND EXISTS (
+				SELECT 1 FROM book_relations t
+				WHERE t.relation_type = s.relation_type
+				  AND ((t.book_id = $1 AND t.related_book_id = s.related_book_id)
+					OR (t.book_id = s.related_book_id AND t.related_book_id = $1))
+			)
+		`, []interface{}{targetID, sourceID}},
+		{"", `
+			DELETE FROM book_relations s
+			WHERE s.related_book_id = $2 AND EXISTS (
+				SELECT 1 FROM book_relations t
+				WHERE t.relation_type = s.relation_type
+				  AND ((t.related_book_id = $1 AND t.book_id = s.book_id)
+					OR (t.related_book_id = s.book_id AND t.book_id = $1))
+			)
+		`, []interface{}{targetID, sourceID}},
+		{"relations", `UPDATE book_relations SET book_id = $1 WHERE book_id = $2`, []interface{}{targetID, sourceID}},
+		{"relations", `UPDATE book_relations SET related_book_id = $1 WHERE related_book_id = $2`, []interface{}{targetID, sourceID}},
+		// Translations the target lacks are kept; the rest cascade with the source
+		{"translations", `
+			UPDATE book_translations SET book_id = $1
+			WHERE book_id = $2 AND locale NOT IN (SELECT locale FROM book_translations WHERE book_id = $1)
+		`, []interface{}{targetID, sourceID}},
+	}
+	for _, step := range steps {
+		if err := exec(step.key, step.query, step.args...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := refreshBookRating(tx, targetID); err != nil {
+		return nil, nil, err
+	}
+
+	// Remaining rows (e.g. precomputed similarities) cascade with the source
+	var sourceTitle string
+	if err := tx.QueryRow(`DELETE FROM books WHERE id = $1 RETURNING title`, sourceID).Scan(&sourceTitle); err != nil {
+		return nil, nil, err
+	}
+
+	movedJSON, err := json.Marshal(moved)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merge := &models.BookMerge{
+		TargetBookID:   targetID,
+		SourceBookID:   sourceID,
+		SourceTitle:    sourceTitle,
+		SourceSnapshot: json.RawMessage(snapshot),
+		Moved:          moved,
+		MergedAt:       now,
+		MergedBy:       username,
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO book_merges (target_book_id, source_book_id, source_title, source_snapshot, moved, merged_at, merged_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, targetID, sourceID, sourceTitle, []byte(snapshot), movedJSON, now, username).Scan(&merge.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return merge, discarded, nil
+}
+
+// mergeHolds menyelesaikan hold aktif anggota yang memegang hold di kedua buku
+// dengan menutup salah satunya. Eksemplar yang disisihkan untuk hold yang
+// ditutup dikembalikan agar dapat diberikan ke antrean berikutnya.
+func mergeHolds(tx *sql.Tx, targetID, sourceID int, now time.Time, username string) ([]int, error) {
+	rows, err := tx.Query(`
+		SELECT s.id, s.status, s.item_id, t.id, t.status, t.item_id
+		FROM holds s
+		JOIN holds t ON t.patron_id = s.patron_id AND t.book_id = $1 AND t.status IN ('waiting', 'ready')
+		WHERE s.book_id = $2 AND s.status IN ('waiting', 'ready')
+		ORDER BY s.id
+		FOR UPDATE
+	`, targetID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	type conflict struct {
+		holdID int
+		itemID sql.NullInt64
+	}
+	var closing []conflict
+	for rows.Next() {
+		var source, target conflict
+		var sourceStatus, targetStatus string
+		if err := rows.Scan(&source.holdID, &sourceStatus, &source.itemID, &target.holdID, &targetStatus, &target.itemID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		if sourceStatus == "ready" && targetStatus == "waiting" {
+			closing = append(closing, target)
+		} else {
+			closing = append(closing, source)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var released []int
+	for _, hold := range closing {
+		_, err := tx.Exec(`
+			UPDATE holds SET status = 'cancelled', closed_at = $1, closed_by = $2 WHERE id = $3
+		`, now.UTC(), username, hold.holdID)
+		if err != nil {
+			return nil, err
+		}
+
+		if hold.itemID.Valid {
+			released = append(released, int(hold.itemID.Int64))
+		}
+	}
+
+	return released, nil
+}
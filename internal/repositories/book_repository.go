@@ -2,11 +2,20 @@ package repositories
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"book-management/internal/models"
+
+	"github.com/lib/pq"
 )
 
+// ErrBookHasLoans dikembalikan ketika buku yang dihapus memiliki eksemplar
+// dengan riwayat peminjaman
+var ErrBookHasLoans = errors.New("book has loans")
+
 type BookRepository struct {
 	db *sql.DB
 }
@@ -15,70 +24,33 @@ func NewBookRepository(db *sql.DB) *BookRepository {
 	return &BookRepository{db: db}
 }
 
-func (r *BookRepository) GetAll() ([]models.BookWithCategory, error) {
-	query := `
+const bookSelectQuery = `
 		SELECT b.id, b.title, b.description, b.image_url, b.release_year, 
-			   b.price, b.total_page, b.thickness, b.category_id,
+			   b.price, b.currency, b.total_page, b.thickness, b.category_id,
 			   b.created_at, b.created_by, b.modified_at, b.modified_by,
-			   c.name as category_name
+			   c.name as category_name, b.rating_average, b.rating_count,
+			   b.work_id, w.title
 		FROM books b
 		JOIN categories c ON b.category_id = c.id
-		ORDER BY b.id ASC
-	`
+		LEFT JOIN works w ON b.work_id = w.id
+`
 
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var books []models.BookWithCategory
-	for rows.Next() {
-		var book models.BookWithCategory
-		err := rows.Scan(
-			&book.ID,
-			&book.Title,
-			&book.Description,
-			&book.ImageURL,
-			&book.ReleaseYear,
-			&book.Price,
-			&book.TotalPage,
-			&book.Thickness,
-			&book.CategoryID,
-			&book.CreatedAt,
-			&book.CreatedBy,
-			&book.ModifiedAt,
-			&book.ModifiedBy,
-			&book.CategoryName,
-		)
-		if err != nil {
-			return nil, err
-		}
-		books = append(books, book)
-	}
-
-	return books, nil
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
-func (r *BookRepository) GetByID(id int) (*models.BookWithCategory, error) {
-	query := `
-		SELECT b.id, b.title, b.description, b.image_url, b.release_year, 
-			   b.price, b.total_page, b.thickness, b.category_id,
-			   b.created_at, b.created_by, b.modified_at, b.modified_by,
-			   c.name as category_name
-		FROM books b
-		JOIN categories c ON b.category_id = c.id
-		WHERE b.id = $1
-	`
-
+func scanBookWithCategory(row rowScanner) (*models.BookWithCategory, error) {
 	book := &models.BookWithCategory{}
-	err := r.db.QueryRow(query, id).Scan(
+	var workID sql.NullInt64
+	var workTitle sql.NullString
+	err := row.Scan(
 		&book.ID,
 		&book.Title,
 		&book.Description,
 		&book.ImageURL,
 		&book.ReleaseYear,
 		&book.Price,
+		&book.Currency,
 		&book.TotalPage,
 		&book.Thickness,
 		&book.CategoryID,
@@ -87,8 +59,250 @@ func (r *BookRepository) GetByID(id int) (*models.BookWithCategory, error) {
 		&book.ModifiedAt,
 		&book.ModifiedBy,
 		&book.CategoryName,
+		&book.RatingAverage,
+		&book.RatingCount,
+		&workID,
+		&workTitle,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if workID.Valid {
+		book.Work = &models.WorkSummary{ID: int(workID.Int64), Title: workTitle.String}
+	}
 
+	return book, nil
+}
+
+func queryBooks(db *sql.DB, query string, args ...interface{}) ([]models.BookWithCategory, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var books []models.BookWithCategory
+	for rows.Next() {
+		book, err := scanBookWithCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, *book)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadBookRelations(db, books); err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// loadBookRelations mengisi kategori, tag, seri, thumbnail sampul dan jumlah
+// eksemplar yang terhubung ke setiap buku
+func loadBookRelations(db *sql.DB, books []models.BookWithCategory) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(books))
+	index := make(map[int]int, len(books))
+	for i := range books {
+		ids[i] = int64(books[i].ID)
+		index[books[i].ID] = i
+		books[i].CategoryIDs = []int{}
+		books[i].Categories = []models.BookCategory{}
+		books[i].Tags = []string{}
+		books[i].Series = []models.BookSeriesEntry{}
+	}
+
+	categoryRows, err := db.Query(`
+		SELECT bc.book_id, c.id, c.name
+		FROM book_categories bc
+		JOIN categories c ON bc.category_id = c.id
+		WHERE bc.book_id = ANY($1)
+		ORDER BY bc.book_id, c.name ASC
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer categoryRows.Close()
+
+	for categoryRows.Next() {
+		var bookID int
+		var category models.BookCategory
+		if err := categoryRows.Scan(&bookID, &category.ID, &category.Name); err != nil {
+			return err
+		}
+		book := &books[index[bookID]]
+		category.IsPrimary = category.ID == book.CategoryID
+		book.CategoryIDs = append(book.CategoryIDs, category.ID)
+		book.Categories = append(book.Categories, category)
+	}
+
+	if err := categoryRows.Err(); err != nil {
+		return err
+	}
+
+	tagRows, err := db.Query(`
+		SELECT bt.book_id, t.name
+		FROM book_tags bt
+		JOIN tags t ON bt.tag_id = t.id
+		WHERE bt.book_id = ANY($1)
+		ORDER BY bt.book_id, t.name ASC
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var bookID int
+		var name string
+		if err := tagRows.Scan(&bookID, &name); err != nil {
+			return err
+		}
+		book := &books[index[bookID]]
+		book.Tags = append(book.Tags, name)
+	}
+
+	if err := tagRows.Err(); err != nil {
+		return err
+	}
+
+	seriesRows, err := db.Query(`
+		SELECT sb.book_id, s.id, s.name, sb.volume_number
+		FROM series_books sb
+		JOIN series s ON sb.series_id = s.id
+		WHERE sb.book_id = ANY($1)
+		ORDER BY sb.book_id, s.name ASC
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer seriesRows.Close()
+
+	for seriesRows.Next() {
+		var bookID int
+		var entry models.BookSeriesEntry
+		if err := seriesRows.Scan(&bookID, &entry.SeriesID, &entry.SeriesName, &entry.VolumeNumber); err != nil {
+			return err
+		}
+		book := &books[index[bookID]]
+		book.Series = append(book.Series, entry)
+	}
+
+	if err := seriesRows.Err(); err != nil {
+		return err
+	}
+
+	thumbnailRows, err := db.Query(`
+		SELECT book_id, variant, url
+		FROM book_covers
+		WHERE book_id = ANY($1) AND variant <> 'original'
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer thumbnailRows.Close()
+
+	for thumbnailRows.Next() {
+		var bookID int
+		var variant, url string
+		if err := thumbnailRows.Scan(&bookID, &variant, &url); err != nil {
+			return err
+		}
+		book := &books[index[bookID]]
+		if book.Thumbnails == nil {
+			book.Thumbnails = make(map[string]string)
+		}
+		book.Thumbnails[variant] = url
+	}
+
+	if err := thumbnailRows.Err(); err != nil {
+		return err
+	}
+
+	itemRows, err := db.Query(`
+		SELECT book_id, status, COUNT(*)
+		FROM items
+		WHERE book_id = ANY($1)
+		GROUP BY book_id, status
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var bookID, count int
+		var status string
+		if err := itemRows.Scan(&bookID, &status, &count); err != nil {
+			return err
+		}
+		availability := &books[index[bookID]].Availability
+		availability.Total += count
+		switch status {
+		case "available":
+			availability.Available = count
+		case "on_loan":
+			availability.OnLoan = count
+		case "on_hold":
+			availability.OnHold = count
+		case "lost":
+			availability.Lost = count
+		case "damaged":
+			availability.Damaged = count
+		}
+	}
+
+	return itemRows.Err()
+}
+
+func (r *BookRepository) GetAll(filter models.BookFilter) ([]models.BookWithCategory, error) {
+	var conditions []string
+	var args []interface{}
+
+	if len(filter.Tags) > 0 {
+		names := make([]string, len(filter.Tags))
+		for i, tag := range filter.Tags {
+			names[i] = strings.ToLower(tag)
+		}
+		args = append(args, pq.Array(names))
+		condition := fmt.Sprintf(`b.id IN (
+			SELECT bt.book_id
+			FROM book_tags bt
+			JOIN tags t ON bt.tag_id = t.id
+			WHERE LOWER(t.name) = ANY($%d)
+			GROUP BY bt.book_id`, len(args))
+		if !filter.TagMatchAny {
+			args = append(args, len(names))
+			condition += fmt.Sprintf(` HAVING COUNT(DISTINCT t.id) = $%d`, len(args))
+		}
+		conditions = append(conditions, condition+")")
+	}
+
+	query := bookSelectQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if filter.Sort == "rating" {
+		query += " ORDER BY b.rating_average DESC, b.rating_count DESC, b.id ASC"
+	} else {
+		query += " ORDER BY b.id ASC"
+	}
+
+	return queryBooks(r.db, query, args...)
+}
+
+func (r *BookRepository) GetByID(id int) (*models.BookWithCategory, error) {
+	query := bookSelectQuery + ` WHERE b.id = $1`
+
+	book, err := scanBookWithCategory(r.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -96,72 +310,190 @@ func (r *BookRepository) GetByID(id int) (*models.BookWithCategory, error) {
 		return nil, err
 	}
 
-	return book, nil
+	books := []models.BookWithCategory{*book}
+	if err := loadBookRelations(r.db, books); err != nil {
+		return nil, err
+	}
+
+	return &books[0], nil
 }
 
 func (r *BookRepository) Create(book *models.Book) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO books (title, description, image_url, release_year, price, 
+		INSERT INTO books (title, description, image_url, release_year, price, currency,
 						  total_page, thickness, category_id, created_by, modified_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, created_at, modified_at
 	`
 
-	err := r.db.QueryRow(
+	err = tx.QueryRow(
 		query,
 		book.Title,
 		book.Description,
 		book.ImageURL,
 		book.ReleaseYear,
 		book.Price,
+		book.Currency,
 		book.TotalPage,
 		book.Thickness,
 		book.CategoryID,
 		book.CreatedBy,
 		book.ModifiedBy,
 	).Scan(&book.ID, &book.CreatedAt, &book.ModifiedAt)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := setBookCategories(tx, book.ID, book.CategoryIDs, book.CreatedBy); err != nil {
+		return err
+	}
+
+	if err := setBookTags(tx, book.ID, book.Tags, book.CreatedBy); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *BookRepository) Update(book *models.Book) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the row and keep the old price for the price history
+	var oldPrice sql.NullInt64
+	var oldCurrency string
+	err = tx.QueryRow(`SELECT price, currency FROM books WHERE id = $1 FOR UPDATE`, book.ID).Scan(&oldPrice, &oldCurrency)
+	if err != nil {
+		return err
+	}
+
+	// A stored cover owns image_url; it only changes through the cover endpoints
 	query := `
 		UPDATE books 
-		SET title = $1, description = $2, image_url = $3, release_year = $4,
-			price = $5, total_page = $6, thickness = $7, category_id = $8,
-			modified_by = $9, modified_at = $10
-		WHERE id = $11
+		SET title = $1, description = $2,
+			image_url = CASE WHEN EXISTS (SELECT 1 FROM book_covers WHERE book_id = $12) THEN image_url ELSE $3 END,
+			release_year = $4, price = $5, currency = $6, total_page = $7, thickness = $8, category_id = $9,
+			modified_by = $10, modified_at = $11
+		WHERE id = $12
+		RETURNING image_url
 	`
 
 	book.ModifiedAt = time.Now()
-	result, err := r.db.Exec(
+	err = tx.QueryRow(
 		query,
 		book.Title,
 		book.Description,
 		book.ImageURL,
 		book.ReleaseYear,
 		book.Price,
+		book.Currency,
 		book.TotalPage,
 		book.Thickness,
 		book.CategoryID,
 		book.ModifiedBy,
 		book.ModifiedAt,
 		book.ID,
-	)
+	).Scan(&book.ImageURL)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	err = recordPriceChange(tx, book.ID, oldPrice, oldCurrency, book.Price, book.Currency, "manual", book.ModifiedBy)
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
+	if err := setBookCategories(tx, book.ID, book.CategoryIDs, book.ModifiedBy); err != nil {
+		return err
 	}
 
-	return nil
+	// Tags are left untouched when the request did not include them
+	if book.Tags != nil {
+		if err := setBookTags(tx, book.ID, book.Tags, book.ModifiedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetStorageKeys mengembalikan key semua file tersimpan milik buku agar bisa
+// dihapus dari storage setelah bukunya dihapus
+func (r *BookRepository) GetStorageKeys(id int) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT storage_key FROM book_covers WHERE book_id = $1
+		UNION ALL
+		SELECT storage_key FROM book_files WHERE book_id = $1
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *BookRepository) GetThicknesses() ([]models.BookThickness, error) {
+	query := `SELECT id, COALESCE(total_page, 0), COALESCE(thickness, '') FROM books ORDER BY id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var books []models.BookThickness
+	for rows.Next() {
+		var book models.BookThickness
+		if err := rows.Scan(&book.ID, &book.TotalPage, &book.Thickness); err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
+// UpdateThicknesses menyimpan ketebalan baru per ID buku dalam satu transaksi.
+// Kolom modified_* tidak diubah karena isi buku tidak berubah.
+func (r *BookRepository) UpdateThicknesses(thicknesses map[int]string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE books SET thickness = $1 WHERE id = $2`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for id, thickness := range thicknesses {
+		if _, err := stmt.Exec(thickness, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (r *BookRepository) Delete(id int) error {
@@ -169,6 +501,10 @@ func (r *BookRepository) Delete(id int) error {
 
 	result, err := r.db.Exec(query, id)
 	if err != nil {
+		// Items cascade with the book, but their loans must be kept
+		if isForeignKeyViolation(err) {
+			return ErrBookHasLoans
+		}
 		return err
 	}
 
@@ -184,14 +520,74 @@ func (r *BookRepository) Delete(id int) error {
 	return nil
 }
 
-func (r *BookRepository) CheckCategoryExists(categoryID int) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1)`
+// CheckCategoriesExist memastikan semua ID kategori (tanpa duplikat) ada
+func (r *BookRepository) CheckCategoriesExist(categoryIDs []int) (bool, error) {
+	query := `SELECT COUNT(*) FROM categories WHERE id = ANY($1)`
 
-	var exists bool
-	err := r.db.QueryRow(query, categoryID).Scan(&exists)
+	var count int
+	err := r.db.QueryRow(query, pq.Array(toInt64s(categoryIDs))).Scan(&count)
 	if err != nil {
 		return false, err
 	}
 
-	return exists, nil
+	return count == len(categoryIDs), nil
+}
+
+// setBookCategories menyamakan isi book_categories dengan daftar ID kategori
+func setBookCategories(tx *sql.Tx, bookID int, categoryIDs []int, username string) error {
+	ids := pq.Array(toInt64s(categoryIDs))
+
+	_, err := tx.Exec(`
+		DELETE FROM book_categories
+		WHERE book_id = $1 AND NOT (category_id = ANY($2))
+	`, bookID, ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO book_categories (book_id, category_id, created_by)
+		SELECT $1, UNNEST($2::INTEGER[]), $3
+		ON CONFLICT (book_id, category_id) DO NOTHING
+	`, bookID, ids, username)
+
+	return err
+}
+
+// setBookTags mengganti tag sebuah buku, membuat tag baru bila belum ada
+func setBookTags(tx *sql.Tx, bookID int, tags []string, username string) error {
+	_, err := tx.Exec(`
+		INSERT INTO tags (name, created_by, modified_by)
+		SELECT UNNEST($1::VARCHAR[]), $2, $2
+		ON CONFLICT (LOWER(name)) DO NOTHING
+	`, pq.Array(tags), username)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM book_tags WHERE book_id = $1`, bookID)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = strings.ToLower(tag)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO book_tags (book_id, tag_id, created_by)
+		SELECT $1, id, $3 FROM tags WHERE LOWER(name) = ANY($2)
+		ON CONFLICT (book_id, tag_id) DO NOTHING
+	`, bookID, pq.Array(names), username)
+
+	return err
+}
+
+func toInt64s(values []int) []int64 {
+	result := make([]int64, len(values))
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+	return result
 }
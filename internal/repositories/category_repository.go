@@ -2,11 +2,47 @@ package repositories
 
 import (
 	"database/sql"
+	"errors"
 	"time"
 
 	"book-management/internal/models"
+
+	"github.com/lib/pq"
 )
 
+var (
+	// ErrCategoryCycle dikembalikan ketika kategori dipindahkan ke bawah
+	// dirinya sendiri atau ke bawah salah satu turunannya
+	ErrCategoryCycle = errors.New("category cycle")
+
+	// ErrCategoryInUse dikembalikan ketika kategori yang dihapus tanpa
+	// reassign masih menjadi kategori utama sebuah buku
+	ErrCategoryInUse = errors.New("category in use")
+
+	// ErrReassignTargetNotFound dikembalikan ketika kategori tujuan
+	// reassign tidak ada saat penghapusan dijalankan
+	ErrReassignTargetNotFound = errors.New("reassign target category not found")
+
+	// ErrDuplicateCategoryName dan ErrDuplicateCategorySlug dikembalikan
+	// ketika indeks unik nama (tanpa membedakan huruf besar) atau slug dilanggar
+	ErrDuplicateCategoryName = errors.New("duplicate category name")
+	ErrDuplicateCategorySlug = errors.New("duplicate category slug")
+)
+
+// CategoryInUseError cocok dengan ErrCategoryInUse (errors.Is) dan membawa
+// jumlah buku yang dihitung di dalam transaksi penghapusan
+type CategoryInUseError struct {
+	BookCount int
+}
+
+func (e *CategoryInUseError) Error() string {
+	return ErrCategoryInUse.Error()
+}
+
+func (e *CategoryInUseError) Is(target error) bool {
+	return target == ErrCategoryInUse
+}
+
 type CategoryRepository struct {
 	db *sql.DB
 }
@@ -15,11 +51,61 @@ func NewCategoryRepository(db *sql.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
+// categorySelectQuery membangun breadcrumb setiap kategori dengan menelusuri
+// pohon dari kategori akar
+const categorySelectQuery = `
+		WITH RECURSIVE category_paths AS (
+			SELECT id, ARRAY[id] AS path_ids, ARRAY[name::TEXT] AS path_names
+			FROM categories
+			WHERE parent_id IS NULL
+			UNION ALL
+			SELECT c.id, cp.path_ids || c.id, cp.path_names || c.name::TEXT
+			FROM categories c
+			JOIN category_paths cp ON c.parent_id = cp.id
+		)
+		SELECT c.id, c.name, c.slug, c.parent_id, c.created_at, c.created_by, c.modified_at, c.modified_by,
+			   cp.path_ids, cp.path_names
+		FROM categories c
+		JOIN category_paths cp ON cp.id = c.id
+`
+
+func scanCategory(row rowScanner) (*models.Category, error) {
+	category := &models.Category{}
+	var parentID sql.NullInt64
+	var pathIDs []int64
+	var pathNames []string
+	err := row.Scan(
+		&category.ID,
+		&category.Name,
+		&category.Slug,
+		&parentID,
+		&category.CreatedAt,
+		&category.CreatedBy,
+		&category.ModifiedAt,
+		&category.ModifiedBy,
+		pq.Array(&pathIDs),
+		pq.Array(&pathNames),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		id := int(parentID.Int64)
+		category.ParentID = &id
+	}
+
+	category.Path = make([]models.CategoryPathItem, len(pathIDs))
+	for i := range pathIDs {
+		category.Path[i] = models.CategoryPathItem{ID: int(pathIDs[i]), Name: pathNames[i]}
+	}
+
+	return category, nil
+}
+
 func (r *CategoryRepository) GetAll() ([]models.Category, error) {
-	query := `
-		SELECT id, name, created_at, created_by, modified_at, modified_by
-		FROM categories 
-		ORDER BY id ASC
+	query := categorySelectQuery + `
+		ORDER BY c.id ASC
 	`
 
 	rows, err := r.db.Query(query)
@@ -30,41 +116,38 @@ func (r *CategoryRepository) GetAll() ([]models.Category, error) {
 
 	var categories []models.Category
 	for rows.Next() {
-		var category models.Category
-		err := rows.Scan(
-			&category.ID,
-			&category.Name,
-			&category.CreatedAt,
-			&category.CreatedBy,
-			&category.ModifiedAt,
-			&category.ModifiedBy,
-		)
+		category, err := scanCategory(rows)
 		if err != nil {
 			return nil, err
 		}
-		categories = append(categories, category)
+		categories = append(categories, *category)
 	}
 
-	return categories, nil
+	return categories, rows.Err()
 }
 
 func (r *CategoryRepository) GetByID(id int) (*models.Category, error) {
-	query := `
-		SELECT id, name, created_at, created_by, modified_at, modified_by
-		FROM categories 
-		WHERE id = $1
+	query := categorySelectQuery + `
+		WHERE c.id = $1
 	`
 
-	category := &models.Category{}
-	err := r.db.QueryRow(query, id).Scan(
-		&category.ID,
-		&category.Name,
-		&category.CreatedAt,
-		&category.CreatedBy,
-		&category.ModifiedAt,
-		&category.ModifiedBy,
-	)
+	category, err := scanCategory(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *CategoryRepository) GetBySlug(slug string) (*models.Category, error) {
+	query := categorySelectQuery + `
+		WHERE c.slug = $1
+	`
 
+	category, err := scanCategory(r.db.QueryRow(query, slug))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -75,34 +158,93 @@ func (r *CategoryRepository) GetByID(id int) (*models.Category, error) {
 	return category, nil
 }
 
+// GetByName mencari kategori tanpa membedakan huruf besar dan kecil
+func (r *CategoryRepository) GetByName(name string) (*models.Category, error) {
+	query := categorySelectQuery + `
+		WHERE LOWER(c.name) = LOWER($1)
+	`
+
+	category, err := scanCategory(r.db.QueryRow(query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// GetWithoutSlug mengembalikan kategori lama yang belum memiliki slug,
+// terurut menurut ID; hanya ID dan nama yang diisi
+func (r *CategoryRepository) GetWithoutSlug() ([]models.Category, error) {
+	rows, err := r.db.Query(`SELECT id, name FROM categories WHERE slug IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []models.Category
+	for rows.Next() {
+		var category models.Category
+		if err := rows.Scan(&category.ID, &category.Name); err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+// SetSlug mengisi slug kategori yang belum memiliki slug
+func (r *CategoryRepository) SetSlug(id int, slug string) error {
+	_, err := r.db.Exec(`UPDATE categories SET slug = $1 WHERE id = $2 AND slug IS NULL`, slug, id)
+	return categoryUniqueError(err)
+}
+
+// SlugExists memeriksa apakah slug sudah dipakai kategori selain excludeID
+func (r *CategoryRepository) SlugExists(slug string, excludeID int) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE slug = $1 AND id <> $2)`
+
+	var exists bool
+	err := r.db.QueryRow(query, slug, excludeID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
 func (r *CategoryRepository) Create(category *models.Category) error {
 	query := `
-		INSERT INTO categories (name, created_by, modified_by)
-		VALUES ($1, $2, $3)
+		INSERT INTO categories (name, slug, parent_id, created_by, modified_by)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at, modified_at
 	`
 
 	err := r.db.QueryRow(
 		query,
 		category.Name,
+		category.Slug,
+		category.ParentID,
 		category.CreatedBy,
 		category.ModifiedBy,
 	).Scan(&category.ID, &category.CreatedAt, &category.ModifiedAt)
 
-	return err
+	return categoryUniqueError(err)
 }
 
 func (r *CategoryRepository) Update(category *models.Category) error {
 	query := `
 		UPDATE categories 
-		SET name = $1, modified_by = $2, modified_at = $3
-		WHERE id = $4
+		SET name = $1, slug = $2, modified_by = $3, modified_at = $4
+		WHERE id = $5
 	`
 
 	category.ModifiedAt = time.Now()
-	result, err := r.db.Exec(query, category.Name, category.ModifiedBy, category.ModifiedAt, category.ID)
+	result, err := r.db.Exec(query, category.Name, category.Slug, category.ModifiedBy, category.ModifiedAt, category.ID)
 	if err != nil {
-		return err
+		return categoryUniqueError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -117,10 +259,36 @@ func (r *CategoryRepository) Update(category *models.Category) error {
 	return nil
 }
 
-func (r *CategoryRepository) Delete(id int) error {
-	query := `DELETE FROM categories WHERE id = $1`
+// Move memindahkan kategori (beserta seluruh turunannya) ke parent baru.
+// Tabel dikunci selama transaksi agar dua pemindahan bersamaan tidak bisa
+// membentuk siklus.
+func (r *CategoryRepository) Move(id int, parentID *int, username string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	result, err := r.db.Exec(query, id)
+	if _, err := tx.Exec(`LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+
+	if parentID != nil {
+		cycle, err := isInSubtree(tx, id, *parentID)
+		if err != nil {
+			return err
+		}
+
+		if cycle {
+			return ErrCategoryCycle
+		}
+	}
+
+	result, err := tx.Exec(`
+		UPDATE categories
+		SET parent_id = $1, modified_by = $2, modified_at = $3
+		WHERE id = $4
+	`, parentID, username, time.Now(), id)
 	if err != nil {
 		return err
 	}
@@ -134,51 +302,222 @@ func (r *CategoryRepository) Delete(id int) error {
 		return sql.ErrNoRows
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (r *CategoryRepository) GetBooksByCategory(categoryID int) ([]models.BookWithCategory, error) {
-	query := `
-		SELECT b.id, b.title, b.description, b.image_url, b.release_year, 
-			   b.price, b.total_page, b.thickness, b.category_id,
-			   b.created_at, b.created_by, b.modified_at, b.modified_by,
-			   c.name as category_name
-		FROM books b
-		JOIN categories c ON b.category_id = c.id
-		WHERE b.category_id = $1
-		ORDER BY b.id ASC
-	`
+// Delete menghapus kategori. Sub-kategori dipindahkan ke parent kategori yang
+// dihapus. Jika reassignTo diisi, semua buku dipindahkan ke kategori tersebut
+// lebih dulu; jika tidak, penghapusan ditolak dengan *CategoryInUseError
+// selama masih ada buku dengan kategori utama ini.
+func (r *CategoryRepository) Delete(id int, reassignTo *int, username string) (*models.CategoryReassignResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
-	rows, err := r.db.Query(query, categoryID)
+	// Locking the row blocks concurrent inserts of books referencing it
+	var parentID sql.NullInt64
+	err = tx.QueryRow(`SELECT parent_id FROM categories WHERE id = $1 FOR UPDATE`, id).Scan(&parentID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var books []models.BookWithCategory
-	for rows.Next() {
-		var book models.BookWithCategory
-		err := rows.Scan(
-			&book.ID,
-			&book.Title,
-			&book.Description,
-			&book.ImageURL,
-			&book.ReleaseYear,
-			&book.Price,
-			&book.TotalPage,
-			&book.Thickness,
-			&book.CategoryID,
-			&book.CreatedAt,
-			&book.CreatedBy,
-			&book.ModifiedAt,
-			&book.ModifiedBy,
-			&book.CategoryName,
-		)
+	result := &models.CategoryReassignResult{SourceID: id, TargetID: reassignTo}
+
+	if reassignTo == nil {
+		var count int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM books WHERE category_id = $1`, id).Scan(&count)
 		if err != nil {
 			return nil, err
 		}
-		books = append(books, book)
+
+		if count > 0 {
+			return nil, &CategoryInUseError{BookCount: count}
+		}
+	} else {
+		// Keep the target from being deleted until the books are moved
+		err := tx.QueryRow(`SELECT 1 FROM categories WHERE id = $1 FOR KEY SHARE`, *reassignTo).Scan(new(int))
+		if err == sql.ErrNoRows {
+			return nil, ErrReassignTargetNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result.MovedBooks, err = moveCategoryBooks(tx, id, *reassignTo, username)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var newParentID *int
+	if parentID.Valid {
+		value := int(parentID.Int64)
+		newParentID = &value
+	}
+
+	result.MovedSubcategories, err = moveSubcategories(tx, id, newParentID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM categories WHERE id = $1`, id); err != nil {
+		return nil, err
+	}
+
+	return result, tx.Commit()
+}
+
+// Merge memindahkan semua buku dan sub-kategori dari sourceID ke targetID,
+// lalu menghapus kategori sumber
+func (r *CategoryRepository) Merge(sourceID, targetID int, username string) (*models.CategoryReassignResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return nil, err
+	}
+
+	// Moving the children under a descendant of the source would form a cycle
+	cycle, err := isInSubtree(tx, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cycle {
+		return nil, ErrCategoryCycle
+	}
+
+	result := &models.CategoryReassignResult{SourceID: sourceID, TargetID: &targetID}
+
+	result.MovedBooks, err = moveCategoryBooks(tx, sourceID, targetID, username)
+	if err != nil {
+		return nil, err
 	}
 
-	return books, nil
+	result.MovedSubcategories, err = moveSubcategories(tx, sourceID, &targetID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := tx.Exec(`DELETE FROM categories WHERE id = $1`, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := deleted.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return result, tx.Commit()
+}
+
+// moveCategoryBooks memindahkan kategori utama dan tautan kategori tambahan
+// dari satu kategori ke kategori lain, mengembalikan jumlah buku yang terkena
+func moveCategoryBooks(tx *sql.Tx, fromID, toID int, username string) (int, error) {
+	_, err := tx.Exec(`
+		UPDATE books
+		SET category_id = $2, modified_by = $3, modified_at = $4
+		WHERE category_id = $1
+	`, fromID, toID, username, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO book_categories (book_id, category_id, created_by)
+		SELECT book_id, $2, $3 FROM book_categories WHERE category_id = $1
+		ON CONFLICT (book_id, category_id) DO NOTHING
+	`, fromID, toID, username)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(`DELETE FROM book_categories WHERE category_id = $1`, fromID)
+	if err != nil {
+		return 0, err
+	}
+
+	moved, err := result.RowsAffected()
+	return int(moved), err
+}
+
+func moveSubcategories(tx *sql.Tx, fromID int, toID *int, username string) (int, error) {
+	result, err := tx.Exec(`
+		UPDATE categories
+		SET parent_id = $2, modified_by = $3, modified_at = $4
+		WHERE parent_id = $1
+	`, fromID, toID, username, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	moved, err := result.RowsAffected()
+	return int(moved), err
+}
+
+// categoryUniqueError menerjemahkan pelanggaran indeks unik kategori
+func categoryUniqueError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		switch pqErr.Constraint {
+		case "idx_categories_name_lower":
+			return ErrDuplicateCategoryName
+		case "idx_categories_slug":
+			return ErrDuplicateCategorySlug
+		}
+	}
+
+	return err
+}
+
+// isInSubtree memeriksa apakah candidateID adalah rootID atau turunannya
+func isInSubtree(tx *sql.Tx, rootID, candidateID int) (bool, error) {
+	var found bool
+	err := tx.QueryRow(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT EXISTS(SELECT 1 FROM subtree WHERE id = $2)
+	`, rootID, candidateID).Scan(&found)
+
+	return found, err
+}
+
+// GetBooksByCategory mengembalikan buku yang terhubung ke kategori, dan bila
+// includeDescendants bernilai true juga buku pada seluruh sub-kategorinya
+func (r *CategoryRepository) GetBooksByCategory(categoryID int, includeDescendants bool) ([]models.BookWithCategory, error) {
+	if !includeDescendants {
+		query := bookSelectQuery + `
+			WHERE b.id IN (SELECT book_id FROM book_categories WHERE category_id = $1)
+			ORDER BY b.id ASC
+		`
+
+		return queryBooks(r.db, query, categoryID)
+	}
+
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+	` + bookSelectQuery + `
+		WHERE b.id IN (
+			SELECT book_id FROM book_categories WHERE category_id IN (SELECT id FROM subtree)
+		)
+		ORDER BY b.id ASC
+	`
+
+	return queryBooks(r.db, query, categoryID)
 }
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"book-management/internal/models"
+)
+
+type CoverRepository struct {
+	db *sql.DB
+}
+
+func NewCoverRepository(db *sql.DB) *CoverRepository {
+	return &CoverRepository{db: db}
+}
+
+func (r *CoverRepository) GetByBookID(bookID int) ([]models.CoverImage, error) {
+	query := `
+		SELECT variant, url, storage_key, content_type, width, height, size_bytes,
+			   created_at, created_by
+		FROM book_covers
+		WHERE book_id = $1
+		ORDER BY width DESC
+	`
+
+	rows, err := r.db.Query(query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var images []models.CoverImage
+	for rows.Next() {
+		var image models.CoverImage
+		err := rows.Scan(
+			&image.Variant,
+			&image.URL,
+			&image.StorageKey,
+			&image.ContentType,
+			&image.Width,
+			&image.Height,
+			&image.SizeBytes,
+			&image.CreatedAt,
+			&image.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	return images, rows.Err()
+}
+
+// Replace mengganti semua varian sampul buku dan mengisi books.image_url
+// dengan URL gambar asli. Key storage sampul lama dikembalikan agar bisa
+// dihapus setelah transaksi berhasil.
+func (r *CoverRepository) Replace(bookID int, images []models.CoverImage, imageURL string, username string) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	oldKeys, err := deleteCovers(tx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range images {
+		err := tx.QueryRow(`
+			INSERT INTO book_covers (book_id, variant, storage_key, url, content_type,
+									 width, height, size_bytes, created_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING created_at
+		`,
+			bookID,
+			images[i].Variant,
+			images[i].StorageKey,
+			images[i].URL,
+			images[i].ContentType,
+			images[i].Width,
+			images[i].Height,
+			images[i].SizeBytes,
+			username,
+		).Scan(&images[i].CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		images[i].CreatedBy = username
+	}
+
+	if err := setBookImageURL(tx, bookID, imageURL, username); err != nil {
+		return nil, err
+	}
+
+	return oldKeys, tx.Commit()
+}
+
+// Delete menghapus semua varian sampul dan mengosongkan books.image_url
+func (r *CoverRepository) Delete(bookID int, username string) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	oldKeys, err := deleteCovers(tx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(oldKeys) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	if err := setBookImageURL(tx, bookID, "", username); err != nil {
+		return nil, err
+	}
+
+	return oldKeys, tx.Commit()
+}
+
+func deleteCovers(tx *sql.Tx, bookID int) ([]string, error) {
+	rows, err := tx.Query(`DELETE FROM book_covers WHERE book_id = $1 RETURNING storage_key`, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func setBookImageURL(tx *sql.Tx, bookID int, imageURL string, username string) error {
+	_, err := tx.Exec(`
+		UPDATE books SET image_url = $1, modified_by = $2, modified_at = $3
+		WHERE id = $4
+	`, imageURL, username, time.Now(), bookID)
+
+	return err
+}
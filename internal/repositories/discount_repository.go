@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type DiscountRepository struct {
+	db *sql.DB
+}
+
+func NewDiscountRepository(db *sql.DB) *DiscountRepository {
+	return &DiscountRepository{db: db}
+}
+
+const discountColumns = `
+		d.id, d.name, d.book_id, d.category_id, d.discount_type, d.percentage,
+		d.amount, COALESCE(d.currency, ''), d.starts_at, d.ends_at,
+		d.created_at, d.created_by, d.modified_at, d.modified_by
+`
+
+func scanDiscount(row rowScanner, extra ...interface{}) (*models.Discount, error) {
+	discount := &models.Discount{}
+	dest := append(extra,
+		&discount.ID,
+		&discount.Name,
+		&discount.BookID,
+		&discount.CategoryID,
+		&discount.DiscountType,
+		&discount.Percentage,
+		&discount.Amount,
+		&discount.Currency,
+		&discount.StartsAt,
+		&discount.EndsAt,
+		&discount.CreatedAt,
+		&discount.CreatedBy,
+		&discount.ModifiedAt,
+		&discount.ModifiedBy,
+	)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return discount, nil
+}
+
+func (r *DiscountRepository) GetAll(filter models.DiscountFilter) ([]models.Discount, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.BookID > 0 {
+		args = append(args, filter.BookID)
+		conditions = append(conditions, fmt.Sprintf("d.book_id = $%d", len(args)))
+	}
+
+	if filter.CategoryID > 0 {
+		args = append(args, filter.CategoryID)
+		conditions = append(conditions, fmt.Sprintf("d.category_id = $%d", len(args)))
+	}
+
+	if filter.ActiveOnly {
+		args = append(args, time.Now().UTC())
+		conditions = append(conditions, fmt.Sprintf("d.starts_at <= $%d AND d.ends_at > $%d", len(args), len(args)))
+	}
+
+	query := `SELECT ` + discountColumns + ` FROM discounts d`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY d.starts_at DESC, d.id DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var discounts []models.Discount
+	for rows.Next() {
+		discount, err := scanDiscount(rows)
+		if err != nil {
+			return nil, err
+		}
+		discounts = append(discounts, *discount)
+	}
+
+	return discounts, rows.Err()
+}
+
+func (r *DiscountRepository) GetByID(id int) (*models.Discount, error) {
+	query := `SELECT ` + discountColumns + ` FROM discounts d WHERE d.id = $1`
+
+	discount, err := scanDiscount(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return discount, nil
+}
+
+func (r *DiscountRepository) Create(discount *models.Discount) error {
+	query := `
+		INSERT INTO discounts (name, book_id, category_id, discount_type, percentage, amount,
+							   currency, starts_at, ends_at, created_by, modified_by)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, $11)
+		RETURNING id, created_at, modified_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		discount.Name,
+		discount.BookID,
+		discount.CategoryID,
+		discount.DiscountType,
+		discount.Percentage,
+		discount.Amount,
+		discount.Currency,
+		discount.StartsAt,
+		discount.EndsAt,
+		discount.CreatedBy,
+		discount.ModifiedBy,
+	).Scan(&discount.ID, &discount.CreatedAt, &discount.ModifiedAt)
+}
+
+func (r *DiscountRepository) Update(discount *models.Discount) error {
+	query := `
+		UPDATE discounts
+		SET name = $1, book_id = $2, category_id = $3, discount_type = $4, percentage = $5,
+			amount = $6, currency = NULLIF($7, ''), starts_at = $8, ends_at = $9,
+			modified_by = $10, modified_at = $11
+		WHERE id = $12
+	`
+
+	discount.ModifiedAt = time.Now()
+	result, err := r.db.Exec(
+		query,
+		discount.Name,
+		discount.BookID,
+		discount.CategoryID,
+		discount.DiscountType,
+		discount.Percentage,
+		discount.Amount,
+		discount.Currency,
+		discount.StartsAt,
+		discount.EndsAt,
+		discount.ModifiedBy,
+		discount.ModifiedAt,
+		discount.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *DiscountRepository) Delete(id int) error {
+	query := `DELETE FROM discounts WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetActiveForBooks mengembalikan diskon yang berlaku pada waktu now untuk
+// setiap buku: diskon buku itu sendiri dan diskon kategori (termasuk
+// kategori induknya) dari semua kategori buku tersebut
+func (r *DiscountRepository) GetActiveForBooks(bookIDs []int, now time.Time) (map[int][]models.Discount, error) {
+	result := make(map[int][]models.Discount)
+	if len(bookIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		WITH RECURSIVE active AS (
+			SELECT * FROM discounts WHERE starts_at <= $2 AND ends_at > $2
+		), discount_categories AS (
+			SELECT a.id AS discount_id, a.category_id
+			FROM active a
+			WHERE a.category_id IS NOT NULL
+			UNION
+			SELECT dc.discount_id, c.id
+			FROM categories c
+			JOIN discount_categories dc ON c.parent_id = dc.category_id
+		)
+		SELECT d.book_id AS target_book_id, ` + discountColumns + `
+		FROM active d
+		WHERE d.book_id = ANY($1)
+		UNION
+		SELECT bc.book_id, ` + discountColumns + `
+		FROM discount_categories dc
+		JOIN active d ON d.id = dc.discount_id
+		JOIN book_categories bc ON bc.category_id = dc.category_id
+		WHERE bc.book_id = ANY($1)
+	`
+
+	rows, err := r.db.Query(query, pq.Array(toInt64s(bookIDs)), now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int
+		discount, err := scanDiscount(rows, &bookID)
+		if err != nil {
+			return nil, err
+		}
+		result[bookID] = append(result[bookID], *discount)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"book-management/internal/models"
+)
+
+type ExchangeRateRepository struct {
+	db *sql.DB
+}
+
+func NewExchangeRateRepository(db *sql.DB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+const exchangeRateSelectQuery = `
+		SELECT base_currency, quote_currency, rate::TEXT, source, updated_at, updated_by
+		FROM exchange_rates
+`
+
+func scanExchangeRate(row rowScanner) (*models.ExchangeRate, error) {
+	rate := &models.ExchangeRate{}
+	err := row.Scan(
+		&rate.BaseCurrency,
+		&rate.QuoteCurrency,
+		&rate.Rate,
+		&rate.Source,
+		&rate.UpdatedAt,
+		&rate.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (r *ExchangeRateRepository) GetAll() ([]models.ExchangeRate, error) {
+	query := exchangeRateSelectQuery + ` ORDER BY base_currency, quote_currency`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []models.ExchangeRate
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, *rate)
+	}
+
+	return rates, rows.Err()
+}
+
+func (r *ExchangeRateRepository) Get(base, quote string) (*models.ExchangeRate, error) {
+	query := exchangeRateSelectQuery + ` WHERE base_currency = $1 AND quote_currency = $2`
+
+	rate, err := scanExchangeRate(r.db.QueryRow(query, base, quote))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// Save menyimpan beberapa kurs sekaligus dalam satu transaksi; kurs untuk
+// pasangan mata uang yang sudah ada akan ditimpa
+func (r *ExchangeRateRepository) Save(rates []models.ExchangeRate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO exchange_rates (base_currency, quote_currency, rate, source, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (base_currency, quote_currency)
+		DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source,
+			updated_by = EXCLUDED.updated_by, updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i := range rates {
+		rate := &rates[i]
+		err := stmt.QueryRow(rate.BaseCurrency, rate.QuoteCurrency, rate.Rate, rate.Source, rate.UpdatedBy).Scan(&rate.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *ExchangeRateRepository) Delete(base, quote string) error {
+	query := `DELETE FROM exchange_rates WHERE base_currency = $1 AND quote_currency = $2`
+
+	result, err := r.db.Exec(query, base, quote)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
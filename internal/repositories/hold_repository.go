@@ -0,0 +1,356 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// holdExpiryBatchSize membatasi jumlah hold kedaluwarsa yang diproses dalam
+// satu panggilan ExpireHolds
+const holdExpiryBatchSize = 100
+
+var (
+	// ErrDuplicateHold dikembalikan ketika anggota sudah memiliki hold
+	// terbuka untuk judul yang sama
+	ErrDuplicateHold = errors.New("duplicate hold")
+
+	// ErrAlreadyBorrowed dikembalikan ketika anggota memasang hold untuk
+	// judul yang eksemplarnya sedang ia pinjam
+	ErrAlreadyBorrowed = errors.New("already borrowed")
+
+	// ErrHoldClosed dikembalikan ketika hold yang dibatalkan sudah ditutup
+	ErrHoldClosed = errors.New("hold closed")
+)
+
+type HoldRepository struct {
+	db *sql.DB
+}
+
+func NewHoldRepository(db *sql.DB) *HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+// Position is only meaningful for waiting holds: the number of waiting holds
+// of the same title placed before (or together with) this one
+const holdSelectQuery = `
+		SELECT h.id, h.book_id, b.title, h.patron_id, p.name, h.item_id, i.barcode, h.status,
+			   CASE WHEN h.status = 'waiting' THEN (
+				   SELECT COUNT(*) FROM holds w
+				   WHERE w.book_id = h.book_id AND w.status = 'waiting'
+					 AND (w.placed_at, w.id) <= (h.placed_at, h.id)
+			   ) END,
+			   h.placed_at, h.ready_at, h.expires_at, h.closed_at, h.created_by, h.closed_by
+		FROM holds h
+		JOIN books b ON h.book_id = b.id
+		JOIN patrons p ON h.patron_id = p.id
+		LEFT JOIN items i ON h.item_id = i.id
+`
+
+func scanHold(row rowScanner) (*models.Hold, error) {
+	hold := &models.Hold{}
+	err := row.Scan(
+		&hold.ID,
+		&hold.BookID,
+		&hold.BookTitle,
+		&hold.PatronID,
+		&hold.PatronName,
+		&hold.ItemID,
+		&hold.Barcode,
+		&hold.Status,
+		&hold.Position,
+		&hold.PlacedAt,
+		&hold.ReadyAt,
+		&hold.ExpiresAt,
+		&hold.ClosedAt,
+		&hold.CreatedBy,
+		&hold.ClosedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// GetAll mengembalikan hold sesuai filter: hold "ready" lebih dulu, lalu
+// hold "waiting" sesuai urutan antrean, lalu hold yang sudah ditutup
+func (r *HoldRepository) GetAll(filter models.HoldFilter) ([]models.Hold, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.BookID > 0 {
+		args = append(args, filter.BookID)
+		conditions = append(conditions, fmt.Sprintf("h.book_id = $%d", len(args)))
+	}
+
+	if filter.PatronID > 0 {
+		args = append(args, filter.PatronID)
+		conditions = append(conditions, fmt.Sprintf("h.patron_id = $%d", len(args)))
+	}
+
+	switch filter.Status {
+	case "":
+	case "open":
+		conditions = append(conditions, "h.status IN ('waiting', 'ready')")
+	default:
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("h.status = $%d", len(args)))
+	}
+
+	query := holdSelectQuery
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += `
+		ORDER BY CASE h.status WHEN 'ready' THEN 0 WHEN 'waiting' THEN 1 ELSE 2 END,
+				 CASE WHEN h.status IN ('ready', 'waiting') THEN h.placed_at END ASC,
+				 h.closed_at DESC, h.id ASC
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []models.Hold
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, *hold)
+	}
+
+	return holds, rows.Err()
+}
+
+func (r *HoldRepository) GetByID(id int) (*models.Hold, error) {
+	query := holdSelectQuery + ` WHERE h.id = $1`
+
+	hold, err := scanHold(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// Place memasang hold baru di akhir antrean. Bila masih ada eksemplar yang
+// available, eksemplar itu langsung disisihkan untuk hold terdepan.
+func (r *HoldRepository) Place(hold *models.Hold, pickupDays int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var patronStatus string
+	err = tx.QueryRow(`SELECT status FROM patrons WHERE id = $1 FOR UPDATE`, hold.PatronID).Scan(&patronStatus)
+	if err != nil {
+		return err
+	}
+
+	if patronStatus != "active" {
+		return ErrPatronSuspended
+	}
+
+	var borrowed bool
+	err = tx.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM loans l
+			JOIN items i ON l.item_id = i.id
+			WHERE i.book_id = $1 AND l.patron_id = $2 AND l.returned_at IS NULL
+		)
+	`, hold.BookID, hold.PatronID).Scan(&borrowed)
+	if err != nil {
+		return err
+	}
+
+	if borrowed {
+		return ErrAlreadyBorrowed
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO holds (book_id, patron_id, status, placed_at, created_by)
+		VALUES ($1, $2, 'waiting', $3, $4)
+		RETURNING id
+	`, hold.BookID, hold.PatronID, hold.PlacedAt, hold.CreatedBy).Scan(&hold.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "idx_holds_open_patron" {
+			return ErrDuplicateHold
+		}
+		return err
+	}
+
+	// Copies being checked out concurrently are skipped rather than waited on
+	var itemID int
+	err = tx.QueryRow(`
+		SELECT id FROM items
+		WHERE book_id = $1 AND status = 'available'
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`, hold.BookID).Scan(&itemID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if err == nil {
+		if err := releaseItem(tx, itemID, hold.BookID, hold.PlacedAt, pickupDays, hold.CreatedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Cancel membatalkan hold terbuka. Eksemplar yang sudah disisihkan untuk
+// hold tersebut diberikan ke antrean berikutnya.
+func (r *HoldRepository) Cancel(id int, now time.Time, pickupDays int, username string) error {
+	return r.close(id, "cancelled", now, pickupDays, username)
+}
+
+// ExpireHolds menutup hold "ready" yang tidak diambil sampai expires_at dan
+// memberikan eksemplarnya ke antrean berikutnya, paling banyak
+// holdExpiryBatchSize sekaligus
+func (r *HoldRepository) ExpireHolds(now time.Time, pickupDays int) (int, error) {
+	rows, err := r.db.Query(`
+		SELECT id FROM holds
+		WHERE status = 'ready' AND expires_at <= $1
+		ORDER BY expires_at, id
+		LIMIT $2
+	`, now, holdExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		err := r.close(id, "expired", now, pickupDays, "system")
+		if err == ErrHoldClosed || err == sql.ErrNoRows {
+			// Picked up or cancelled in the meantime
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// close menutup hold terbuka dengan status cancelled atau expired. Eksemplar
+// dikunci sebelum hold, sama seperti pada checkout.
+func (r *HoldRepository) close(id int, status string, now time.Time, pickupDays int, username string) error {
+	var itemID sql.NullInt64
+	err := r.db.QueryRow(`SELECT item_id FROM holds WHERE id = $1`, id).Scan(&itemID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if itemID.Valid {
+		if _, err := tx.Exec(`SELECT 1 FROM items WHERE id = $1 FOR UPDATE`, itemID.Int64); err != nil {
+			return err
+		}
+	}
+
+	var bookID int
+	var currentStatus string
+	var expiresAt *time.Time
+	err = tx.QueryRow(`
+		SELECT book_id, item_id, status, expires_at FROM holds WHERE id = $1 FOR UPDATE
+	`, id).Scan(&bookID, &itemID, &currentStatus, &expiresAt)
+	if err != nil {
+		return err
+	}
+
+	if currentStatus != "waiting" && currentStatus != "ready" {
+		return ErrHoldClosed
+	}
+
+	if status == "expired" && (currentStatus != "ready" || expiresAt == nil || expiresAt.After(now)) {
+		return ErrHoldClosed
+	}
+
+	_, err = tx.Exec(`
+		UPDATE holds SET status = $1, closed_at = $2, closed_by = $3 WHERE id = $4
+	`, status, now, username, id)
+	if err != nil {
+		return err
+	}
+
+	if currentStatus == "ready" && itemID.Valid {
+		if err := releaseItem(tx, int(itemID.Int64), bookID, now, pickupDays, username); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// releaseItem memberikan eksemplar yang baru bebas ke hold "waiting" terdepan
+// untuk judulnya dan menandainya on_hold, atau menandainya available bila
+// antrean kosong. Baris eksemplar harus sudah dikunci oleh pemanggil.
+func releaseItem(tx *sql.Tx, itemID, bookID int, now time.Time, pickupDays int, username string) error {
+	var holdID int
+	err := tx.QueryRow(`
+		SELECT id FROM holds
+		WHERE book_id = $1 AND status = 'waiting'
+		ORDER BY placed_at, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`, bookID).Scan(&holdID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	itemStatus := "available"
+	if err == nil {
+		_, err := tx.Exec(`
+			UPDATE holds SET status = 'ready', item_id = $1, ready_at = $2, expires_at = $3
+			WHERE id = $4
+		`, itemID, now, now.AddDate(0, 0, pickupDays), holdID)
+		if err != nil {
+			return err
+		}
+		itemStatus = "on_hold"
+	}
+
+	_, err = tx.Exec(`
+		UPDATE items SET status = $1, modified_at = $2, modified_by = $3 WHERE id = $4
+	`, itemStatus, time.Now(), username, itemID)
+
+	return err
+}
@@ -0,0 +1,240 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDuplicateBarcode dikembalikan ketika barcode sudah dipakai eksemplar lain
+	ErrDuplicateBarcode = errors.New("duplicate barcode")
+
+	// ErrItemStatusChanged dikembalikan ketika status eksemplar diubah oleh
+	// peminjaman atau hold sejak dibaca
+	ErrItemStatusChanged = errors.New("item status changed")
+
+	// ErrItemHasLoans dikembalikan ketika eksemplar yang dihapus memiliki
+	// riwayat peminjaman
+	ErrItemHasLoans = errors.New("item has loans")
+)
+
+type ItemRepository struct {
+	db *sql.DB
+}
+
+func NewItemRepository(db *sql.DB) *ItemRepository {
+	return &ItemRepository{db: db}
+}
+
+const itemSelectQuery = `
+		SELECT i.id, i.book_id, b.title, i.barcode, TO_CHAR(i.acquisition_date, 'YYYY-MM-DD'),
+			   i.condition, COALESCE(i.shelf_location, ''), i.status, COALESCE(i.notes, ''),
+			   i.created_at, i.created_by, i.modified_at, i.modified_by
+		FROM items i
+		JOIN books b ON i.book_id = b.id
+`
+
+func scanItem(row rowScanner) (*models.Item, error) {
+	item := &models.Item{}
+	err := row.Scan(
+		&item.ID,
+		&item.BookID,
+		&item.BookTitle,
+		&item.Barcode,
+		&item.AcquisitionDate,
+		&item.Condition,
+		&item.ShelfLocation,
+		&item.Status,
+		&item.Notes,
+		&item.CreatedAt,
+		&item.CreatedBy,
+		&item.ModifiedAt,
+		&item.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r *ItemRepository) GetAll(filter models.ItemFilter) ([]models.Item, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.BookID > 0 {
+		args = append(args, filter.BookID)
+		conditions = append(conditions, fmt.Sprintf("i.book_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("i.status = $%d", len(args)))
+	}
+
+	if filter.ShelfLocation != "" {
+		args = append(args, filter.ShelfLocation)
+		conditions = append(conditions, fmt.Sprintf("i.shelf_location = $%d", len(args)))
+	}
+
+	query := itemSelectQuery
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY i.book_id ASC, i.barcode ASC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *ItemRepository) GetByID(id int) (*models.Item, error) {
+	query := itemSelectQuery + ` WHERE i.id = $1`
+
+	item, err := scanItem(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r *ItemRepository) GetByBarcode(barcode string) (*models.Item, error) {
+	query := itemSelectQuery + ` WHERE i.barcode = $1`
+
+	item, err := scanItem(r.db.QueryRow(query, barcode))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r *ItemRepository) Create(item *models.Item) error {
+	query := `
+		INSERT INTO items (book_id, barcode, acquisition_date, condition, shelf_location,
+						   status, notes, created_by, modified_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, ''), $8, $9)
+		RETURNING id, created_at, modified_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		item.BookID,
+		item.Barcode,
+		item.AcquisitionDate,
+		item.Condition,
+		item.ShelfLocation,
+		item.Status,
+		item.Notes,
+		item.CreatedBy,
+		item.ModifiedBy,
+	).Scan(&item.ID, &item.CreatedAt, &item.ModifiedAt)
+
+	return itemUniqueError(err)
+}
+
+// Update menyimpan eksemplar hanya bila statusnya masih oldStatus, agar
+// perubahan status oleh peminjaman atau hold tidak tertimpa
+func (r *ItemRepository) Update(item *models.Item, oldStatus string) error {
+	query := `
+		UPDATE items
+		SET barcode = $1, acquisition_date = $2, condition = $3, shelf_location = NULLIF($4, ''),
+			status = $5, notes = NULLIF($6, ''), modified_by = $7, modified_at = $8
+		WHERE id = $9 AND status = $10
+	`
+
+	item.ModifiedAt = time.Now()
+	result, err := r.db.Exec(
+		query,
+		item.Barcode,
+		item.AcquisitionDate,
+		item.Condition,
+		item.ShelfLocation,
+		item.Status,
+		item.Notes,
+		item.ModifiedBy,
+		item.ModifiedAt,
+		item.ID,
+		oldStatus,
+	)
+	if err != nil {
+		return itemUniqueError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		var exists bool
+		if err := r.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM items WHERE id = $1)`, item.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return ErrItemStatusChanged
+		}
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *ItemRepository) Delete(id int) error {
+	query := `DELETE FROM items WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrItemHasLoans
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// itemUniqueError menerjemahkan pelanggaran indeks unik barcode
+func itemUniqueError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "idx_items_barcode" {
+		return ErrDuplicateBarcode
+	}
+
+	return err
+}
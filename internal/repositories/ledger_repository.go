@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"book-management/internal/models"
+)
+
+var (
+	// ErrAmountExceedsBalance dikembalikan ketika pembayaran atau penghapusan
+	// denda melebihi saldo anggota
+	ErrAmountExceedsBalance = errors.New("amount exceeds balance")
+
+	// ErrLoanPatronMismatch dikembalikan ketika entri buku besar merujuk
+	// pinjaman milik anggota lain
+	ErrLoanPatronMismatch = errors.New("loan belongs to another patron")
+)
+
+// dbExecutor dipenuhi oleh *sql.DB maupun *sql.Tx
+type dbExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+type LedgerRepository struct {
+	db *sql.DB
+}
+
+func NewLedgerRepository(db *sql.DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+func (r *LedgerRepository) GetEntries(patronID int) ([]models.LedgerEntry, error) {
+	query := `
+		SELECT id, patron_id, loan_id, entry_type, amount, currency, COALESCE(note, ''),
+			   created_at, created_by, modified_at
+		FROM patron_ledger_entries
+		WHERE patron_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, patronID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.LedgerEntry
+	for rows.Next() {
+		var entry models.LedgerEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.PatronID,
+			&entry.LoanID,
+			&entry.EntryType,
+			&entry.Amount,
+			&entry.Currency,
+			&entry.Note,
+			&entry.CreatedAt,
+			&entry.CreatedBy,
+			&entry.ModifiedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *LedgerRepository) GetBalance(patronID int) (*models.PatronBalance, error) {
+	query := `
+		SELECT COALESCE(SUM(amount) FILTER (WHERE entry_type = 'charge'), 0),
+			   COALESCE(SUM(amount) FILTER (WHERE entry_type = 'payment'), 0),
+			   COALESCE(SUM(amount) FILTER (WHERE entry_type = 'waiver'), 0)
+		FROM patron_ledger_entries
+		WHERE patron_id = $1
+	`
+
+	balance := &models.PatronBalance{PatronID: patronID}
+	err := r.db.QueryRow(query, patronID).Scan(&balance.Charges, &balance.Payments, &balance.Waivers)
+	if err != nil {
+		return nil, err
+	}
+
+	balance.Balance = balance.Charges - balance.Payments - balance.Waivers
+	return balance, nil
+}
+
+// AddEntry mencatat pembayaran atau penghapusan denda. Baris anggota dikunci
+// agar dua pembayaran bersamaan tidak melebihi saldo.
+func (r *LedgerRepository) AddEntry(entry *models.LedgerEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT 1 FROM patrons WHERE id = $1 FOR UPDATE`, entry.PatronID); err != nil {
+		return err
+	}
+
+	if entry.LoanID != nil {
+		var loanPatronID int
+		err := tx.QueryRow(`SELECT patron_id FROM loans WHERE id = $1`, *entry.LoanID).Scan(&loanPatronID)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if err == sql.ErrNoRows || loanPatronID != entry.PatronID {
+			return ErrLoanPatronMismatch
+		}
+	}
+
+	balance, err := patronBalance(tx, entry.PatronID)
+	if err != nil {
+		return err
+	}
+
+	if entry.Amount > balance {
+		return ErrAmountExceedsBalance
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO patron_ledger_entries (patron_id, loan_id, entry_type, amount, currency, note,
+										   created_at, created_by, modified_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $7)
+		RETURNING id
+	`,
+		entry.PatronID,
+		entry.LoanID,
+		entry.EntryType,
+		entry.Amount,
+		entry.Currency,
+		entry.Note,
+		entry.CreatedAt,
+		entry.CreatedBy,
+	).Scan(&entry.ID)
+	if err != nil {
+		return err
+	}
+
+	entry.ModifiedAt = entry.CreatedAt
+	return tx.Commit()
+}
+
+// AccrueFines memperbarui denda semua pinjaman terbuka yang terlambat dan
+// mengembalikan jumlah denda yang bertambah
+func (r *LedgerRepository) AccrueFines(now time.Time) (int, error) {
+	return accrueFines(r.db, now, 0)
+}
+
+// accrueFines menghitung denda pinjaman yang terlambat sampai returned_at
+// (atau now untuk pinjaman terbuka) memakai aturan denda yang disalin saat
+// checkout. Denda satu pinjaman disimpan sebagai satu entri "charge" yang
+// hanya bisa bertambah. Bila loanID diisi, hanya pinjaman tersebut yang
+// dihitung; jika tidak, semua pinjaman terbuka.
+func accrueFines(e dbExecutor, now time.Time, loanID int) (int, error) {
+	condition := "l.returned_at IS NULL"
+	args := []interface{}{now}
+	if loanID > 0 {
+		condition = "l.id = $2"
+		args = append(args, loanID)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO patron_ledger_entries (patron_id, loan_id, entry_type, amount, currency, note,
+										   created_at, created_by, modified_at)
+		SELECT patron_id, id, 'charge', fine, fine_currency, 'Overdue fine', $1::TIMESTAMP, 'system', $1::TIMESTAMP
+		FROM (
+			SELECT l.id, l.patron_id, l.fine_currency,
+				   LEAST(
+					   GREATEST(
+						   FLOOR(EXTRACT(EPOCH FROM (COALESCE(l.returned_at, $1::TIMESTAMP) - l.due_at)) / 86400)::BIGINT
+						   - l.fine_grace_days,
+						   0
+					   ) * l.fine_per_day,
+					   CASE WHEN l.fine_cap > 0 THEN l.fine_cap ELSE 2147483647 END
+				   ) AS fine
+			FROM loans l
+			WHERE l.fine_per_day > 0 AND l.due_at < COALESCE(l.returned_at, $1::TIMESTAMP) AND %s
+		) fines
+		WHERE fine > 0
+		ON CONFLICT (loan_id) WHERE entry_type = 'charge' DO UPDATE
+		SET amount = EXCLUDED.amount, modified_at = EXCLUDED.modified_at
+		WHERE patron_ledger_entries.amount < EXCLUDED.amount
+	`, condition)
+
+	result, err := e.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	accrued, err := result.RowsAffected()
+	return int(accrued), err
+}
+
+// patronBalance menghitung saldo denda anggota yang belum dibayar
+func patronBalance(e dbExecutor, patronID int) (int, error) {
+	var balance int
+	err := e.QueryRow(`
+		SELECT COALESCE(SUM(CASE WHEN entry_type = 'charge' THEN amount ELSE -amount END), 0)
+		FROM patron_ledger_entries
+		WHERE patron_id = $1
+	`, patronID).Scan(&balance)
+
+	return balance, err
+}
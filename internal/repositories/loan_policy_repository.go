@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"book-management/internal/models"
+)
+
+type LoanPolicyRepository struct {
+	db *sql.DB
+}
+
+func NewLoanPolicyRepository(db *sql.DB) *LoanPolicyRepository {
+	return &LoanPolicyRepository{db: db}
+}
+
+func (r *LoanPolicyRepository) GetAll() ([]models.LoanPolicy, error) {
+	query := `
+		SELECT category_id, loan_days, max_renewals, fine_per_day, fine_grace_days, fine_cap,
+			   modified_at, modified_by
+		FROM loan_policies
+		ORDER BY category_id ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []models.LoanPolicy
+	for rows.Next() {
+		var policy models.LoanPolicy
+		err := rows.Scan(
+			&policy.CategoryID,
+			&policy.LoanDays,
+			&policy.MaxRenewals,
+			&policy.FinePerDay,
+			&policy.FineGraceDays,
+			&policy.FineCap,
+			&policy.ModifiedAt,
+			&policy.ModifiedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		categoryID := policy.CategoryID
+		policy.SourceCategoryID = &categoryID
+		policies = append(policies, policy)
+	}
+
+	return policies, rows.Err()
+}
+
+// GetEffective mengembalikan kebijakan kategori atau kebijakan kategori
+// induk terdekat; nil jika tidak ada satu pun di rantai induknya
+func (r *LoanPolicyRepository) GetEffective(categoryID int) (*models.LoanPolicy, error) {
+	query := `
+		WITH RECURSIVE chain AS (
+			SELECT id, parent_id, 0 AS depth FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id, c.parent_id, chain.depth + 1
+			FROM categories c
+			JOIN chain ON c.id = chain.parent_id
+		)
+		SELECT lp.category_id, lp.loan_days, lp.max_renewals, lp.fine_per_day, lp.fine_grace_days,
+			   lp.fine_cap, lp.modified_at, lp.modified_by
+		FROM chain
+		JOIN loan_policies lp ON lp.category_id = chain.id
+		ORDER BY chain.depth ASC
+		LIMIT 1
+	`
+
+	var sourceID int
+	policy := &models.LoanPolicy{CategoryID: categoryID}
+	err := r.db.QueryRow(query, categoryID).Scan(
+		&sourceID,
+		&policy.LoanDays,
+		&policy.MaxRenewals,
+		&policy.FinePerDay,
+		&policy.FineGraceDays,
+		&policy.FineCap,
+		&policy.ModifiedAt,
+		&policy.ModifiedBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	policy.SourceCategoryID = &sourceID
+	policy.Inherited = sourceID != categoryID
+
+	return policy, nil
+}
+
+func (r *LoanPolicyRepository) Save(policy *models.LoanPolicy) error {
+	query := `
+		INSERT INTO loan_policies (category_id, loan_days, max_renewals, fine_per_day, fine_grace_days,
+								   fine_cap, modified_at, modified_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (category_id) DO UPDATE
+		SET loan_days = EXCLUDED.loan_days, max_renewals = EXCLUDED.max_renewals,
+			fine_per_day = EXCLUDED.fine_per_day, fine_grace_days = EXCLUDED.fine_grace_days,
+			fine_cap = EXCLUDED.fine_cap, modified_at = EXCLUDED.modified_at, modified_by = EXCLUDED.modified_by
+	`
+
+	now := time.Now()
+	_, err := r.db.Exec(
+		query,
+		policy.CategoryID,
+		policy.LoanDays,
+		policy.MaxRenewals,
+		policy.FinePerDay,
+		policy.FineGraceDays,
+		policy.FineCap,
+		now,
+		policy.ModifiedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	policy.ModifiedAt = &now
+	return nil
+}
+
+func (r *LoanPolicyRepository) Delete(categoryID int) error {
+	query := `DELETE FROM loan_policies WHERE category_id = $1`
+
+	result, err := r.db.Exec(query, categoryID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
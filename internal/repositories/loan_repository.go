@@ -0,0 +1,421 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrItemNotAvailable dikembalikan ketika eksemplar yang dipinjam tidak
+	// berstatus available atau sudah memiliki pinjaman terbuka
+	ErrItemNotAvailable = errors.New("item not available")
+
+	// ErrPatronSuspended dikembalikan ketika anggota yang meminjam
+	// berstatus suspended
+	ErrPatronSuspended = errors.New("patron suspended")
+
+	// ErrLoanLimitReached dikembalikan ketika anggota sudah mencapai batas
+	// jumlah pinjaman aktif
+	ErrLoanLimitReached = errors.New("loan limit reached")
+
+	// ErrLoanClosed dikembalikan ketika pinjaman yang dikembalikan atau
+	// diperpanjang sudah dikembalikan
+	ErrLoanClosed = errors.New("loan already returned")
+
+	// ErrFinesOutstanding dikembalikan ketika saldo denda anggota melebihi
+	// batas yang diizinkan untuk meminjam
+	ErrFinesOutstanding = errors.New("fines outstanding")
+
+	// ErrItemOnHold dikembalikan ketika eksemplar sedang disisihkan untuk
+	// hold anggota lain
+	ErrItemOnHold = errors.New("item on hold")
+
+	// ErrLoanOverdue, ErrRenewalLimitReached dan ErrHoldsPending dikembalikan
+	// ketika pinjaman tidak dapat diperpanjang
+	ErrLoanOverdue         = errors.New("loan overdue")
+	ErrRenewalLimitReached = errors.New("renewal limit reached")
+	ErrHoldsPending        = errors.New("holds pending")
+)
+
+type LoanRepository struct {
+	db *sql.DB
+}
+
+func NewLoanRepository(db *sql.DB) *LoanRepository {
+	return &LoanRepository{db: db}
+}
+
+// Loan timestamps are stored as UTC, so they are compared with the UTC clock
+const loanSelectQuery = `
+		SELECT l.id, l.item_id, i.barcode, i.book_id, b.title, l.patron_id, p.name,
+			   l.checked_out_at, l.due_at, l.returned_at, l.loan_days, l.renewals, l.max_renewals,
+			   CASE
+				   WHEN l.returned_at IS NOT NULL THEN 'returned'
+				   WHEN l.due_at < (NOW() AT TIME ZONE 'UTC') THEN 'overdue'
+				   ELSE 'active'
+			   END,
+			   COALESCE(f.amount, 0), l.fine_per_day, l.fine_grace_days, l.fine_cap, l.fine_currency,
+			   l.checked_out_by, l.returned_by
+		FROM loans l
+		JOIN items i ON l.item_id = i.id
+		JOIN books b ON i.book_id = b.id
+		JOIN patrons p ON l.patron_id = p.id
+		LEFT JOIN patron_ledger_entries f ON f.loan_id = l.id AND f.entry_type = 'charge'
+`
+
+func scanLoan(row rowScanner) (*models.Loan, error) {
+	loan := &models.Loan{}
+	err := row.Scan(
+		&loan.ID,
+		&loan.ItemID,
+		&loan.Barcode,
+		&loan.BookID,
+		&loan.BookTitle,
+		&loan.PatronID,
+		&loan.PatronName,
+		&loan.CheckedOutAt,
+		&loan.DueAt,
+		&loan.ReturnedAt,
+		&loan.LoanDays,
+		&loan.Renewals,
+		&loan.MaxRenewals,
+		&loan.Status,
+		&loan.Fine,
+		&loan.FinePerDay,
+		&loan.FineGrace,
+		&loan.FineCap,
+		&loan.FineCurrency,
+		&loan.CheckedOutBy,
+		&loan.ReturnedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (r *LoanRepository) GetAll(filter models.LoanFilter) ([]models.Loan, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.PatronID > 0 {
+		args = append(args, filter.PatronID)
+		conditions = append(conditions, fmt.Sprintf("l.patron_id = $%d", len(args)))
+	}
+
+	if filter.ItemID > 0 {
+		args = append(args, filter.ItemID)
+		conditions = append(conditions, fmt.Sprintf("l.item_id = $%d", len(args)))
+	}
+
+	switch filter.Status {
+	case "active":
+		conditions = append(conditions, "l.returned_at IS NULL")
+	case "overdue":
+		conditions = append(conditions, "l.returned_at IS NULL AND l.due_at < (NOW() AT TIME ZONE 'UTC')")
+	case "returned":
+		conditions = append(conditions, "l.returned_at IS NOT NULL")
+	}
+
+	query := loanSelectQuery
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY l.checked_out_at DESC, l.id DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loans []models.Loan
+	for rows.Next() {
+		loan, err := scanLoan(rows)
+		if err != nil {
+			return nil, err
+		}
+		loans = append(loans, *loan)
+	}
+
+	return loans, rows.Err()
+}
+
+func (r *LoanRepository) GetByID(id int) (*models.Loan, error) {
+	query := loanSelectQuery + ` WHERE l.id = $1`
+
+	loan, err := scanLoan(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// Checkout mencatat pinjaman baru dan menandai eksemplarnya on_loan dalam
+// satu transaksi. Baris eksemplar dan anggota dikunci sehingga dua checkout
+// bersamaan untuk eksemplar yang sama tidak mungkin keduanya berhasil;
+// indeks unik pinjaman terbuka per eksemplar menjadi pengaman terakhir.
+// Eksemplar on_hold hanya dapat dipinjam oleh anggota pemilik hold-nya, dan
+// hold terbuka anggota untuk judul tersebut ditandai fulfilled. Anggota
+// dengan saldo denda di atas maxBalance (0 berarti tanpa batas) ditolak.
+func (r *LoanRepository) Checkout(loan *models.Loan, maxActiveLoans, maxBalance, holdPickupDays int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var itemStatus string
+	var bookID int
+	err = tx.QueryRow(`SELECT status, book_id FROM items WHERE id = $1 FOR UPDATE`, loan.ItemID).Scan(&itemStatus, &bookID)
+	if err != nil {
+		return err
+	}
+
+	switch itemStatus {
+	case "available":
+	case "on_hold":
+		var holdPatronID int
+		err := tx.QueryRow(`
+			SELECT patron_id FROM holds WHERE item_id = $1 AND status = 'ready'
+		`, loan.ItemID).Scan(&holdPatronID)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if err == sql.ErrNoRows || holdPatronID != loan.PatronID {
+			return ErrItemOnHold
+		}
+	default:
+		return ErrItemNotAvailable
+	}
+
+	var patronStatus string
+	err = tx.QueryRow(`SELECT status FROM patrons WHERE id = $1 FOR UPDATE`, loan.PatronID).Scan(&patronStatus)
+	if err != nil {
+		return err
+	}
+
+	if patronStatus != "active" {
+		return ErrPatronSuspended
+	}
+
+	if maxBalance > 0 {
+		balance, err := patronBalance(tx, loan.PatronID)
+		if err != nil {
+			return err
+		}
+
+		if balance > maxBalance {
+			return ErrFinesOutstanding
+		}
+	}
+
+	if maxActiveLoans > 0 {
+		var activeLoans int
+		err := tx.QueryRow(`
+			SELECT COUNT(*) FROM loans WHERE patron_id = $1 AND returned_at IS NULL
+		`, loan.PatronID).Scan(&activeLoans)
+		if err != nil {
+			return err
+		}
+
+		if activeLoans >= maxActiveLoans {
+			return ErrLoanLimitReached
+		}
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO loans (item_id, patron_id, checked_out_at, due_at, loan_days, max_renewals,
+						   fine_per_day, fine_grace_days, fine_cap, fine_currency, checked_out_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`,
+		loan.ItemID,
+		loan.PatronID,
+		loan.CheckedOutAt,
+		loan.DueAt,
+		loan.LoanDays,
+		loan.MaxRenewals,
+		loan.FinePerDay,
+		loan.FineGrace,
+		loan.FineCap,
+		loan.FineCurrency,
+		loan.CheckedOutBy,
+	).Scan(&loan.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "idx_loans_active_item" {
+			return ErrItemNotAvailable
+		}
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE items SET status = 'on_loan', modified_at = $1, modified_by = $2 WHERE id = $3
+	`, time.Now(), loan.CheckedOutBy, loan.ItemID)
+	if err != nil {
+		return err
+	}
+
+	var holdID int
+	var holdItemID sql.NullInt64
+	err = tx.QueryRow(`
+		SELECT id, item_id FROM holds
+		WHERE book_id = $1 AND patron_id = $2 AND status IN ('waiting', 'ready')
+		FOR UPDATE
+	`, bookID, loan.PatronID).Scan(&holdID, &holdItemID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if err == nil {
+		_, err := tx.Exec(`
+			UPDATE holds SET status = 'fulfilled', closed_at = $1, closed_by = $2 WHERE id = $3
+		`, loan.CheckedOutAt, loan.CheckedOutBy, holdID)
+		if err != nil {
+			return err
+		}
+
+		// The patron took another copy, so the one set aside goes to the queue
+		if holdItemID.Valid && int(holdItemID.Int64) != loan.ItemID {
+			err := releaseItem(tx, int(holdItemID.Int64), bookID, loan.CheckedOutAt, holdPickupDays, loan.CheckedOutBy)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Return menutup pinjaman dan menetapkan denda akhirnya. Eksemplarnya
+// diberikan ke hold terdepan untuk judul tersebut, atau kembali available
+// bila tidak ada antrean. Eksemplar
+// dikunci lebih dulu, sama seperti Checkout, agar urutan penguncian konsisten.
+func (r *LoanRepository) Return(id int, now time.Time, holdPickupDays int, username string) error {
+	var itemID int
+	err := r.db.QueryRow(`SELECT item_id FROM loans WHERE id = $1`, id).Scan(&itemID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var itemStatus string
+	var bookID int
+	err = tx.QueryRow(`SELECT status, book_id FROM items WHERE id = $1 FOR UPDATE`, itemID).Scan(&itemStatus, &bookID)
+	if err != nil {
+		return err
+	}
+
+	var returnedAt *time.Time
+	err = tx.QueryRow(`SELECT returned_at FROM loans WHERE id = $1 FOR UPDATE`, id).Scan(&returnedAt)
+	if err != nil {
+		return err
+	}
+
+	if returnedAt != nil {
+		return ErrLoanClosed
+	}
+
+	_, err = tx.Exec(`
+		UPDATE loans SET returned_at = $1, returned_by = $2 WHERE id = $3
+	`, now, username, id)
+	if err != nil {
+		return err
+	}
+
+	// The fine is settled with the actual return time
+	if _, err := accrueFines(tx, now, id); err != nil {
+		return err
+	}
+
+	// Items marked lost or damaged while on loan keep that status
+	if itemStatus == "on_loan" {
+		if err := releaseItem(tx, itemID, bookID, now, holdPickupDays, username); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Renew memperpanjang pinjaman selama loan_days sejak sekarang, tanpa
+// pernah memajukan jatuh tempo yang sudah ada. Pinjaman yang sudah
+// terlambat, sudah mencapai batas perpanjangan, atau judulnya sedang
+// ditunggu anggota lain ditolak.
+func (r *LoanRepository) Renew(id int, now time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var dueAt time.Time
+	var returnedAt *time.Time
+	var loanDays, renewals, maxRenewals int
+	err = tx.QueryRow(`
+		SELECT due_at, returned_at, loan_days, renewals, max_renewals
+		FROM loans
+		WHERE id = $1
+		FOR UPDATE
+	`, id).Scan(&dueAt, &returnedAt, &loanDays, &renewals, &maxRenewals)
+	if err != nil {
+		return err
+	}
+
+	if returnedAt != nil {
+		return ErrLoanClosed
+	}
+
+	if dueAt.Before(now) {
+		return ErrLoanOverdue
+	}
+
+	if renewals >= maxRenewals {
+		return ErrRenewalLimitReached
+	}
+
+	var holdsPending bool
+	err = tx.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM holds h
+			JOIN items i ON i.book_id = h.book_id
+			JOIN loans l ON l.item_id = i.id
+			WHERE l.id = $1 AND h.status = 'waiting'
+		)
+	`, id).Scan(&holdsPending)
+	if err != nil {
+		return err
+	}
+
+	if holdsPending {
+		return ErrHoldsPending
+	}
+
+	// Renewing early must not shorten the loan
+	_, err = tx.Exec(`
+		UPDATE loans SET due_at = GREATEST(due_at, $1), renewals = renewals + 1 WHERE id = $2
+	`, now.AddDate(0, 0, loanDays), id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
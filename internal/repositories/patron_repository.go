@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDuplicateCardNumber dikembalikan ketika nomor kartu sudah dipakai
+	// anggota lain
+	ErrDuplicateCardNumber = errors.New("duplicate card number")
+
+	// ErrPatronHasLoans dikembalikan ketika anggota yang dihapus masih
+	// memiliki riwayat peminjaman atau catatan keuangan
+	ErrPatronHasLoans = errors.New("patron has loans")
+)
+
+type PatronRepository struct {
+	db *sql.DB
+}
+
+func NewPatronRepository(db *sql.DB) *PatronRepository {
+	return &PatronRepository{db: db}
+}
+
+const patronSelectQuery = `
+		SELECT p.id, p.card_number, p.name, COALESCE(p.email, ''), COALESCE(p.phone, ''), p.status,
+			   (SELECT COUNT(*) FROM loans l WHERE l.patron_id = p.id AND l.returned_at IS NULL),
+			   (SELECT COALESCE(SUM(CASE WHEN e.entry_type = 'charge' THEN e.amount ELSE -e.amount END), 0)
+				FROM patron_ledger_entries e WHERE e.patron_id = p.id),
+			   p.created_at, p.created_by, p.modified_at, p.modified_by
+		FROM patrons p
+`
+
+func scanPatron(row rowScanner) (*models.Patron, error) {
+	patron := &models.Patron{}
+	err := row.Scan(
+		&patron.ID,
+		&patron.CardNumber,
+		&patron.Name,
+		&patron.Email,
+		&patron.Phone,
+		&patron.Status,
+		&patron.ActiveLoans,
+		&patron.Balance,
+		&patron.CreatedAt,
+		&patron.CreatedBy,
+		&patron.ModifiedAt,
+		&patron.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return patron, nil
+}
+
+// GetAll mengembalikan semua anggota; search mencocokkan nama atau nomor kartu
+func (r *PatronRepository) GetAll(search string) ([]models.Patron, error) {
+	query := patronSelectQuery
+	var args []interface{}
+
+	if search != "" {
+		query += ` WHERE p.name ILIKE $1 OR p.card_number ILIKE $1`
+		args = append(args, "%"+search+"%")
+	}
+	query += ` ORDER BY p.name ASC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var patrons []models.Patron
+	for rows.Next() {
+		patron, err := scanPatron(rows)
+		if err != nil {
+			return nil, err
+		}
+		patrons = append(patrons, *patron)
+	}
+
+	return patrons, rows.Err()
+}
+
+func (r *PatronRepository) GetByID(id int) (*models.Patron, error) {
+	query := patronSelectQuery + ` WHERE p.id = $1`
+
+	patron, err := scanPatron(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return patron, nil
+}
+
+func (r *PatronRepository) Create(patron *models.Patron) error {
+	query := `
+		INSERT INTO patrons (card_number, name, email, phone, status, created_by, modified_by)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7)
+		RETURNING id, created_at, modified_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		patron.CardNumber,
+		patron.Name,
+		patron.Email,
+		patron.Phone,
+		patron.Status,
+		patron.CreatedBy,
+		patron.ModifiedBy,
+	).Scan(&patron.ID, &patron.CreatedAt, &patron.ModifiedAt)
+
+	return patronUniqueError(err)
+}
+
+func (r *PatronRepository) Update(patron *models.Patron) error {
+	query := `
+		UPDATE patrons
+		SET card_number = $1, name = $2, email = NULLIF($3, ''), phone = NULLIF($4, ''),
+			status = $5, modified_by = $6, modified_at = $7
+		WHERE id = $8
+	`
+
+	patron.ModifiedAt = time.Now()
+	result, err := r.db.Exec(
+		query,
+		patron.CardNumber,
+		patron.Name,
+		patron.Email,
+		patron.Phone,
+		patron.Status,
+		patron.ModifiedBy,
+		patron.ModifiedAt,
+		patron.ID,
+	)
+	if err != nil {
+		return patronUniqueError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PatronRepository) Delete(id int) error {
+	query := `DELETE FROM patrons WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrPatronHasLoans
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// patronUniqueError menerjemahkan pelanggaran indeks unik nomor kartu
+func patronUniqueError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "idx_patrons_card_number" {
+		return ErrDuplicateCardNumber
+	}
+
+	return err
+}
+
+// isForeignKeyViolation bernilai true bila baris yang dihapus masih dirujuk
+// tabel lain dengan ON DELETE RESTRICT
+func isForeignKeyViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == "23503"
+}
@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"book-management/internal/models"
+)
+
+// scheduledPriceBatchSize membatasi jumlah perubahan harga terjadwal yang
+// diterapkan dalam satu transaksi
+const scheduledPriceBatchSize = 100
+
+type PriceRepository struct {
+	db *sql.DB
+}
+
+func NewPriceRepository(db *sql.DB) *PriceRepository {
+	return &PriceRepository{db: db}
+}
+
+func (r *PriceRepository) GetHistory(bookID int) ([]models.PriceHistory, error) {
+	query := `
+		SELECT id, book_id, old_price, COALESCE(old_currency, ''), new_price, new_currency,
+			   source, changed_at, changed_by
+		FROM price_history
+		WHERE book_id = $1
+		ORDER BY changed_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []models.PriceHistory
+	for rows.Next() {
+		var entry models.PriceHistory
+		err := rows.Scan(
+			&entry.ID,
+			&entry.BookID,
+			&entry.OldPrice,
+			&entry.OldCurrency,
+			&entry.NewPrice,
+			&entry.NewCurrency,
+			&entry.Source,
+			&entry.ChangedAt,
+			&entry.ChangedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, entry)
+	}
+
+	return history, rows.Err()
+}
+
+func (r *PriceRepository) GetScheduledChanges(bookID int) ([]models.ScheduledPriceChange, error) {
+	query := `
+		SELECT id, book_id, price, currency, effective_at, status, applied_at, created_at, created_by
+		FROM scheduled_price_changes
+		WHERE book_id = $1
+		ORDER BY effective_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []models.ScheduledPriceChange
+	for rows.Next() {
+		var change models.ScheduledPriceChange
+		err := rows.Scan(
+			&change.ID,
+			&change.BookID,
+			&change.Price,
+			&change.Currency,
+			&change.EffectiveAt,
+			&change.Status,
+			&change.AppliedAt,
+			&change.CreatedAt,
+			&change.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+func (r *PriceRepository) CreateScheduledChange(change *models.ScheduledPriceChange) error {
+	query := `
+		INSERT INTO scheduled_price_changes (book_id, price, currency, effective_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		change.BookID,
+		change.Price,
+		change.Currency,
+		change.EffectiveAt,
+		change.CreatedBy,
+	).Scan(&change.ID, &change.Status, &change.CreatedAt)
+}
+
+// CancelScheduledChange membatalkan perubahan harga yang belum diterapkan
+func (r *PriceRepository) CancelScheduledChange(bookID, changeID int) error {
+	query := `
+		UPDATE scheduled_price_changes
+		SET status = 'cancelled'
+		WHERE id = $1 AND book_id = $2 AND status = 'pending'
+	`
+
+	result, err := r.db.Exec(query, changeID, bookID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ApplyDueChanges menerapkan perubahan harga terjadwal yang sudah jatuh
+// tempo, paling banyak scheduledPriceBatchSize sekaligus. Baris yang sedang
+// dikunci instance lain dilewati sehingga aman dijalankan paralel.
+func (r *PriceRepository) ApplyDueChanges(now time.Time) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, book_id, price, currency, created_by
+		FROM scheduled_price_changes
+		WHERE status = 'pending' AND effective_at <= $1
+		ORDER BY effective_at, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, now, scheduledPriceBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var changes []models.ScheduledPriceChange
+	for rows.Next() {
+		var change models.ScheduledPriceChange
+		if err := rows.Scan(&change.ID, &change.BookID, &change.Price, &change.Currency, &change.CreatedBy); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		changes = append(changes, change)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, change := range changes {
+		var oldPrice sql.NullInt64
+		var oldCurrency string
+		err := tx.QueryRow(`SELECT price, currency FROM books WHERE id = $1 FOR UPDATE`, change.BookID).Scan(&oldPrice, &oldCurrency)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(`
+			UPDATE books SET price = $1, currency = $2, modified_at = $3, modified_by = $4
+			WHERE id = $5
+		`, change.Price, change.Currency, now, change.CreatedBy, change.BookID)
+		if err != nil {
+			return 0, err
+		}
+
+		err = recordPriceChange(tx, change.BookID, oldPrice, oldCurrency, change.Price, change.Currency, "scheduled", change.CreatedBy)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(`
+			UPDATE scheduled_price_changes SET status = 'applied', applied_at = $1 WHERE id = $2
+		`, now, change.ID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(changes), nil
+}
+
+// recordPriceChange menulis riwayat harga bila harga atau mata uang berubah
+func recordPriceChange(tx *sql.Tx, bookID int, oldPrice sql.NullInt64, oldCurrency string, newPrice int, newCurrency, source, username string) error {
+	if oldPrice.Valid && oldPrice.Int64 == int64(newPrice) && oldCurrency == newCurrency {
+		return nil
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO price_history (book_id, old_price, old_currency, new_price, new_currency, source, changed_by)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)
+	`, bookID, oldPrice, oldCurrency, newPrice, newCurrency, source, username)
+
+	return err
+}
@@ -0,0 +1,391 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrBookAlreadyInList dikembalikan ketika buku sudah ada di daftar bacaan
+	ErrBookAlreadyInList = errors.New("book already in list")
+
+	// ErrReorderMismatch dikembalikan ketika urutan baru tidak berisi tepat
+	// seluruh buku di daftar bacaan
+	ErrReorderMismatch = errors.New("reorder does not match list books")
+)
+
+// defaultShelves adalah rak bawaan setiap pengguna beserta namanya
+var defaultShelves = []struct {
+	Shelf string
+	Name  string
+}{
+	{"want_to_read", "Want to Read"},
+	{"reading", "Currently Reading"},
+	{"read", "Read"},
+}
+
+type ReadingListRepository struct {
+	db *sql.DB
+}
+
+func NewReadingListRepository(db *sql.DB) *ReadingListRepository {
+	return &ReadingListRepository{db: db}
+}
+
+const readingListSelectQuery = `
+		SELECT l.id, l.user_id, u.username, COALESCE(l.shelf, ''), l.name, COALESCE(l.description, ''),
+			   l.visibility, COALESCE(l.share_token, ''),
+			   (SELECT COUNT(*) FROM reading_list_books lb WHERE lb.list_id = l.id),
+			   l.created_at, l.modified_at
+		FROM reading_lists l
+		JOIN users u ON l.user_id = u.id
+`
+
+// readingListOrder menampilkan rak bawaan lebih dulu, lalu daftar buatan
+// pengguna menurut nama
+const readingListOrder = `
+		ORDER BY CASE l.shelf WHEN 'want_to_read' THEN 1 WHEN 'reading' THEN 2 WHEN 'read' THEN 3 ELSE 4 END,
+				 l.name, l.id
+`
+
+func scanReadingList(row rowScanner) (*models.ReadingList, error) {
+	list := &models.ReadingList{}
+	err := row.Scan(
+		&list.ID,
+		&list.UserID,
+		&list.Username,
+		&list.Shelf,
+		&list.Name,
+		&list.Description,
+		&list.Visibility,
+		&list.ShareToken,
+		&list.BookCount,
+		&list.CreatedAt,
+		&list.ModifiedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// EnsureShelves membuat rak bawaan pengguna yang belum ada
+func (r *ReadingListRepository) EnsureShelves(userID int) error {
+	now := time.Now()
+	for _, shelf := range defaultShelves {
+		_, err := r.db.Exec(`
+			INSERT INTO reading_lists (user_id, shelf, name, created_at, modified_at)
+			VALUES ($1, $2, $3, $4, $4)
+			ON CONFLICT (user_id, shelf) WHERE shelf IS NOT NULL DO NOTHING
+		`, userID, shelf.Shelf, shelf.Name, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByUser mengembalikan daftar bacaan pengguna; publicOnly membatasi hasil
+// pada daftar yang dapat dilihat pengguna lain
+func (r *ReadingListRepository) GetByUser(userID int, publicOnly bool) ([]models.ReadingList, error) {
+	query := readingListSelectQuery + ` WHERE l.user_id = $1`
+	if publicOnly {
+		query += ` AND l.visibility = 'public'`
+	}
+	query += readingListOrder
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lists []models.ReadingList
+	for rows.Next() {
+		list, err := scanReadingList(rows)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, *list)
+	}
+
+	return lists, rows.Err()
+}
+
+func (r *ReadingListRepository) GetByID(id int) (*models.ReadingList, error) {
+	return r.getOne(readingListSelectQuery+` WHERE l.id = $1`, id)
+}
+
+func (r *ReadingListRepository) GetByShareToken(token string) (*models.ReadingList, error) {
+	return r.getOne(readingListSelectQuery+` WHERE l.share_token = $1`, token)
+}
+
+func (r *ReadingListRepository) getOne(query string, arg interface{}) (*models.ReadingList, error) {
+	list, err := scanReadingList(r.db.QueryRow(query, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetBooks mengembalikan buku di daftar bacaan sesuai urutannya
+func (r *ReadingListRepository) GetBooks(listID int) ([]models.ReadingListBook, error) {
+	rows, err := r.db.Query(`
+		SELECT lb.book_id, b.title, b.total_page, lb.position, COALESCE(lb.note, ''), lb.added_at
+		FROM reading_list_books lb
+		JOIN books b ON lb.book_id = b.id
+		WHERE lb.list_id = $1
+		ORDER BY lb.position, lb.added_at
+	`, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := []models.ReadingListBook{}
+	for rows.Next() {
+		var book models.ReadingListBook
+		if err := rows.Scan(&book.BookID, &book.Title, &book.TotalPage, &book.Position, &book.Note, &book.AddedAt); err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
+func (r *ReadingListRepository) Create(list *models.ReadingList) error {
+	query := `
+		INSERT INTO reading_lists (user_id, name, description, visibility, created_at, modified_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $5)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(query, list.UserID, list.Name, list.Description, list.Visibility, time.Now()).Scan(&list.ID)
+}
+
+func (r *ReadingListRepository) Update(list *models.ReadingList) error {
+	query := `
+		UPDATE reading_lists SET name = $1, description = NULLIF($2, ''), visibility = $3, modified_at = $4
+		WHERE id = $5
+	`
+
+	result, err := r.db.Exec(query, list.Name, list.Description, list.Visibility, time.Now(), list.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetShareToken memasang token tautan berbagi; token kosong mencabut tautan
+func (r *ReadingListRepository) SetShareToken(id int, token string) error {
+	query := `UPDATE reading_lists SET share_token = NULLIF($1, ''), modified_at = $2 WHERE id = $3`
+
+	result, err := r.db.Exec(query, token, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *ReadingListRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM reading_lists WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// AddBook menambahkan buku di akhir daftar bacaan. Sebuah buku hanya berada
+// di satu rak bawaan, sehingga menaruhnya di rak bawaan memindahkannya dari
+// rak bawaan lain milik pengguna yang sama.
+func (r *ReadingListRepository) AddBook(list *models.ReadingList, bookID int, note string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the list so concurrent additions get distinct positions
+	if _, err := tx.Exec(`SELECT id FROM reading_lists WHERE id = $1 FOR UPDATE`, list.ID); err != nil {
+		return err
+	}
+
+	if list.Shelf != "" {
+		rows, err := tx.Query(`
+			DELETE FROM reading_list_books lb
+			USING reading_lists l
+			WHERE lb.list_id = l.id AND l.user_id = $1 AND l.shelf IS NOT NULL AND l.id <> $2 AND lb.book_id = $3
+			RETURNING lb.list_id, lb.position
+		`, list.UserID, list.ID, bookID)
+		if err != nil {
+			return err
+		}
+
+		type removal struct{ listID, position int }
+		var removed []removal
+		for rows.Next() {
+			var item removal
+			if err := rows.Scan(&item.listID, &item.position); err != nil {
+				rows.Close()
+				return err
+			}
+			removed = append(removed, item)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, item := range removed {
+			if err := closePositionGap(tx, item.listID, item.position); err != nil {
+				return err
+			}
+		}
+	}
+
+	now := time.Now()
+	_, err = tx.Exec(`
+		INSERT INTO reading_list_books (list_id, book_id, position, note, added_at)
+		VALUES ($1, $2, (SELECT COALESCE(MAX(position), 0) + 1 FROM reading_list_books WHERE list_id = $1), NULLIF($3, ''), $4)
+	`, list.ID, bookID, note, now)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrBookAlreadyInList
+		}
+		return err
+	}
+
+	if err := touchReadingList(tx, list.ID, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ReadingListRepository) RemoveBook(listID, bookID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var position int
+	err = tx.QueryRow(`
+		DELETE FROM reading_list_books WHERE list_id = $1 AND book_id = $2
+		RETURNING position
+	`, listID, bookID).Scan(&position)
+	if err != nil {
+		return err
+	}
+
+	if err := closePositionGap(tx, listID, position); err != nil {
+		return err
+	}
+
+	if err := touchReadingList(tx, listID, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Reorder menyusun ulang posisi buku di daftar bacaan sesuai urutan bookIDs
+func (r *ReadingListRepository) Reorder(listID int, bookIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM reading_lists WHERE id = $1 FOR UPDATE`, listID); err != nil {
+		return err
+	}
+
+	ids := pq.Array(toInt64s(bookIDs))
+
+	var total, matched int
+	err = tx.QueryRow(`
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE book_id = ANY($2))
+		FROM reading_list_books WHERE list_id = $1
+	`, listID, ids).Scan(&total, &matched)
+	if err != nil {
+		return err
+	}
+
+	if total != len(bookIDs) || matched != len(bookIDs) {
+		return ErrReorderMismatch
+	}
+
+	_, err = tx.Exec(`
+		UPDATE reading_list_books lb SET position = o.position
+		FROM unnest($2::int[]) WITH ORDINALITY AS o(book_id, position)
+		WHERE lb.list_id = $1 AND lb.book_id = o.book_id
+	`, listID, ids)
+	if err != nil {
+		return err
+	}
+
+	if err := touchReadingList(tx, listID, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// closePositionGap menggeser buku setelah posisi yang dihapus agar urutan
+// tetap bersambung
+func closePositionGap(tx *sql.Tx, listID, position int) error {
+	_, err := tx.Exec(`
+		UPDATE reading_list_books SET position = position - 1
+		WHERE list_id = $1 AND position > $2
+	`, listID, position)
+
+	return err
+}
+
+func touchReadingList(tx *sql.Tx, listID int, now time.Time) error {
+	_, err := tx.Exec(`UPDATE reading_lists SET modified_at = $1 WHERE id = $2`, now, listID)
+	return err
+}
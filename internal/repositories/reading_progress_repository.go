@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"book-management/internal/models"
+)
+
+type ReadingProgressRepository struct {
+	db *sql.DB
+}
+
+func NewReadingProgressRepository(db *sql.DB) *ReadingProgressRepository {
+	return &ReadingProgressRepository{db: db}
+}
+
+const readingProgressSelectQuery = `
+		SELECT p.user_id, p.book_id, b.title, p.current_page, b.total_page,
+			   TO_CHAR(p.started_at, 'YYYY-MM-DD'), TO_CHAR(p.finished_at, 'YYYY-MM-DD'), p.modified_at
+		FROM reading_progress p
+		JOIN books b ON p.book_id = b.id
+`
+
+func scanReadingProgress(row rowScanner) (*models.ReadingProgress, error) {
+	progress := &models.ReadingProgress{}
+	err := row.Scan(
+		&progress.UserID,
+		&progress.BookID,
+		&progress.BookTitle,
+		&progress.CurrentPage,
+		&progress.TotalPage,
+		&progress.StartedAt,
+		&progress.FinishedAt,
+		&progress.ModifiedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+func (r *ReadingProgressRepository) GetByUser(userID int) ([]models.ReadingProgress, error) {
+	query := readingProgressSelectQuery + ` WHERE p.user_id = $1 ORDER BY p.modified_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var progress []models.ReadingProgress
+	for rows.Next() {
+		item, err := scanReadingProgress(rows)
+		if err != nil {
+			return nil, err
+		}
+		progress = append(progress, *item)
+	}
+
+	return progress, rows.Err()
+}
+
+func (r *ReadingProgressRepository) Get(userID, bookID int) (*models.ReadingProgress, error) {
+	query := readingProgressSelectQuery + ` WHERE p.user_id = $1 AND p.book_id = $2`
+
+	progress, err := scanReadingProgress(r.db.QueryRow(query, userID, bookID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+// Save membuat atau memperbarui kemajuan membaca pengguna untuk sebuah buku
+func (r *ReadingProgressRepository) Save(progress *models.ReadingProgress) error {
+	query := `
+		INSERT INTO reading_progress (user_id, book_id, current_page, started_at, finished_at, modified_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, book_id) DO UPDATE
+		SET current_page = EXCLUDED.current_page, started_at = EXCLUDED.started_at,
+			finished_at = EXCLUDED.finished_at, modified_at = EXCLUDED.modified_at
+	`
+
+	_, err := r.db.Exec(query, progress.UserID, progress.BookID, progress.CurrentPage,
+		progress.StartedAt, progress.FinishedAt, time.Now())
+
+	return err
+}
+
+func (r *ReadingProgressRepository) Delete(userID, bookID int) error {
+	result, err := r.db.Exec(`DELETE FROM reading_progress WHERE user_id = $1 AND book_id = $2`, userID, bookID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
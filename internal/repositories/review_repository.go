@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateReview dikembalikan ketika pengguna sudah mengulas buku yang sama
+var ErrDuplicateReview = errors.New("duplicate review")
+
+type ReviewRepository struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(db *sql.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+const reviewSelectQuery = `
+		SELECT r.id, r.book_id, b.title, r.user_id, u.username, r.rating, COALESCE(r.title, ''),
+			   COALESCE(r.body, ''), r.status, COALESCE(r.moderation_reason, ''), r.moderated_at,
+			   COALESCE(r.moderated_by, ''), r.created_at, r.modified_at
+		FROM reviews r
+		JOIN books b ON r.book_id = b.id
+		JOIN users u ON r.user_id = u.id
+`
+
+func scanReview(row rowScanner) (*models.Review, error) {
+	review := &models.Review{}
+	err := row.Scan(
+		&review.ID,
+		&review.BookID,
+		&review.BookTitle,
+		&review.UserID,
+		&review.Username,
+		&review.Rating,
+		&review.Title,
+		&review.Body,
+		&review.Status,
+		&review.ModerationReason,
+		&review.ModeratedAt,
+		&review.ModeratedBy,
+		&review.CreatedAt,
+		&review.ModifiedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *ReviewRepository) GetAll(filter models.ReviewFilter) ([]models.Review, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.BookID > 0 {
+		args = append(args, filter.BookID)
+		conditions = append(conditions, fmt.Sprintf("r.book_id = $%d", len(args)))
+	}
+
+	if filter.UserID > 0 {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("r.user_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("r.status = $%d", len(args)))
+	}
+
+	query := reviewSelectQuery
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY r.created_at DESC, r.id DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []models.Review
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *ReviewRepository) GetByID(id int) (*models.Review, error) {
+	query := reviewSelectQuery + ` WHERE r.id = $1`
+
+	review, err := scanReview(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *ReviewRepository) Create(review *models.Review) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockBookRating(tx, review.BookID); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO reviews (book_id, user_id, rating, title, body, created_at, modified_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $6)
+		RETURNING id
+	`, review.BookID, review.UserID, review.Rating, review.Title, review.Body, time.Now()).Scan(&review.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "idx_reviews_book_user" {
+			return ErrDuplicateReview
+		}
+		return err
+	}
+
+	if err := refreshBookRating(tx, review.BookID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ReviewRepository) Update(review *models.Review) error {
+	return r.mutate(review.ID, `
+		UPDATE reviews SET rating = $1, title = NULLIF($2, ''), body = NULLIF($3, ''), modified_at = $4
+		WHERE id = $5
+		RETURNING book_id
+	`, review.Rating, review.Title, review.Body, time.Now(), review.ID)
+}
+
+// SetStatus menampilkan atau menyembunyikan ulasan beserta alasan moderasinya
+func (r *ReviewRepository) SetStatus(id int, status, reason, moderator string) error {
+	return r.mutate(id, `
+		UPDATE reviews
+		SET status = $1, moderation_reason = NULLIF($2, ''), moderated_at = $3, moderated_by = $4
+		WHERE id = $5
+		RETURNING book_id
+	`, status, reason, time.Now(), moderator, id)
+}
+
+func (r *ReviewRepository) Delete(id int) error {
+	return r.mutate(id, `DELETE FROM reviews WHERE id = $1 RETURNING book_id`, id)
+}
+
+// mutate menjalankan perubahan pada satu ulasan (query harus mengembalikan
+// book_id) lalu menghitung ulang rating bukunya dalam transaksi yang sama
+func (r *ReviewRepository) mutate(id int, query string, args ...interface{}) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var lockedID int
+	if err := tx.QueryRow(`SELECT book_id FROM reviews WHERE id = $1`, id).Scan(&lockedID); err != nil {
+		return err
+	}
+	if err := lockBookRating(tx, lockedID); err != nil {
+		return err
+	}
+
+	var bookID int
+	if err := tx.QueryRow(query, args...).Scan(&bookID); err != nil {
+		return err
+	}
+
+	// A merge may have moved the review to another book before the lock
+	if bookID != lockedID {
+		if err := lockBookRating(tx, bookID); err != nil {
+			return err
+		}
+	}
+
+	if err := refreshBookRating(tx, bookID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockBookRating mengunci baris buku sebelum ulasannya diubah. Tanpa kunci
+// ini, dua transaksi bersamaan menghitung rating dari snapshot yang belum
+// memuat ulasan satu sama lain dan yang terakhir menyimpan nilai yang salah.
+// Urutannya (buku lalu ulasan) sama dengan penggabungan buku.
+func lockBookRating(tx *sql.Tx, bookID int) error {
+	_, err := tx.Exec(`SELECT 1 FROM books WHERE id = $1 FOR UPDATE`, bookID)
+	return err
+}
+
+// refreshBookRating menghitung ulang rata-rata dan jumlah rating buku dari
+// ulasan yang terlihat; baris buku harus sudah dikunci dengan lockBookRating
+// dalam transaksi yang sama
+func refreshBookRating(tx *sql.Tx, bookID int) error {
+	_, err := tx.Exec(`
+		UPDATE books b
+		SET rating_average = COALESCE(stats.average, 0), rating_count = stats.total
+		FROM (
+			SELECT ROUND(AVG(rating), 2) AS average, COUNT(*) AS total
+			FROM reviews
+			WHERE book_id = $1 AND status = 'visible'
+		) stats
+		WHERE b.id = $1
+	`, bookID)
+
+	return err
+}
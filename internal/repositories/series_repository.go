@@ -0,0 +1,308 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDuplicateSeriesName dikembalikan ketika nama seri sudah dipakai
+	ErrDuplicateSeriesName = errors.New("duplicate series name")
+
+	// ErrBookAlreadyInSeries dikembalikan ketika buku sudah menjadi volume seri
+	ErrBookAlreadyInSeries = errors.New("book already in series")
+
+	// ErrDuplicateVolume dikembalikan ketika nomor volume sudah dipakai buku
+	// lain di seri yang sama
+	ErrDuplicateVolume = errors.New("duplicate volume number")
+)
+
+type SeriesRepository struct {
+	db *sql.DB
+}
+
+func NewSeriesRepository(db *sql.DB) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+const seriesSelectQuery = `
+		SELECT s.id, s.name, COALESCE(s.description, ''),
+			   (SELECT COUNT(*) FROM series_books sb WHERE sb.series_id = s.id),
+			   s.created_at, s.created_by, s.modified_at, s.modified_by
+		FROM series s
+`
+
+func scanSeries(row rowScanner) (*models.Series, error) {
+	series := &models.Series{}
+	err := row.Scan(
+		&series.ID,
+		&series.Name,
+		&series.Description,
+		&series.VolumeCount,
+		&series.CreatedAt,
+		&series.CreatedBy,
+		&series.ModifiedAt,
+		&series.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r *SeriesRepository) GetAll(search string) ([]models.Series, error) {
+	query := seriesSelectQuery
+	var args []interface{}
+
+	if search != "" {
+		query += ` WHERE s.name ILIKE $1`
+		args = append(args, "%"+search+"%")
+	}
+	query += ` ORDER BY s.name ASC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []models.Series
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *series)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *SeriesRepository) GetByID(id int) (*models.Series, error) {
+	query := seriesSelectQuery + ` WHERE s.id = $1`
+
+	series, err := scanSeries(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// GetVolumes mengembalikan buku di dalam seri sesuai nomor volume
+func (r *SeriesRepository) GetVolumes(seriesID int) ([]models.SeriesVolume, error) {
+	rows, err := r.db.Query(`
+		SELECT sb.book_id, b.title, b.release_year, sb.volume_number
+		FROM series_books sb
+		JOIN books b ON sb.book_id = b.id
+		WHERE sb.series_id = $1
+		ORDER BY sb.volume_number ASC
+	`, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	volumes := []models.SeriesVolume{}
+	for rows.Next() {
+		var volume models.SeriesVolume
+		if err := rows.Scan(&volume.BookID, &volume.Title, &volume.ReleaseYear, &volume.VolumeNumber); err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, rows.Err()
+}
+
+// GetBookSeries mengembalikan seri yang memuat bookID beserta volume sebelum
+// dan sesudahnya
+func (r *SeriesRepository) GetBookSeries(bookID int) ([]models.BookSeriesEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT series_id, name, volume_number,
+			   prev_book_id, prev_title, prev_year, prev_volume,
+			   next_book_id, next_title, next_year, next_volume
+		FROM (
+			SELECT sb.series_id, s.name, sb.book_id, sb.volume_number,
+				   LAG(sb.book_id) OVER w AS prev_book_id, LAG(b.title) OVER w AS prev_title,
+				   LAG(b.release_year) OVER w AS prev_year, LAG(sb.volume_number) OVER w AS prev_volume,
+				   LEAD(sb.book_id) OVER w AS next_book_id, LEAD(b.title) OVER w AS next_title,
+				   LEAD(b.release_year) OVER w AS next_year, LEAD(sb.volume_number) OVER w AS next_volume
+			FROM series_books sb
+			JOIN series s ON sb.series_id = s.id
+			JOIN books b ON sb.book_id = b.id
+			WHERE sb.series_id IN (SELECT series_id FROM series_books WHERE book_id = $1)
+			WINDOW w AS (PARTITION BY sb.series_id ORDER BY sb.volume_number)
+		) volumes
+		WHERE book_id = $1
+		ORDER BY name ASC
+	`, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.BookSeriesEntry{}
+	for rows.Next() {
+		var entry models.BookSeriesEntry
+		var prevID, nextID, prevYear, nextYear sql.NullInt64
+		var prevTitle, nextTitle sql.NullString
+		var prevVolume, nextVolume sql.NullFloat64
+		err := rows.Scan(
+			&entry.SeriesID, &entry.SeriesName, &entry.VolumeNumber,
+			&prevID, &prevTitle, &prevYear, &prevVolume,
+			&nextID, &nextTitle, &nextYear, &nextVolume,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if prevID.Valid {
+			entry.Previous = &models.SeriesVolume{
+				BookID:       int(prevID.Int64),
+				Title:        prevTitle.String,
+				ReleaseYear:  int(prevYear.Int64),
+				VolumeNumber: prevVolume.Float64,
+			}
+		}
+		if nextID.Valid {
+			entry.Next = &models.SeriesVolume{
+				BookID:       int(nextID.Int64),
+				Title:        nextTitle.String,
+				ReleaseYear:  int(nextYear.Int64),
+				VolumeNumber: nextVolume.Float64,
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *SeriesRepository) Create(series *models.Series) error {
+	query := `
+		INSERT INTO series (name, description, created_by, modified_by)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
+		RETURNING id, created_at, modified_at
+	`
+
+	err := r.db.QueryRow(query, series.Name, series.Description, series.CreatedBy, series.ModifiedBy).
+		Scan(&series.ID, &series.CreatedAt, &series.ModifiedAt)
+
+	return seriesUniqueError(err)
+}
+
+func (r *SeriesRepository) Update(series *models.Series) error {
+	query := `
+		UPDATE series
+		SET name = $1, description = NULLIF($2, ''), modified_by = $3, modified_at = $4
+		WHERE id = $5
+	`
+
+	series.ModifiedAt = time.Now()
+	result, err := r.db.Exec(query, series.Name, series.Description, series.ModifiedBy, series.ModifiedAt, series.ID)
+	if err != nil {
+		return seriesUniqueError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *SeriesRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM series WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *SeriesRepository) AddBook(seriesID, bookID int, volumeNumber float64, username string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO series_books (series_id, book_id, volume_number, created_by)
+		VALUES ($1, $2, $3, $4)
+	`, seriesID, bookID, volumeNumber, username)
+
+	return seriesUniqueError(err)
+}
+
+func (r *SeriesRepository) UpdateVolume(seriesID, bookID int, volumeNumber float64) error {
+	result, err := r.db.Exec(`
+		UPDATE series_books SET volume_number = $1 WHERE series_id = $2 AND book_id = $3
+	`, volumeNumber, seriesID, bookID)
+	if err != nil {
+		return seriesUniqueError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *SeriesRepository) RemoveBook(seriesID, bookID int) error {
+	result, err := r.db.Exec(`DELETE FROM series_books WHERE series_id = $1 AND book_id = $2`, seriesID, bookID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// seriesUniqueError menerjemahkan pelanggaran indeks unik seri
+func seriesUniqueError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		switch pqErr.Constraint {
+		case "idx_series_name":
+			return ErrDuplicateSeriesName
+		case "series_books_pkey":
+			return ErrBookAlreadyInSeries
+		case "idx_series_books_volume":
+			return ErrDuplicateVolume
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type SimilarityRepository struct {
+	db *sql.DB
+}
+
+func NewSimilarityRepository(db *sql.DB) *SimilarityRepository {
+	return &SimilarityRepository{db: db}
+}
+
+// bookReadersQuery berisi pembaca setiap buku: anggota yang pernah
+// meminjamnya dan pengguna yang memberinya rating 4 ke atas
+const bookReadersQuery = `
+		WITH readers AS (
+			SELECT DISTINCT 'p' || l.patron_id AS reader, i.book_id
+			FROM loans l
+			JOIN items i ON l.item_id = i.id
+			UNION
+			SELECT 'u' || r.user_id, r.book_id
+			FROM reviews r
+			WHERE r.status = 'visible' AND r.rating >= 4
+		)
+`
+
+// GetSimilar mengembalikan buku yang paling mirip dengan bookID
+func (r *SimilarityRepository) GetSimilar(bookID, limit int) ([]models.SimilarBook, error) {
+	query := `
+		SELECT s.similar_book_id, b.title, b.release_year, b.category_id, c.name, b.rating_average,
+			   s.score, s.category_score, s.text_score, s.year_score, s.activity_score, s.computed_at
+		FROM book_similarities s
+		JOIN books b ON s.similar_book_id = b.id
+		JOIN categories c ON b.category_id = c.id
+		WHERE s.book_id = $1
+		ORDER BY s.score DESC, s.similar_book_id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, bookID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := []models.SimilarBook{}
+	for rows.Next() {
+		var book models.SimilarBook
+		err := rows.Scan(
+			&book.BookID,
+			&book.Title,
+			&book.ReleaseYear,
+			&book.CategoryID,
+			&book.CategoryName,
+			&book.RatingAverage,
+			&book.Score,
+			&book.CategoryScore,
+			&book.TextScore,
+			&book.YearScore,
+			&book.ActivityScore,
+			&book.ComputedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
+// GetInputs mengembalikan data semua buku untuk perhitungan kemiripan
+func (r *SimilarityRepository) GetInputs() ([]models.SimilarityInput, error) {
+	rows, err := r.db.Query(`
+		SELECT b.id, b.title, COALESCE(b.description, ''), b.release_year,
+			   COALESCE((SELECT ARRAY_AGG(bc.category_id) FROM book_categories bc WHERE bc.book_id = b.id), '{}'),
+			   COALESCE((SELECT ARRAY_AGG(t.name) FROM book_tags bt JOIN tags t ON bt.tag_id = t.id WHERE bt.book_id = b.id), '{}')
+		FROM books b
+		ORDER BY b.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var inputs []models.SimilarityInput
+	for rows.Next() {
+		var input models.SimilarityInput
+		var categoryIDs pq.Int64Array
+		var tags pq.StringArray
+		if err := rows.Scan(&input.BookID, &input.Title, &input.Description, &input.ReleaseYear, &categoryIDs, &tags); err != nil {
+			return nil, err
+		}
+		for _, id := range categoryIDs {
+			input.CategoryIDs = append(input.CategoryIDs, int(id))
+		}
+		input.Tags = tags
+		inputs = append(inputs, input)
+	}
+
+	return inputs, rows.Err()
+}
+
+// GetReaderCounts mengembalikan jumlah pembaca setiap buku dan jumlah pembaca
+// yang sama untuk setiap pasang buku (kunci pasangan selalu [kecil, besar])
+func (r *SimilarityRepository) GetReaderCounts() (map[int]int, map[[2]int]int, error) {
+	readers := make(map[int]int)
+	rows, err := r.db.Query(bookReadersQuery + `SELECT book_id, COUNT(*) FROM readers GROUP BY book_id`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID, count int
+		if err := rows.Scan(&bookID, &count); err != nil {
+			return nil, nil, err
+		}
+		readers[bookID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	shared := make(map[[2]int]int)
+	pairRows, err := r.db.Query(bookReadersQuery + `
+		SELECT a.book_id, b.book_id, COUNT(*)
+		FROM readers a
+		JOIN readers b ON a.reader = b.reader AND a.book_id < b.book_id
+		GROUP BY a.book_id, b.book_id
+	`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer pairRows.Close()
+
+	for pairRows.Next() {
+		var first, second, count int
+		if err := pairRows.Scan(&first, &second, &count); err != nil {
+			return nil, nil, err
+		}
+		shared[[2]int{first, second}] = count
+	}
+
+	return readers, shared, pairRows.Err()
+}
+
+// Replace mengganti seluruh isi tabel kemiripan dengan hasil perhitungan
+// terbaru dalam satu transaksi, sehingga pembaca tidak pernah melihat tabel
+// yang setengah terisi
+func (r *SimilarityRepository) Replace(scores []models.SimilarityScore, computedAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM book_similarities`); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO book_similarities (book_id, similar_book_id, score, category_score, text_score,
+									   year_score, activity_score, computed_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8
+		WHERE EXISTS (SELECT 1 FROM books WHERE id = $1) AND EXISTS (SELECT 1 FROM books WHERE id = $2)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, score := range scores {
+		_, err := stmt.Exec(score.BookID, score.SimilarBookID, score.Score, score.CategoryScore,
+			score.TextScore, score.YearScore, score.ActivityScore, computedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"book-management/internal/models"
+)
+
+type TagRepository struct {
+	db *sql.DB
+}
+
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+const tagSelectQuery = `
+		SELECT t.id, t.name, COUNT(bt.book_id) as book_count,
+			   t.created_at, t.created_by, t.modified_at, t.modified_by
+		FROM tags t
+		LEFT JOIN book_tags bt ON bt.tag_id = t.id
+`
+
+func scanTag(row rowScanner) (*models.Tag, error) {
+	tag := &models.Tag{}
+	err := row.Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.BookCount,
+		&tag.CreatedAt,
+		&tag.CreatedBy,
+		&tag.ModifiedAt,
+		&tag.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (r *TagRepository) GetAll() ([]models.Tag, error) {
+	query := tagSelectQuery + `
+		GROUP BY t.id
+		ORDER BY t.name ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func (r *TagRepository) GetByID(id int) (*models.Tag, error) {
+	query := tagSelectQuery + `
+		WHERE t.id = $1
+		GROUP BY t.id
+	`
+
+	tag, err := scanTag(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// GetByName mencari tag tanpa membedakan huruf besar dan kecil
+func (r *TagRepository) GetByName(name string) (*models.Tag, error) {
+	query := tagSelectQuery + `
+		WHERE LOWER(t.name) = LOWER($1)
+		GROUP BY t.id
+	`
+
+	tag, err := scanTag(r.db.QueryRow(query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (r *TagRepository) Create(tag *models.Tag) error {
+	query := `
+		INSERT INTO tags (name, created_by, modified_by)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, modified_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		tag.Name,
+		tag.CreatedBy,
+		tag.ModifiedBy,
+	).Scan(&tag.ID, &tag.CreatedAt, &tag.ModifiedAt)
+
+	return err
+}
+
+func (r *TagRepository) Update(tag *models.Tag) error {
+	query := `
+		UPDATE tags 
+		SET name = $1, modified_by = $2, modified_at = $3
+		WHERE id = $4
+	`
+
+	tag.ModifiedAt = time.Now()
+	result, err := r.db.Exec(query, tag.Name, tag.ModifiedBy, tag.ModifiedAt, tag.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Delete(id int) error {
+	query := `DELETE FROM tags WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetCloud mengembalikan tag yang dipakai minimal satu buku, diurutkan dari
+// yang paling sering dipakai
+func (r *TagRepository) GetCloud(limit int) ([]models.TagCloudEntry, error) {
+	query := `
+		SELECT t.id, t.name, COUNT(bt.book_id) as book_count
+		FROM tags t
+		JOIN book_tags bt ON bt.tag_id = t.id
+		GROUP BY t.id
+		ORDER BY book_count DESC, t.name ASC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.TagCloudEntry
+	for rows.Next() {
+		var entry models.TagCloudEntry
+		if err := rows.Scan(&entry.ID, &entry.Name, &entry.Count); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,222 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type TranslationRepository struct {
+	db *sql.DB
+}
+
+func NewTranslationRepository(db *sql.DB) *TranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+const bookTranslationSelectQuery = `
+		SELECT book_id, locale, title, COALESCE(description, ''),
+			   created_at, created_by, modified_at, modified_by
+		FROM book_translations
+`
+
+func scanBookTranslation(row rowScanner) (*models.BookTranslation, error) {
+	translation := &models.BookTranslation{}
+	err := row.Scan(
+		&translation.BookID,
+		&translation.Locale,
+		&translation.Title,
+		&translation.Description,
+		&translation.CreatedAt,
+		&translation.CreatedBy,
+		&translation.ModifiedAt,
+		&translation.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+// GetBookTranslations mengembalikan semua terjemahan sebuah buku
+func (r *TranslationRepository) GetBookTranslations(bookID int) ([]models.BookTranslation, error) {
+	rows, err := r.db.Query(bookTranslationSelectQuery+` WHERE book_id = $1 ORDER BY locale`, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []models.BookTranslation{}
+	for rows.Next() {
+		translation, err := scanBookTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, *translation)
+	}
+
+	return translations, rows.Err()
+}
+
+// GetBookTranslationsByLocale mengembalikan terjemahan bookIDs dalam satu
+// bahasa, dikelompokkan per ID buku
+func (r *TranslationRepository) GetBookTranslationsByLocale(bookIDs []int, locale string) (map[int]models.BookTranslation, error) {
+	translations := make(map[int]models.BookTranslation)
+	if len(bookIDs) == 0 {
+		return translations, nil
+	}
+
+	query := bookTranslationSelectQuery + ` WHERE book_id = ANY($1) AND locale = $2`
+
+	rows, err := r.db.Query(query, pq.Array(toInt64s(bookIDs)), locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		translation, err := scanBookTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations[translation.BookID] = *translation
+	}
+
+	return translations, rows.Err()
+}
+
+// SaveBookTranslation menambah atau mengganti terjemahan buku dalam satu bahasa
+func (r *TranslationRepository) SaveBookTranslation(translation *models.BookTranslation) error {
+	query := `
+		INSERT INTO book_translations (book_id, locale, title, description, created_by, modified_at, modified_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $5)
+		ON CONFLICT (book_id, locale) DO UPDATE
+		SET title = EXCLUDED.title, description = EXCLUDED.description,
+			modified_at = EXCLUDED.modified_at, modified_by = EXCLUDED.modified_by
+		RETURNING created_at, created_by, modified_at, modified_by
+	`
+
+	return r.db.QueryRow(query, translation.BookID, translation.Locale, translation.Title,
+		translation.Description, translation.ModifiedBy, time.Now()).
+		Scan(&translation.CreatedAt, &translation.CreatedBy, &translation.ModifiedAt, &translation.ModifiedBy)
+}
+
+func (r *TranslationRepository) DeleteBookTranslation(bookID int, locale string) error {
+	result, err := r.db.Exec(`DELETE FROM book_translations WHERE book_id = $1 AND locale = $2`, bookID, locale)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+const categoryTranslationSelectQuery = `
+		SELECT category_id, locale, name, created_at, created_by, modified_at, modified_by
+		FROM category_translations
+`
+
+func scanCategoryTranslation(row rowScanner) (*models.CategoryTranslation, error) {
+	translation := &models.CategoryTranslation{}
+	err := row.Scan(
+		&translation.CategoryID,
+		&translation.Locale,
+		&translation.Name,
+		&translation.CreatedAt,
+		&translation.CreatedBy,
+		&translation.ModifiedAt,
+		&translation.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+// GetCategoryTranslations mengembalikan semua terjemahan sebuah kategori
+func (r *TranslationRepository) GetCategoryTranslations(categoryID int) ([]models.CategoryTranslation, error) {
+	rows, err := r.db.Query(categoryTranslationSelectQuery+` WHERE category_id = $1 ORDER BY locale`, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []models.CategoryTranslation{}
+	for rows.Next() {
+		translation, err := scanCategoryTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, *translation)
+	}
+
+	return translations, rows.Err()
+}
+
+// GetCategoryNames mengembalikan nama terjemahan semua kategori yang memiliki
+// terjemahan dalam bahasa locale, dikelompokkan per ID kategori
+func (r *TranslationRepository) GetCategoryNames(locale string) (map[int]string, error) {
+	rows, err := r.db.Query(`SELECT category_id, name FROM category_translations WHERE locale = $1`, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+
+	return names, rows.Err()
+}
+
+// SaveCategoryTranslation menambah atau mengganti nama kategori dalam satu bahasa
+func (r *TranslationRepository) SaveCategoryTranslation(translation *models.CategoryTranslation) error {
+	query := `
+		INSERT INTO category_translations (category_id, locale, name, created_by, modified_at, modified_by)
+		VALUES ($1, $2, $3, $4, $5, $4)
+		ON CONFLICT (category_id, locale) DO UPDATE
+		SET name = EXCLUDED.name, modified_at = EXCLUDED.modified_at, modified_by = EXCLUDED.modified_by
+		RETURNING created_at, created_by, modified_at, modified_by
+	`
+
+	return r.db.QueryRow(query, translation.CategoryID, translation.Locale, translation.Name,
+		translation.ModifiedBy, time.Now()).
+		Scan(&translation.CreatedAt, &translation.CreatedBy, &translation.ModifiedAt, &translation.ModifiedBy)
+}
+
+func (r *TranslationRepository) DeleteCategoryTranslation(categoryID int, locale string) error {
+	result, err := r.db.Exec(`DELETE FROM category_translations WHERE category_id = $1 AND locale = $2`, categoryID, locale)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
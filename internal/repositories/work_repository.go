@@ -0,0 +1,298 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"book-management/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateRelation dikembalikan ketika hubungan yang sama antara dua buku
+// sudah ada
+var ErrDuplicateRelation = errors.New("duplicate book relation")
+
+type WorkRepository struct {
+	db *sql.DB
+}
+
+func NewWorkRepository(db *sql.DB) *WorkRepository {
+	return &WorkRepository{db: db}
+}
+
+const workSelectQuery = `
+		SELECT w.id, w.title, COALESCE(w.description, ''),
+			   (SELECT COUNT(*) FROM books b WHERE b.work_id = w.id),
+			   w.created_at, w.created_by, w.modified_at, w.modified_by
+		FROM works w
+`
+
+func scanWork(row rowScanner) (*models.Work, error) {
+	work := &models.Work{}
+	err := row.Scan(
+		&work.ID,
+		&work.Title,
+		&work.Description,
+		&work.EditionCount,
+		&work.CreatedAt,
+		&work.CreatedBy,
+		&work.ModifiedAt,
+		&work.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return work, nil
+}
+
+// GetAll mengembalikan karya yang judulnya mengandung search (tanpa
+// membedakan huruf besar/kecil); search kosong berarti semua karya
+func (r *WorkRepository) GetAll(search string) ([]models.Work, error) {
+	query := workSelectQuery
+	var args []interface{}
+
+	if search != "" {
+		query += ` WHERE w.title ILIKE $1`
+		args = append(args, "%"+search+"%")
+	}
+	query += ` ORDER BY w.title ASC, w.id ASC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var works []models.Work
+	for rows.Next() {
+		work, err := scanWork(rows)
+		if err != nil {
+			return nil, err
+		}
+		works = append(works, *work)
+	}
+
+	return works, rows.Err()
+}
+
+func (r *WorkRepository) GetByID(id int) (*models.Work, error) {
+	query := workSelectQuery + ` WHERE w.id = $1`
+
+	work, err := scanWork(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return work, nil
+}
+
+// GetEditions mengembalikan buku yang menjadi edisi karya, terbitan terlama
+// lebih dulu
+func (r *WorkRepository) GetEditions(workID int) ([]models.WorkEdition, error) {
+	rows, err := r.db.Query(`
+		SELECT id, title, release_year, total_page
+		FROM books
+		WHERE work_id = $1
+		ORDER BY release_year ASC, id ASC
+	`, workID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	editions := []models.WorkEdition{}
+	for rows.Next() {
+		var edition models.WorkEdition
+		if err := rows.Scan(&edition.BookID, &edition.Title, &edition.ReleaseYear, &edition.TotalPage); err != nil {
+			return nil, err
+		}
+		editions = append(editions, edition)
+	}
+
+	return editions, rows.Err()
+}
+
+func (r *WorkRepository) Create(work *models.Work) error {
+	query := `
+		INSERT INTO works (title, description, created_by, modified_by)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
+		RETURNING id, created_at, modified_at
+	`
+
+	return r.db.QueryRow(query, work.Title, work.Description, work.CreatedBy, work.ModifiedBy).
+		Scan(&work.ID, &work.CreatedAt, &work.ModifiedAt)
+}
+
+func (r *WorkRepository) Update(work *models.Work) error {
+	query := `
+		UPDATE works
+		SET title = $1, description = NULLIF($2, ''), modified_by = $3, modified_at = $4
+		WHERE id = $5
+	`
+
+	work.ModifiedAt = time.Now()
+	result, err := r.db.Exec(query, work.Title, work.Description, work.ModifiedBy, work.ModifiedAt, work.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete menghapus karya; edisinya tetap ada tanpa karya
+func (r *WorkRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM works WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetBookWork menjadikan buku sebagai edisi karya workID; workID nil
+// melepaskan buku dari karyanya
+func (r *WorkRepository) SetBookWork(bookID int, workID *int, username string) error {
+	query := `UPDATE books SET work_id = $1, modified_at = $2, modified_by = $3 WHERE id = $4`
+
+	result, err := r.db.Exec(query, workID, time.Now(), username, bookID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetRelations mengembalikan hubungan sebuah buku dari kedua arah. Hubungan
+// masuk diberi nama kebalikannya, misalnya "sequel_to" menjadi "prequel_to".
+func (r *WorkRepository) GetRelations(bookID int) ([]models.BookRelation, error) {
+	query := `
+		SELECT r.id, r.relation_type, 'outgoing', r.related_book_id, b.title, r.created_at, r.created_by
+		FROM book_relations r
+		JOIN books b ON r.related_book_id = b.id
+		WHERE r.book_id = $1
+		UNION ALL
+		SELECT r.id,
+			   CASE r.relation_type
+				   WHEN 'translation_of' THEN 'translated_as'
+				   WHEN 'sequel_to' THEN 'prequel_to'
+				   WHEN 'revised_edition_of' THEN 'revised_as'
+			   END,
+			   'incoming', r.book_id, b.title, r.created_at, r.created_by
+		FROM book_relations r
+		JOIN books b ON r.book_id = b.id
+		WHERE r.related_book_id = $1
+		ORDER BY 2, 5, 1
+	`
+
+	rows, err := r.db.Query(query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	relations := []models.BookRelation{}
+	for rows.Next() {
+		var relation models.BookRelation
+		err := rows.Scan(
+			&relation.ID,
+			&relation.RelationType,
+			&relation.Direction,
+			&relation.RelatedBookID,
+			&relation.RelatedBookTitle,
+			&relation.CreatedAt,
+			&relation.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		relations = append(relations, relation)
+	}
+
+	return relations, rows.Err()
+}
+
+// RelationExists memeriksa apakah bookID sudah tercatat sebagai relationType
+// dari relatedBookID
+func (r *WorkRepository) RelationExists(bookID, relatedBookID int, relationType string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM book_relations
+			WHERE book_id = $1 AND related_book_id = $2 AND relation_type = $3
+		)
+	`, bookID, relatedBookID, relationType).Scan(&exists)
+
+	return exists, err
+}
+
+func (r *WorkRepository) CreateRelation(bookID int, req *models.BookRelationRequest, username string) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		INSERT INTO book_relations (book_id, related_book_id, relation_type, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, bookID, req.RelatedBookID, req.RelationType, username).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return 0, ErrDuplicateRelation
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// DeleteRelation menghapus hubungan yang melibatkan bookID dari arah mana pun
+func (r *WorkRepository) DeleteRelation(bookID, relationID int) error {
+	result, err := r.db.Exec(`
+		DELETE FROM book_relations
+		WHERE id = $1 AND (book_id = $2 OR related_book_id = $2)
+	`, relationID, bookID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -25,7 +25,7 @@ func NewAuthService(userRepo *repositories.UserRepository, jwtManager *utils.JWT
 func (s *AuthService) Login(req *models.LoginRequest) (*models.LoginResponse, error) {
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
-		return nil, errors.New("validation failed: " + err.Error())
+		return nil, validationFailed(err)
 	}
 
 	// Find user by username
@@ -35,12 +35,12 @@ func (s *AuthService) Login(req *models.LoginRequest) (*models.LoginResponse, er
 	}
 
 	if user == nil {
-		return nil, errors.New("invalid credentials")
+		return nil, ErrInvalidCredentials
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, ErrInvalidCredentials
 	}
 
 	// Generate JWT token
@@ -70,7 +70,7 @@ func (s *AuthService) GetUserByID(id int) (*models.User, error) {
 	}
 
 	if user == nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	return user, nil
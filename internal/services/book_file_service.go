@@ -0,0 +1,230 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/storage"
+)
+
+type BookFileService struct {
+	bookRepo *repositories.BookRepository
+	fileRepo *repositories.BookFileRepository
+	storage  storage.Storage
+	maxSize  int64
+}
+
+func NewBookFileService(bookRepo *repositories.BookRepository, fileRepo *repositories.BookFileRepository, store storage.Storage, maxSize int64) *BookFileService {
+	return &BookFileService{
+		bookRepo: bookRepo,
+		fileRepo: fileRepo,
+		storage:  store,
+		maxSize:  maxSize,
+	}
+}
+
+func (s *BookFileService) MaxSize() int64 {
+	return s.maxSize
+}
+
+func (s *BookFileService) GetFiles(bookID int) ([]models.BookFile, error) {
+	if err := s.checkBook(bookID); err != nil {
+		return nil, err
+	}
+
+	files, err := s.fileRepo.GetByBookID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get files")
+	}
+
+	return files, nil
+}
+
+// UploadFile menyimpan file buku digital. Format dideteksi dari isi file,
+// checksum SHA-256 dihitung sambil file dialirkan ke storage, dan file lama
+// dengan format yang sama digantikan.
+func (s *BookFileService) UploadFile(bookID int, fileName string, r io.Reader, size int64, username string) (*models.BookFile, error) {
+	if err := s.checkBook(bookID); err != nil {
+		return nil, err
+	}
+
+	if size > s.maxSize {
+		return nil, tooLarge("file_too_large", "File must not exceed %d MB", s.maxSize>>20)
+	}
+
+	head := make([]byte, 1024)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, errors.New("failed to read file")
+	}
+	head = head[:n]
+
+	format, contentType := detectBookFileFormat(head)
+	if format == "" {
+		return nil, ErrUnsupportedFileFormat
+	}
+
+	token, err := randomToken(8)
+	if err != nil {
+		return nil, errors.New("failed to store file")
+	}
+
+	// Stored outside storage.PublicPrefix so the file is only reachable
+	// through the authenticated download endpoint
+	file := &models.BookFile{
+		BookID:      bookID,
+		Format:      format,
+		FileName:    sanitizeFileName(fileName, format),
+		StorageKey:  fmt.Sprintf("files/%d/%s.%s", bookID, token, format),
+		ContentType: contentType,
+		SizeBytes:   size,
+		CreatedBy:   username,
+	}
+
+	hasher := sha256.New()
+	content := io.TeeReader(io.MultiReader(bytes.NewReader(head), r), hasher)
+	if err := s.storage.Put(file.StorageKey, content, size, contentType); err != nil {
+		log.Printf("Failed to store %s: %v", file.StorageKey, err)
+		return nil, errors.New("failed to store file")
+	}
+	file.ChecksumSHA256 = hex.EncodeToString(hasher.Sum(nil))
+
+	oldKey, err := s.fileRepo.Save(file)
+	if err != nil {
+		s.deleteObject(file.StorageKey)
+		return nil, errors.New("failed to save file")
+	}
+
+	if oldKey != "" {
+		s.deleteObject(oldKey)
+	}
+
+	return file, nil
+}
+
+// OpenFile membuka isi file untuk diunduh; pemanggil wajib menutup reader
+func (s *BookFileService) OpenFile(bookID, fileID int) (*models.BookFile, io.ReadSeekCloser, error) {
+	file, err := s.getFile(bookID, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.storage.Open(file.StorageKey)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, nil, ErrFileNotFound
+		}
+		return nil, nil, errors.New("failed to open file")
+	}
+
+	return file, content, nil
+}
+
+func (s *BookFileService) RecordDownload(download *models.FileDownload) error {
+	if err := s.fileRepo.CreateDownload(download); err != nil {
+		return errors.New("failed to record download")
+	}
+
+	return nil
+}
+
+func (s *BookFileService) GetDownloads(bookID, fileID int) ([]models.FileDownload, error) {
+	if _, err := s.getFile(bookID, fileID); err != nil {
+		return nil, err
+	}
+
+	downloads, err := s.fileRepo.GetDownloads(fileID)
+	if err != nil {
+		return nil, errors.New("failed to get downloads")
+	}
+
+	return downloads, nil
+}
+
+func (s *BookFileService) DeleteFile(bookID, fileID int) error {
+	key, err := s.fileRepo.Delete(bookID, fileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrFileNotFound
+		}
+		return errors.New("failed to delete file")
+	}
+
+	s.deleteObject(key)
+
+	return nil
+}
+
+func (s *BookFileService) getFile(bookID, fileID int) (*models.BookFile, error) {
+	file, err := s.fileRepo.GetByID(bookID, fileID)
+	if err != nil {
+		return nil, errors.New("failed to get file")
+	}
+
+	if file == nil {
+		return nil, ErrFileNotFound
+	}
+
+	return file, nil
+}
+
+func (s *BookFileService) checkBook(bookID int) error {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return ErrBookNotFound
+	}
+
+	return nil
+}
+
+func (s *BookFileService) deleteObject(key string) {
+	if err := s.storage.Delete(key); err != nil {
+		log.Printf("Failed to delete stored object %s: %v", key, err)
+	}
+}
+
+// detectBookFileFormat mengenali PDF, EPUB dan MOBI dari byte awal file
+func detectBookFileFormat(head []byte) (string, string) {
+	switch {
+	case bytes.Contains(head, []byte("%PDF-")):
+		return "pdf", "application/pdf"
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")) && bytes.Contains(head, []byte("mimetypeapplication/epub+zip")):
+		return "epub", "application/epub+zip"
+	case len(head) >= 68 && string(head[60:68]) == "BOOKMOBI":
+		return "mobi", "application/x-mobipocket-ebook"
+	default:
+		return "", ""
+	}
+}
+
+// sanitizeFileName membuang path dan memastikan ekstensi sesuai format
+func sanitizeFileName(name, format string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		name = "book"
+	}
+
+	if !strings.EqualFold(filepath.Ext(name), "."+format) {
+		name += "." + format
+	}
+
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+
+	return name
+}
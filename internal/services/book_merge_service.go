@@ -0,0 +1,315 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/storage"
+	"book-management/internal/utils"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Bobot setiap sinyal dalam skor duplikat. Pasangan dengan kemiripan judul di
+// bawah minDuplicateTitleScore tidak dianggap duplikat.
+const (
+	duplicateTitleWeight   = 0.6
+	duplicateYearWeight    = 0.2
+	duplicatePageWeight    = 0.2
+	minDuplicateTitleScore = 0.7
+
+	// maxDuplicateBlockSize membatasi kata judul yang terlalu umum agar tidak
+	// membandingkan hampir semua buku satu sama lain
+	maxDuplicateBlockSize = 500
+)
+
+// titleArticles adalah kata sandang di awal judul yang diabaikan
+var titleArticles = map[string]bool{"the": true, "a": true, "an": true}
+
+type BookMergeService struct {
+	mergeRepo  *repositories.BookMergeRepository
+	bookRepo   *repositories.BookRepository
+	storage    storage.Storage
+	pickupDays int
+}
+
+func NewBookMergeService(mergeRepo *repositories.BookMergeRepository, bookRepo *repositories.BookRepository, storage storage.Storage, pickupDays int) *BookMergeService {
+	return &BookMergeService{
+		mergeRepo:  mergeRepo,
+		bookRepo:   bookRepo,
+		storage:    storage,
+		pickupDays: pickupDays,
+	}
+}
+
+// FindDuplicates mengembalikan pasangan buku dengan skor duplikat minimal
+// minScore, skor tertinggi lebih dulu
+func (s *BookMergeService) FindDuplicates(minScore float64, limit int) ([]models.DuplicateCandidate, error) {
+	if minScore < 0 || minScore > 1 {
+		return nil, invalid("invalid_min_score", "Invalid min_score", "must be between 0 and 1")
+	}
+
+	books, err := s.mergeRepo.GetDuplicateInputs()
+	if err != nil {
+		return nil, errors.New("failed to get books")
+	}
+
+	candidates := findDuplicates(books, minScore)
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates, nil
+}
+
+// MergeBooks menggabungkan buku sumber ke buku targetID. Semua data yang
+// merujuk buku sumber dipindahkan, buku sumber dihapus, dan penggabungan
+// dicatat di audit.
+func (s *BookMergeService) MergeBooks(targetID int, req *models.MergeBookRequest, username string) (*models.BookMerge, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if req.SourceBookID == targetID {
+		return nil, invalid("invalid_merge", "Invalid merge", "a book cannot be merged into itself")
+	}
+
+	target, err := s.bookRepo.GetByID(targetID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if target == nil {
+		return nil, ErrBookNotFound
+	}
+
+	source, err := s.bookRepo.GetByID(req.SourceBookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if source == nil {
+		return nil, ErrSourceBookNotFound
+	}
+
+	snapshot, err := json.Marshal(source)
+	if err != nil {
+		return nil, errors.New("failed to merge books")
+	}
+
+	merge, discardedKeys, err := s.mergeRepo.Merge(targetID, req.SourceBookID, snapshot, s.pickupDays, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBookNotFound
+		}
+		return nil, errors.New("failed to merge books")
+	}
+
+	// Covers and files that lost to the target's are removed after the merge
+	for _, key := range discardedKeys {
+		if err := s.storage.Delete(key); err != nil {
+			log.Printf("Failed to delete stored object %s: %v", key, err)
+		}
+	}
+
+	return merge, nil
+}
+
+func (s *BookMergeService) GetMerges(bookID int) ([]models.BookMerge, error) {
+	merges, err := s.mergeRepo.GetAll(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book merges")
+	}
+
+	return merges, nil
+}
+
+// findDuplicates membandingkan buku yang berbagi kata judul atau awalan judul
+// yang sama. Setiap pasangan hanya muncul sekali dengan buku ber-ID kecil
+// sebagai Book. Judul tanpa huruf atau angka tidak dibandingkan.
+func findDuplicates(books []models.DuplicateBook, minScore float64) []models.DuplicateCandidate {
+	titles := make([]string, len(books))
+	blocks := make(map[string][]int)
+	for i, book := range books {
+		titles[i] = normalizeTitle(book.Title)
+		if titles[i] == "" {
+			continue
+		}
+
+		keys := make(map[string]bool)
+		for _, word := range strings.Fields(titles[i]) {
+			if len([]rune(word)) >= 3 {
+				keys["w:"+word] = true
+			}
+		}
+		prefix := []rune(titles[i])
+		if len(prefix) > 6 {
+			prefix = prefix[:6]
+		}
+		keys["p:"+string(prefix)] = true
+
+		for key := range keys {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	var candidates []models.DuplicateCandidate
+	for _, members := range blocks {
+		if len(members) < 2 || len(members) > maxDuplicateBlockSize {
+			continue
+		}
+
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				i, j := members[a], members[b]
+				pair := [2]int{i, j}
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+
+				titleScore := titleSimilarity(titles[i], titles[j])
+				if titleScore < minDuplicateTitleScore {
+					continue
+				}
+
+				candidate := models.DuplicateCandidate{
+					Book:       books[i],
+					Duplicate:  books[j],
+					TitleScore: titleScore,
+					YearScore:  releaseYearSimilarity(books[i].ReleaseYear, books[j].ReleaseYear),
+					PageScore:  pageCountSimilarity(books[i].TotalPage, books[j].TotalPage),
+				}
+				candidate.Score = roundScore(duplicateTitleWeight*candidate.TitleScore +
+					duplicateYearWeight*candidate.YearScore + duplicatePageWeight*candidate.PageScore)
+
+				if candidate.Score >= minScore {
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].Score != candidates[b].Score {
+			return candidates[a].Score > candidates[b].Score
+		}
+		if candidates[a].Book.ID != candidates[b].Book.ID {
+			return candidates[a].Book.ID < candidates[b].Book.ID
+		}
+		return candidates[a].Duplicate.ID < candidates[b].Duplicate.ID
+	})
+
+	return candidates
+}
+
+// normalizeTitle menyamakan huruf besar/kecil, diakritik pada huruf Latin
+// dan tanda baca judul, lalu membuang kata sandang di awal judul. Huruf dari
+// aksara lain dipertahankan apa adanya.
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	var base rune
+	for _, r := range norm.NFD.String(cases.Fold().String(title)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Accents are dropped from Latin letters only; in other scripts
+			// combining marks are part of the spelling
+			if !unicode.Is(unicode.Latin, base) {
+				b.WriteRune(r)
+			}
+			continue
+		case r == '&':
+			b.WriteString(" and ")
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mc, r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte(' ')
+		}
+		base = r
+	}
+
+	words := strings.Fields(norm.NFC.String(b.String()))
+	if len(words) > 1 && titleArticles[words[0]] {
+		words = words[1:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// titleSimilarity adalah kemiripan edit distance dua judul; judul yang
+// katanya sama dengan urutan berbeda juga dianggap mirip
+func titleSimilarity(a, b string) float64 {
+	score := editSimilarity(a, b)
+
+	sortedA := strings.Fields(a)
+	sortedB := strings.Fields(b)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+
+	return roundScore(math.Max(score, editSimilarity(strings.Join(sortedA, " "), strings.Join(sortedB, " "))))
+}
+
+func editSimilarity(a, b string) float64 {
+	runesA, runesB := []rune(a), []rune(b)
+	longest := math.Max(float64(len(runesA)), float64(len(runesB)))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(runesA, runesB))/longest
+}
+
+// levenshtein menghitung jumlah minimum penyisipan, penghapusan dan
+// penggantian karakter untuk mengubah a menjadi b
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func releaseYearSimilarity(a, b int) float64 {
+	switch diff := a - b; {
+	case diff == 0:
+		return 1
+	case diff == 1 || diff == -1:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+func pageCountSimilarity(a, b int) float64 {
+	longest := math.Max(float64(a), float64(b))
+	if longest <= 0 {
+		return 1
+	}
+
+	return roundScore(1 - math.Abs(float64(a-b))/longest)
+}
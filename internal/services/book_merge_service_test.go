@@ -0,0 +1,67 @@
+package services
+
+import (
+	"testing"
+
+	"book-management/internal/models"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"The Hobbit", "hobbit"},
+		{"  Laskar   Pelangi!! ", "laskar pelangi"},
+		{"Crème Brûlée", "creme brulee"},
+		{"Straße", "strasse"},
+		{"Romeo & Juliet", "romeo and juliet"},
+		{"The", "the"},
+		{"كتاب الأيام", "كتاب الأيام"},
+		{"三体", "三体"},
+		{"ความสุข", "ความสุข"},
+		{"गोदान", "गोदान"},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeTitle(tt.title); got != tt.want {
+			t.Errorf("normalizeTitle(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+// duplicatePairs mengembalikan pasangan ID dari hasil findDuplicates
+func duplicatePairs(candidates []models.DuplicateCandidate) [][2]int {
+	var pairs [][2]int
+	for _, candidate := range candidates {
+		pairs = append(pairs, [2]int{candidate.Book.ID, candidate.Duplicate.ID})
+	}
+	return pairs
+}
+
+func TestFindDuplicates(t *testing.T) {
+	books := []models.DuplicateBook{
+		{ID: 1, Title: "Bumi Manusia", ReleaseYear: 1980, TotalPage: 535},
+		{ID: 2, Title: "Bumi  Manusia!", ReleaseYear: 1980, TotalPage: 535},
+		{ID: 3, Title: "三体", ReleaseYear: 2008, TotalPage: 302},
+		{ID: 4, Title: "三体", ReleaseYear: 2008, TotalPage: 302},
+		{ID: 5, Title: "كتاب الأيام", ReleaseYear: 1929, TotalPage: 200},
+		{ID: 6, Title: "ความสุข", ReleaseYear: 2010, TotalPage: 120},
+		{ID: 7, Title: "गोदान", ReleaseYear: 1936, TotalPage: 300},
+		{ID: 8, Title: "???", ReleaseYear: 2000, TotalPage: 100},
+		{ID: 9, Title: "...", ReleaseYear: 2000, TotalPage: 100},
+	}
+
+	got := duplicatePairs(findDuplicates(books, 0.8))
+	want := [][2]int{{1, 2}, {3, 4}}
+	if len(got) != len(want) {
+		t.Fatalf("findDuplicates() pairs = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("findDuplicates() pairs = %v, want %v", got, want)
+			break
+		}
+	}
+}
@@ -3,58 +3,99 @@ package services
 import (
 	"database/sql"
 	"errors"
+	"log"
 
+	"book-management/internal/currency"
 	"book-management/internal/models"
 	"book-management/internal/repositories"
+	"book-management/internal/storage"
 	"book-management/internal/utils"
 )
 
 type BookService struct {
-	bookRepo *repositories.BookRepository
+	bookRepo       *repositories.BookRepository
+	storage        storage.Storage
+	thickness      models.ThicknessScheme
+	rateService    *ExchangeRateService
+	pricingService *PricingService
+
+	translationService *TranslationService
 }
 
-func NewBookService(bookRepo *repositories.BookRepository) *BookService {
+func NewBookService(bookRepo *repositories.BookRepository, store storage.Storage, thickness models.ThicknessScheme, rateService *ExchangeRateService, pricingService *PricingService, translationService *TranslationService) *BookService {
 	return &BookService{
-		bookRepo: bookRepo,
+		bookRepo:       bookRepo,
+		storage:        store,
+		thickness:      thickness,
+		rateService:    rateService,
+		pricingService: pricingService,
+
+		translationService: translationService,
 	}
 }
 
-func (s *BookService) GetAllBooks() ([]models.BookWithCategory, error) {
-	books, err := s.bookRepo.GetAll()
+func (s *BookService) GetAllBooks(filter models.BookFilter, view models.BookViewOptions) ([]models.BookWithCategory, error) {
+	filter.Tags = normalizeTags(filter.Tags)
+
+	books, err := s.bookRepo.GetAll(filter)
 	if err != nil {
 		return nil, errors.New("failed to get books")
 	}
 
+	if err := s.preparePrices(books, view); err != nil {
+		return nil, err
+	}
+
+	if err := s.translationService.TranslateBooks(books, view.Locale); err != nil {
+		return nil, err
+	}
+
 	return books, nil
 }
 
-func (s *BookService) GetBookByID(id int) (*models.BookWithCategory, error) {
+func (s *BookService) GetBookByID(id int, view models.BookViewOptions) (*models.BookWithCategory, error) {
 	book, err := s.bookRepo.GetByID(id)
 	if err != nil {
 		return nil, errors.New("failed to get book")
 	}
 
 	if book == nil {
-		return nil, errors.New("book not found")
+		return nil, ErrBookNotFound
 	}
 
-	return book, nil
+	books := []models.BookWithCategory{*book}
+	if err := s.preparePrices(books, view); err != nil {
+		return nil, err
+	}
+
+	if err := s.translationService.TranslateBooks(books, view.Locale); err != nil {
+		return nil, err
+	}
+
+	return &books[0], nil
 }
 
 func (s *BookService) CreateBook(req *models.CreateBookRequest, username string) (*models.Book, error) {
+	req.Tags = normalizeTags(req.Tags)
+	req.Currency = currency.Normalize(req.Currency)
+	if req.Currency == "" {
+		req.Currency = s.rateService.BaseCurrency()
+	}
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
-		return nil, errors.New("validation failed: " + err.Error())
+		return nil, validationFailed(err)
 	}
 
-	// Check if category exists
-	categoryExists, err := s.bookRepo.CheckCategoryExists(req.CategoryID)
-	if err != nil {
-		return nil, errors.New("failed to validate category")
+	// Check if all categories exist
+	categoryIDs := mergeCategoryIDs(req.CategoryID, req.CategoryIDs)
+	if err := s.checkCategories(categoryIDs); err != nil {
+		return nil, err
 	}
 
-	if !categoryExists {
-		return nil, errors.New("category not found")
+	tags := req.Tags
+	if tags == nil {
+		tags = []string{}
 	}
 
 	book := &models.Book{
@@ -62,17 +103,20 @@ func (s *BookService) CreateBook(req *models.CreateBookRequest, username string)
 		Description: req.Description,
 		ImageURL:    req.ImageURL,
 		ReleaseYear: req.ReleaseYear,
-		Price:       req.Price,
+		Price:       *req.Price,
+		Currency:    req.Currency,
 		TotalPage:   req.TotalPage,
 		CategoryID:  req.CategoryID,
+		CategoryIDs: categoryIDs,
+		Tags:        tags,
 		CreatedBy:   username,
 		ModifiedBy:  username,
 	}
 
 	// Calculate thickness based on total pages
-	book.CalculateThickness()
+	book.CalculateThickness(s.thickness)
 
-	err = s.bookRepo.Create(book)
+	err := s.bookRepo.Create(book)
 	if err != nil {
 		return nil, errors.New("failed to create book")
 	}
@@ -81,9 +125,12 @@ func (s *BookService) CreateBook(req *models.CreateBookRequest, username string)
 }
 
 func (s *BookService) UpdateBook(id int, req *models.UpdateBookRequest, username string) (*models.Book, error) {
+	req.Tags = normalizeTags(req.Tags)
+	req.Currency = currency.Normalize(req.Currency)
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
-		return nil, errors.New("validation failed: " + err.Error())
+		return nil, validationFailed(err)
 	}
 
 	// Check if book exists
@@ -93,17 +140,28 @@ func (s *BookService) UpdateBook(id int, req *models.UpdateBookRequest, username
 	}
 
 	if existingBook == nil {
-		return nil, errors.New("book not found")
+		return nil, ErrBookNotFound
 	}
 
-	// Check if category exists
-	categoryExists, err := s.bookRepo.CheckCategoryExists(req.CategoryID)
-	if err != nil {
-		return nil, errors.New("failed to validate category")
+	// Keep the current currency when the request does not specify one
+	if req.Currency == "" {
+		req.Currency = existingBook.Currency
+	}
+
+	// Keep the secondary categories when the request does not list them
+	extraCategoryIDs := req.CategoryIDs
+	if extraCategoryIDs == nil {
+		for _, categoryID := range existingBook.CategoryIDs {
+			if categoryID != existingBook.CategoryID {
+				extraCategoryIDs = append(extraCategoryIDs, categoryID)
+			}
+		}
 	}
 
-	if !categoryExists {
-		return nil, errors.New("category not found")
+	// Check if all categories exist
+	categoryIDs := mergeCategoryIDs(req.CategoryID, extraCategoryIDs)
+	if err := s.checkCategories(categoryIDs); err != nil {
+		return nil, err
 	}
 
 	// Update book
@@ -113,36 +171,160 @@ func (s *BookService) UpdateBook(id int, req *models.UpdateBookRequest, username
 		Description: req.Description,
 		ImageURL:    req.ImageURL,
 		ReleaseYear: req.ReleaseYear,
-		Price:       req.Price,
+		Price:       *req.Price,
+		Currency:    req.Currency,
 		TotalPage:   req.TotalPage,
 		CategoryID:  req.CategoryID,
+		CategoryIDs: categoryIDs,
+		Tags:        req.Tags,
 		ModifiedBy:  username,
 		CreatedAt:   existingBook.CreatedAt,
 		CreatedBy:   existingBook.CreatedBy,
 	}
 
 	// Calculate thickness based on total pages
-	updatedBook.CalculateThickness()
+	updatedBook.CalculateThickness(s.thickness)
 
 	err = s.bookRepo.Update(updatedBook)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("book not found")
+			return nil, ErrBookNotFound
 		}
 		return nil, errors.New("failed to update book")
 	}
 
+	if updatedBook.Tags == nil {
+		updatedBook.Tags = existingBook.Tags
+	}
+
 	return updatedBook, nil
 }
 
 func (s *BookService) DeleteBook(id int) error {
-	err := s.bookRepo.Delete(id)
+	storageKeys, err := s.bookRepo.GetStorageKeys(id)
+	if err != nil {
+		return errors.New("failed to delete book")
+	}
+
+	err = s.bookRepo.Delete(id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return errors.New("book not found")
+			return ErrBookNotFound
+		}
+		if err == repositories.ErrBookHasLoans {
+			return ErrBookHasLoanHistory
 		}
 		return errors.New("failed to delete book")
 	}
 
+	// Stored files are removed only after the book is gone
+	for _, key := range storageKeys {
+		if err := s.storage.Delete(key); err != nil {
+			log.Printf("Failed to delete stored object %s: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// RecalculateThickness menghitung ulang ketebalan semua buku dengan skema
+// saat ini. Bila dryRun bernilai true, perubahan hanya dihitung tanpa disimpan.
+func (s *BookService) RecalculateThickness(dryRun bool) (*models.ThicknessRecalculation, error) {
+	books, err := s.bookRepo.GetThicknesses()
+	if err != nil {
+		return nil, errors.New("failed to get books")
+	}
+
+	result := &models.ThicknessRecalculation{
+		TotalBooks: len(books),
+		Counts:     make(map[string]int),
+	}
+	changes := make(map[int]string)
+
+	for _, book := range books {
+		thickness := s.thickness.Classify(book.TotalPage)
+		result.Counts[thickness]++
+		if thickness != book.Thickness {
+			changes[book.ID] = thickness
+		}
+	}
+	result.ChangedBooks = len(changes)
+
+	if dryRun || len(changes) == 0 {
+		return result, nil
+	}
+
+	if err := s.bookRepo.UpdateThicknesses(changes); err != nil {
+		return nil, errors.New("failed to update thickness")
+	}
+
+	return result, nil
+}
+
+// preparePrices mengisi harga efektif setelah diskon dan, bila klien meminta
+// mata uang tertentu, harga yang sudah dikonversi
+func (s *BookService) preparePrices(books []models.BookWithCategory, view models.BookViewOptions) error {
+	target := currency.Normalize(view.Currency)
+	if target != "" && !currency.IsSupported(target) {
+		return ErrUnsupportedCurrency
+	}
+
+	if err := s.pricingService.ApplyEffectivePrices(books); err != nil {
+		return err
+	}
+
+	if target == "" || len(books) == 0 {
+		return nil
+	}
+
+	converter, err := s.rateService.NewConverter()
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		converted, err := converter.Convert(int64(books[i].Price), books[i].Currency, target)
+		if err != nil {
+			return err
+		}
+
+		effective, err := converter.Convert(int64(books[i].EffectivePrice.Amount), books[i].Currency, target)
+		if err != nil {
+			return err
+		}
+		converted.EffectiveAmount = effective.Amount
+		converted.EffectiveDisplay = effective.Display
+
+		books[i].ConvertedPrice = converted
+	}
+
 	return nil
 }
+
+func (s *BookService) checkCategories(categoryIDs []int) error {
+	categoriesExist, err := s.bookRepo.CheckCategoriesExist(categoryIDs)
+	if err != nil {
+		return errors.New("failed to validate category")
+	}
+
+	if !categoriesExist {
+		return ErrReferencedCategoryNotFound
+	}
+
+	return nil
+}
+
+// mergeCategoryIDs menggabungkan kategori utama dengan kategori tambahan
+// tanpa duplikat, dengan kategori utama di urutan pertama
+func mergeCategoryIDs(primaryID int, categoryIDs []int) []int {
+	result := []int{primaryID}
+	seen := map[int]bool{primaryID: true}
+	for _, categoryID := range categoryIDs {
+		if !seen[categoryID] {
+			seen[categoryID] = true
+			result = append(result, categoryID)
+		}
+	}
+
+	return result
+}
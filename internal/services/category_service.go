@@ -3,6 +3,9 @@ package services
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
 
 	"book-management/internal/models"
 	"book-management/internal/repositories"
@@ -10,61 +13,121 @@ import (
 )
 
 type CategoryService struct {
-	categoryRepo *repositories.CategoryRepository
+	categoryRepo       *repositories.CategoryRepository
+	translationService *TranslationService
 }
 
-func NewCategoryService(categoryRepo *repositories.CategoryRepository) *CategoryService {
+func NewCategoryService(categoryRepo *repositories.CategoryRepository, translationService *TranslationService) *CategoryService {
 	return &CategoryService{
-		categoryRepo: categoryRepo,
+		categoryRepo:       categoryRepo,
+		translationService: translationService,
 	}
 }
 
-func (s *CategoryService) GetAllCategories() ([]models.Category, error) {
+// GetAllCategories mengembalikan semua kategori dengan nama dalam bahasa
+// locale (kosong berarti bahasa default)
+func (s *CategoryService) GetAllCategories(locale string) ([]models.Category, error) {
 	categories, err := s.categoryRepo.GetAll()
 	if err != nil {
 		return nil, errors.New("failed to get categories")
 	}
 
+	if err := s.translationService.TranslateCategories(categories, locale); err != nil {
+		return nil, err
+	}
+
 	return categories, nil
 }
 
-func (s *CategoryService) GetCategoryByID(id int) (*models.Category, error) {
+func (s *CategoryService) GetCategoryByID(id int, locale string) (*models.Category, error) {
 	category, err := s.categoryRepo.GetByID(id)
 	if err != nil {
 		return nil, errors.New("failed to get category")
 	}
 
 	if category == nil {
-		return nil, errors.New("category not found")
+		return nil, ErrCategoryNotFound
 	}
 
-	return category, nil
+	return s.translate(category, locale)
+}
+
+func (s *CategoryService) GetCategoryBySlug(slug, locale string) (*models.Category, error) {
+	category, err := s.categoryRepo.GetBySlug(strings.ToLower(slug))
+	if err != nil {
+		return nil, errors.New("failed to get category")
+	}
+
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	return s.translate(category, locale)
 }
 
 func (s *CategoryService) CreateCategory(req *models.CreateCategoryRequest, username string) (*models.Category, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
-		return nil, errors.New("validation failed: " + err.Error())
+		return nil, validationFailed(err)
+	}
+
+	// Category names are unique regardless of case
+	if err := s.checkNameAvailable(req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	// Check if parent category exists
+	var parent *models.Category
+	if req.ParentID != nil {
+		var err error
+		parent, err = s.categoryRepo.GetByID(*req.ParentID)
+		if err != nil {
+			return nil, errors.New("failed to get parent category")
+		}
+
+		if parent == nil {
+			return nil, ErrParentCategoryNotFound
+		}
+	}
+
+	slug, err := s.generateSlug(req.Name, 0)
+	if err != nil {
+		return nil, err
 	}
 
 	category := &models.Category{
 		Name:       req.Name,
+		Slug:       slug,
+		ParentID:   req.ParentID,
 		CreatedBy:  username,
 		ModifiedBy: username,
 	}
 
-	err := s.categoryRepo.Create(category)
+	err = s.categoryRepo.Create(category)
 	if err != nil {
+		if err == repositories.ErrDuplicateCategoryName || err == repositories.ErrDuplicateCategorySlug {
+			return nil, ErrCategoryNameExists
+		}
 		return nil, errors.New("failed to create category")
 	}
 
+	// Breadcrumb path of the new category extends the parent's path
+	if parent != nil {
+		category.Path = append(category.Path, parent.Path...)
+	}
+	category.Path = append(category.Path, models.CategoryPathItem{ID: category.ID, Name: category.Name})
+
 	return category, nil
 }
 
 func (s *CategoryService) UpdateCategory(id int, req *models.UpdateCategoryRequest, username string) (*models.Category, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
-		return nil, errors.New("validation failed: " + err.Error())
+		return nil, validationFailed(err)
 	}
 
 	// Check if category exists
@@ -74,7 +137,19 @@ func (s *CategoryService) UpdateCategory(id int, req *models.UpdateCategoryReque
 	}
 
 	if existingCategory == nil {
-		return nil, errors.New("category not found")
+		return nil, ErrCategoryNotFound
+	}
+
+	// The slug follows the name so it is only regenerated on rename
+	if req.Name != existingCategory.Name {
+		if err := s.checkNameAvailable(req.Name, id); err != nil {
+			return nil, err
+		}
+
+		existingCategory.Slug, err = s.generateSlug(req.Name, id)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Update category
@@ -84,27 +159,156 @@ func (s *CategoryService) UpdateCategory(id int, req *models.UpdateCategoryReque
 	err = s.categoryRepo.Update(existingCategory)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("category not found")
+			return nil, ErrCategoryNotFound
+		}
+		if err == repositories.ErrDuplicateCategoryName || err == repositories.ErrDuplicateCategorySlug {
+			return nil, ErrCategoryNameExists
 		}
 		return nil, errors.New("failed to update category")
 	}
 
+	existingCategory.Path[len(existingCategory.Path)-1].Name = req.Name
+
 	return existingCategory, nil
 }
 
-func (s *CategoryService) DeleteCategory(id int) error {
-	err := s.categoryRepo.Delete(id)
+func (s *CategoryService) MoveCategory(id int, req *models.MoveCategoryRequest, username string) (*models.Category, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	// Check if parent category exists
+	if req.ParentID != nil {
+		parent, err := s.categoryRepo.GetByID(*req.ParentID)
+		if err != nil {
+			return nil, errors.New("failed to get parent category")
+		}
+
+		if parent == nil {
+			return nil, ErrParentCategoryNotFound
+		}
+	}
+
+	err := s.categoryRepo.Move(id, req.ParentID, username)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return errors.New("category not found")
+			return nil, ErrCategoryNotFound
+		}
+		if err == repositories.ErrCategoryCycle {
+			return nil, ErrCategoryMoveIntoSelf
 		}
-		return errors.New("failed to delete category")
+		return nil, errors.New("failed to move category")
 	}
 
-	return nil
+	return s.GetCategoryByID(id, "")
+}
+
+// GetCategoryTree menyusun semua kategori menjadi pohon, diurutkan menurut ID
+func (s *CategoryService) GetCategoryTree(locale string) ([]models.CategoryNode, error) {
+	categories, err := s.GetAllCategories(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	childrenOf := make(map[int][]models.Category)
+	var roots []models.Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		childrenOf[*category.ParentID] = append(childrenOf[*category.ParentID], category)
+	}
+
+	var build func(categories []models.Category) []models.CategoryNode
+	build = func(categories []models.Category) []models.CategoryNode {
+		nodes := make([]models.CategoryNode, len(categories))
+		for i, category := range categories {
+			nodes[i] = models.CategoryNode{
+				Category: category,
+				Children: build(childrenOf[category.ID]),
+			}
+		}
+		return nodes
+	}
+
+	return build(roots), nil
+}
+
+// DeleteCategory menghapus kategori. Buku yang masih memakai kategori ini
+// sebagai kategori utama harus dipindahkan dengan reassignTo, jika tidak
+// penghapusan ditolak dengan kesalahan category_in_use.
+func (s *CategoryService) DeleteCategory(id int, reassignTo *int, username string) (*models.CategoryReassignResult, error) {
+	if reassignTo != nil && *reassignTo == id {
+		return nil, ErrCategoryReassignToSelf
+	}
+
+	// The target and the book count are checked inside the delete transaction
+	result, err := s.categoryRepo.Delete(id, reassignTo, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCategoryNotFound
+		}
+		if err == repositories.ErrReassignTargetNotFound {
+			return nil, ErrTargetCategoryNotFound
+		}
+		var inUse *repositories.CategoryInUseError
+		if errors.As(err, &inUse) {
+			return nil, categoryInUse(inUse.BookCount)
+		}
+		return nil, errors.New("failed to delete category")
+	}
+
+	return result, nil
+}
+
+// MergeCategory menggabungkan kategori sumber ke kategori tujuan: semua buku
+// dan sub-kategori dipindahkan lalu kategori sumber dihapus
+func (s *CategoryService) MergeCategory(sourceID int, req *models.MergeCategoryRequest, username string) (*models.CategoryReassignResult, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if req.TargetID == sourceID {
+		return nil, ErrCategoryMergeIntoSelf
+	}
+
+	// Check if both categories exist
+	source, err := s.categoryRepo.GetByID(sourceID)
+	if err != nil {
+		return nil, errors.New("failed to get category")
+	}
+
+	if source == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	target, err := s.categoryRepo.GetByID(req.TargetID)
+	if err != nil {
+		return nil, errors.New("failed to get target category")
+	}
+
+	if target == nil {
+		return nil, ErrTargetCategoryNotFound
+	}
+
+	result, err := s.categoryRepo.Merge(sourceID, req.TargetID, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCategoryNotFound
+		}
+		if err == repositories.ErrCategoryCycle {
+			return nil, ErrCategoryMergeIntoChild
+		}
+		return nil, errors.New("failed to merge category")
+	}
+
+	return result, nil
 }
 
-func (s *CategoryService) GetBooksByCategory(categoryID int) ([]models.BookWithCategory, error) {
+func (s *CategoryService) GetBooksByCategory(categoryID int, includeDescendants bool, locale string) ([]models.BookWithCategory, error) {
 	// Check if category exists
 	category, err := s.categoryRepo.GetByID(categoryID)
 	if err != nil {
@@ -112,14 +316,97 @@ func (s *CategoryService) GetBooksByCategory(categoryID int) ([]models.BookWithC
 	}
 
 	if category == nil {
-		return nil, errors.New("category not found")
+		return nil, ErrCategoryNotFound
 	}
 
 	// Get books by category
-	books, err := s.categoryRepo.GetBooksByCategory(categoryID)
+	books, err := s.categoryRepo.GetBooksByCategory(categoryID, includeDescendants)
 	if err != nil {
 		return nil, errors.New("failed to get books")
 	}
 
+	if err := s.translationService.TranslateBooks(books, locale); err != nil {
+		return nil, err
+	}
+
 	return books, nil
 }
+
+func (s *CategoryService) translate(category *models.Category, locale string) (*models.Category, error) {
+	categories := []models.Category{*category}
+	if err := s.translationService.TranslateCategories(categories, locale); err != nil {
+		return nil, err
+	}
+
+	return &categories[0], nil
+}
+
+func (s *CategoryService) checkNameAvailable(name string, excludeID int) error {
+	sameName, err := s.categoryRepo.GetByName(name)
+	if err != nil {
+		return errors.New("failed to validate category")
+	}
+
+	if sameName != nil && sameName.ID != excludeID {
+		return ErrCategoryNameExists
+	}
+
+	return nil
+}
+
+// BackfillSlugs memberi slug kategori yang dibuat sebelum kolom slug ada,
+// dengan aturan yang sama seperti kategori baru; dipanggil saat aplikasi
+// dimulai, setelah migrasi
+func (s *CategoryService) BackfillSlugs() error {
+	categories, err := s.categoryRepo.GetWithoutSlug()
+	if err != nil {
+		return err
+	}
+
+	for _, category := range categories {
+		// Another instance starting at the same time may take the slug first
+		for attempt := 0; ; attempt++ {
+			slug, err := s.generateSlug(category.Name, category.ID)
+			if err != nil {
+				return err
+			}
+
+			err = s.categoryRepo.SetSlug(category.ID, slug)
+			if err == repositories.ErrDuplicateCategorySlug && attempt < 3 {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	if len(categories) > 0 {
+		log.Printf("Generated slugs for %d categories", len(categories))
+	}
+	return nil
+}
+
+// generateSlug membuat slug unik dari nama kategori dengan menambahkan
+// akhiran angka (-2, -3, ...) bila slug dasarnya sudah dipakai
+func (s *CategoryService) generateSlug(name string, excludeID int) (string, error) {
+	base := utils.Slugify(name)
+	if base == "" {
+		base = "category"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		exists, err := s.categoryRepo.SlugExists(slug, excludeID)
+		if err != nil {
+			return "", errors.New("failed to generate category slug")
+		}
+
+		if !exists {
+			return slug, nil
+		}
+
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
@@ -0,0 +1,428 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"book-management/internal/config"
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type CirculationService struct {
+	loanRepo     *repositories.LoanRepository
+	policyRepo   *repositories.LoanPolicyRepository
+	holdRepo     *repositories.HoldRepository
+	itemRepo     *repositories.ItemRepository
+	patronRepo   *repositories.PatronRepository
+	bookRepo     *repositories.BookRepository
+	categoryRepo *repositories.CategoryRepository
+	config       config.LoanConfig
+}
+
+func NewCirculationService(loanRepo *repositories.LoanRepository, policyRepo *repositories.LoanPolicyRepository, holdRepo *repositories.HoldRepository, itemRepo *repositories.ItemRepository, patronRepo *repositories.PatronRepository, bookRepo *repositories.BookRepository, categoryRepo *repositories.CategoryRepository, cfg config.LoanConfig) *CirculationService {
+	return &CirculationService{
+		loanRepo:     loanRepo,
+		policyRepo:   policyRepo,
+		holdRepo:     holdRepo,
+		itemRepo:     itemRepo,
+		patronRepo:   patronRepo,
+		bookRepo:     bookRepo,
+		categoryRepo: categoryRepo,
+		config:       cfg,
+	}
+}
+
+func (s *CirculationService) GetLoans(filter models.LoanFilter) ([]models.Loan, error) {
+	loans, err := s.loanRepo.GetAll(filter)
+	if err != nil {
+		return nil, errors.New("failed to get loans")
+	}
+
+	return loans, nil
+}
+
+func (s *CirculationService) GetPatronLoans(patronID int, status string) ([]models.Loan, error) {
+	if err := s.ensurePatron(patronID); err != nil {
+		return nil, err
+	}
+
+	return s.GetLoans(models.LoanFilter{PatronID: patronID, Status: status})
+}
+
+func (s *CirculationService) GetLoanByID(id int) (*models.Loan, error) {
+	loan, err := s.loanRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get loan")
+	}
+
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	return loan, nil
+}
+
+// Checkout meminjamkan eksemplar kepada anggota. Lama pinjam, batas
+// perpanjangan dan aturan denda diambil dari kebijakan kategori utama buku
+// saat checkout.
+func (s *CirculationService) Checkout(req *models.CheckoutRequest, username string) (*models.Loan, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	var item *models.Item
+	var err error
+	if req.ItemID > 0 {
+		item, err = s.itemRepo.GetByID(req.ItemID)
+	} else {
+		item, err = s.itemRepo.GetByBarcode(req.Barcode)
+	}
+	if err != nil {
+		return nil, errors.New("failed to get item")
+	}
+
+	if item == nil {
+		return nil, ErrItemNotFound
+	}
+
+	if err := s.ensurePatron(req.PatronID); err != nil {
+		return nil, err
+	}
+
+	book, err := s.bookRepo.GetByID(item.BookID)
+	if err != nil || book == nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	policy, err := s.effectivePolicy(book.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	loan := &models.Loan{
+		ItemID:       item.ID,
+		PatronID:     req.PatronID,
+		CheckedOutAt: now,
+		DueAt:        now.AddDate(0, 0, policy.LoanDays),
+		LoanDays:     policy.LoanDays,
+		MaxRenewals:  policy.MaxRenewals,
+		FinePerDay:   *policy.FinePerDay,
+		FineGrace:    *policy.FineGraceDays,
+		FineCap:      *policy.FineCap,
+		FineCurrency: s.config.FineCurrency,
+		CheckedOutBy: username,
+	}
+
+	if err := s.loanRepo.Checkout(loan, s.config.MaxActiveLoans, s.config.FineBlockThreshold, s.config.HoldPickupDays); err != nil {
+		// The item or patron was deleted after the lookups above
+		if err == sql.ErrNoRows {
+			return nil, ErrItemNotFound
+		}
+		return nil, circulationError(err, "failed to checkout item")
+	}
+
+	return s.GetLoanByID(loan.ID)
+}
+
+func (s *CirculationService) ReturnLoan(id int, username string) (*models.Loan, error) {
+	if err := s.loanRepo.Return(id, time.Now().UTC(), s.config.HoldPickupDays, username); err != nil {
+		return nil, circulationError(err, "failed to return loan")
+	}
+
+	return s.GetLoanByID(id)
+}
+
+func (s *CirculationService) RenewLoan(id int) (*models.Loan, error) {
+	if err := s.loanRepo.Renew(id, time.Now().UTC()); err != nil {
+		return nil, circulationError(err, "failed to renew loan")
+	}
+
+	return s.GetLoanByID(id)
+}
+
+func (s *CirculationService) GetHolds(filter models.HoldFilter) ([]models.Hold, error) {
+	holds, err := s.holdRepo.GetAll(filter)
+	if err != nil {
+		return nil, errors.New("failed to get holds")
+	}
+
+	return holds, nil
+}
+
+// GetBookHolds mengembalikan antrean hold sebuah judul; hold yang sudah
+// ditutup hanya disertakan bila includeClosed bernilai true
+func (s *CirculationService) GetBookHolds(bookID int, includeClosed bool) ([]models.Hold, error) {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+
+	filter := models.HoldFilter{BookID: bookID, Status: "open"}
+	if includeClosed {
+		filter.Status = ""
+	}
+
+	return s.GetHolds(filter)
+}
+
+func (s *CirculationService) GetPatronHolds(patronID int, status string) ([]models.Hold, error) {
+	if err := s.ensurePatron(patronID); err != nil {
+		return nil, err
+	}
+
+	return s.GetHolds(models.HoldFilter{PatronID: patronID, Status: status})
+}
+
+func (s *CirculationService) GetHoldByID(id int) (*models.Hold, error) {
+	hold, err := s.holdRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get hold")
+	}
+
+	if hold == nil {
+		return nil, ErrHoldNotFound
+	}
+
+	return hold, nil
+}
+
+// PlaceHold memasukkan anggota ke antrean sebuah judul. Bila masih ada
+// eksemplar available, eksemplar itu langsung disisihkan untuk diambil.
+func (s *CirculationService) PlaceHold(bookID int, req *models.PlaceHoldRequest, username string) (*models.Hold, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+
+	if err := s.ensurePatron(req.PatronID); err != nil {
+		return nil, err
+	}
+
+	hold := &models.Hold{
+		BookID:    bookID,
+		PatronID:  req.PatronID,
+		PlacedAt:  time.Now().UTC(),
+		CreatedBy: username,
+	}
+
+	if err := s.holdRepo.Place(hold, s.config.HoldPickupDays); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrPatronNotFound
+		case repositories.ErrDuplicateHold:
+			return nil, ErrHoldExists
+		case repositories.ErrAlreadyBorrowed:
+			return nil, ErrBookAlreadyBorrowed
+		case repositories.ErrPatronSuspended:
+			return nil, ErrPatronSuspended
+		}
+		return nil, errors.New("failed to place hold")
+	}
+
+	return s.GetHoldByID(hold.ID)
+}
+
+func (s *CirculationService) CancelHold(id int, username string) (*models.Hold, error) {
+	if err := s.holdRepo.Cancel(id, time.Now().UTC(), s.config.HoldPickupDays, username); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrHoldNotFound
+		case repositories.ErrHoldClosed:
+			return nil, ErrHoldAlreadyClosed
+		}
+		return nil, errors.New("failed to cancel hold")
+	}
+
+	return s.GetHoldByID(id)
+}
+
+// ExpireHolds menutup hold yang tidak diambil dalam masa pengambilan;
+// dipanggil berkala oleh background job
+func (s *CirculationService) ExpireHolds() error {
+	total := 0
+	for {
+		expired, err := s.holdRepo.ExpireHolds(time.Now().UTC(), s.config.HoldPickupDays)
+		if err != nil {
+			return err
+		}
+
+		total += expired
+		if expired == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Expired %d uncollected holds", total)
+	}
+
+	return nil
+}
+
+// GetLoanPolicy mengembalikan kebijakan yang berlaku untuk kategori, baik
+// miliknya sendiri, warisan kategori induk, maupun default konfigurasi
+func (s *CirculationService) GetLoanPolicy(categoryID int) (*models.LoanPolicy, error) {
+	if err := s.ensureCategory(categoryID); err != nil {
+		return nil, err
+	}
+
+	return s.effectivePolicy(categoryID)
+}
+
+func (s *CirculationService) GetLoanPolicies() ([]models.LoanPolicy, error) {
+	policies, err := s.policyRepo.GetAll()
+	if err != nil {
+		return nil, errors.New("failed to get loan policies")
+	}
+
+	return policies, nil
+}
+
+func (s *CirculationService) SetLoanPolicy(categoryID int, req *models.SetLoanPolicyRequest, username string) (*models.LoanPolicy, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if err := s.ensureCategory(categoryID); err != nil {
+		return nil, err
+	}
+
+	policy := &models.LoanPolicy{
+		CategoryID:    categoryID,
+		LoanDays:      req.LoanDays,
+		MaxRenewals:   req.MaxRenewals,
+		FinePerDay:    req.FinePerDay,
+		FineGraceDays: req.FineGraceDays,
+		FineCap:       req.FineCap,
+		ModifiedBy:    username,
+	}
+
+	if err := s.policyRepo.Save(policy); err != nil {
+		return nil, errors.New("failed to save loan policy")
+	}
+
+	policy.SourceCategoryID = &categoryID
+	s.applyFineDefaults(policy)
+	return policy, nil
+}
+
+func (s *CirculationService) DeleteLoanPolicy(categoryID int) error {
+	if err := s.policyRepo.Delete(categoryID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrLoanPolicyNotFound
+		}
+		return errors.New("failed to delete loan policy")
+	}
+
+	return nil
+}
+
+func (s *CirculationService) effectivePolicy(categoryID int) (*models.LoanPolicy, error) {
+	policy, err := s.policyRepo.GetEffective(categoryID)
+	if err != nil {
+		return nil, errors.New("failed to get loan policy")
+	}
+
+	if policy == nil {
+		policy = &models.LoanPolicy{
+			CategoryID:  categoryID,
+			LoanDays:    s.config.DefaultLoanDays,
+			MaxRenewals: s.config.DefaultMaxRenewals,
+			Inherited:   true,
+		}
+	}
+
+	s.applyFineDefaults(policy)
+	return policy, nil
+}
+
+// applyFineDefaults mengisi aturan denda yang kosong dengan nilai default
+func (s *CirculationService) applyFineDefaults(policy *models.LoanPolicy) {
+	if policy.FinePerDay == nil {
+		finePerDay := s.config.DefaultFinePerDay
+		policy.FinePerDay = &finePerDay
+	}
+	if policy.FineGraceDays == nil {
+		graceDays := s.config.DefaultFineGraceDays
+		policy.FineGraceDays = &graceDays
+	}
+	if policy.FineCap == nil {
+		fineCap := s.config.DefaultFineCap
+		policy.FineCap = &fineCap
+	}
+}
+
+func (s *CirculationService) ensurePatron(patronID int) error {
+	patron, err := s.patronRepo.GetByID(patronID)
+	if err != nil {
+		return errors.New("failed to get patron")
+	}
+
+	if patron == nil {
+		return ErrPatronNotFound
+	}
+
+	return nil
+}
+
+func (s *CirculationService) ensureCategory(categoryID int) error {
+	category, err := s.categoryRepo.GetByID(categoryID)
+	if err != nil {
+		return errors.New("failed to get category")
+	}
+
+	if category == nil {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// circulationError menerjemahkan error repository peminjaman menjadi error
+// service yang dikenali controller
+func circulationError(err error, fallback string) error {
+	switch err {
+	case sql.ErrNoRows:
+		return ErrLoanNotFound
+	case repositories.ErrItemNotAvailable:
+		return ErrItemNotAvailable
+	case repositories.ErrItemOnHold:
+		return ErrItemOnHold
+	case repositories.ErrPatronSuspended:
+		return ErrPatronSuspended
+	case repositories.ErrLoanLimitReached:
+		return ErrLoanLimitReached
+	case repositories.ErrFinesOutstanding:
+		return ErrFinesOutstanding
+	case repositories.ErrLoanClosed:
+		return ErrLoanAlreadyReturned
+	case repositories.ErrLoanOverdue:
+		return ErrLoanOverdue
+	case repositories.ErrRenewalLimitReached:
+		return ErrRenewalLimitReached
+	case repositories.ErrHoldsPending:
+		return ErrHoldsPending
+	}
+
+	return errors.New(fallback)
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/storage"
+	"book-management/internal/utils"
+)
+
+// maxCoverPixels membatasi resolusi gambar yang didekode agar file kecil
+// dengan dimensi sangat besar tidak menghabiskan memori
+const maxCoverPixels = 40_000_000
+
+// coverExtensions berisi tipe gambar yang diterima, dideteksi dari isi file
+// (bukan dari header Content-Type yang dikirim klien)
+var coverExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type CoverService struct {
+	bookRepo        *repositories.BookRepository
+	coverRepo       *repositories.CoverRepository
+	storage         storage.Storage
+	maxSize         int64
+	thumbnailWidths []int
+}
+
+func NewCoverService(bookRepo *repositories.BookRepository, coverRepo *repositories.CoverRepository, store storage.Storage, maxSize int64, thumbnailWidths []int) *CoverService {
+	widths := append([]int(nil), thumbnailWidths...)
+	sort.Ints(widths)
+
+	return &CoverService{
+		bookRepo:        bookRepo,
+		coverRepo:       coverRepo,
+		storage:         store,
+		maxSize:         maxSize,
+		thumbnailWidths: widths,
+	}
+}
+
+func (s *CoverService) MaxSize() int64 {
+	return s.maxSize
+}
+
+func (s *CoverService) GetCover(bookID int) (*models.BookCover, error) {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+
+	images, err := s.coverRepo.GetByBookID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get cover")
+	}
+
+	if len(images) == 0 {
+		return nil, ErrCoverNotFound
+	}
+
+	return &models.BookCover{BookID: bookID, ImageURL: book.ImageURL, Images: images}, nil
+}
+
+// UploadCover menyimpan gambar sampul beserta thumbnail-nya, lalu mengganti
+// sampul lama dan mengisi image_url buku dengan URL gambar asli
+func (s *CoverService) UploadCover(bookID int, file io.Reader, username string) (*models.BookCover, error) {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, s.maxSize+1))
+	if err != nil {
+		return nil, errors.New("failed to read cover image")
+	}
+
+	if int64(len(data)) > s.maxSize {
+		return nil, tooLarge("cover_too_large", "Cover image must not exceed %d MB", s.maxSize>>20)
+	}
+
+	return s.storeCover(bookID, data, username)
+}
+
+func (s *CoverService) storeCover(bookID int, data []byte, username string) (*models.BookCover, error) {
+	contentType := http.DetectContentType(data)
+	extension, ok := coverExtensions[contentType]
+	if !ok {
+		return nil, ErrUnsupportedCoverType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, invalid("invalid_cover_image", "Invalid cover image", "")
+	}
+
+	if config.Width*config.Height > maxCoverPixels {
+		return nil, invalid("invalid_cover_image", "Invalid cover image", "cover image dimensions are too large")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, invalid("invalid_cover_image", "Invalid cover image", "")
+	}
+
+	// A random prefix gives every upload new URLs, so caches never serve a stale cover
+	prefix, err := randomToken(8)
+	if err != nil {
+		return nil, errors.New("failed to store cover image")
+	}
+	prefix = fmt.Sprintf("%s/%d/%s", storage.PublicPrefix, bookID, prefix)
+
+	images := []models.CoverImage{{
+		Variant:     "original",
+		StorageKey:  fmt.Sprintf("%s-original.%s", prefix, extension),
+		ContentType: contentType,
+		Width:       config.Width,
+		Height:      config.Height,
+		SizeBytes:   int64(len(data)),
+	}}
+	contents := [][]byte{data}
+
+	for _, width := range s.thumbnailWidths {
+		if width <= 0 || width >= config.Width {
+			continue
+		}
+
+		thumbnail := utils.ResizeToWidth(img, width)
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, errors.New("failed to generate thumbnail")
+		}
+
+		variant := fmt.Sprintf("w%d", width)
+		images = append(images, models.CoverImage{
+			Variant:     variant,
+			StorageKey:  fmt.Sprintf("%s-%s.jpg", prefix, variant),
+			ContentType: "image/jpeg",
+			Width:       thumbnail.Bounds().Dx(),
+			Height:      thumbnail.Bounds().Dy(),
+			SizeBytes:   int64(buf.Len()),
+		})
+		contents = append(contents, buf.Bytes())
+	}
+
+	for i := range images {
+		err := s.storage.Put(images[i].StorageKey, bytes.NewReader(contents[i]), images[i].SizeBytes, images[i].ContentType)
+		if err != nil {
+			log.Printf("Failed to store %s: %v", images[i].StorageKey, err)
+			s.deleteObjects(coverKeys(images[:i]))
+			return nil, errors.New("failed to store cover image")
+		}
+		images[i].URL = s.storage.URL(images[i].StorageKey)
+	}
+
+	oldKeys, err := s.coverRepo.Replace(bookID, images, images[0].URL, username)
+	if err != nil {
+		s.deleteObjects(coverKeys(images))
+		return nil, errors.New("failed to save cover")
+	}
+
+	s.deleteObjects(oldKeys)
+
+	return &models.BookCover{BookID: bookID, ImageURL: images[0].URL, Images: images}, nil
+}
+
+func (s *CoverService) DeleteCover(bookID int, username string) error {
+	oldKeys, err := s.coverRepo.Delete(bookID, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCoverNotFound
+		}
+		return errors.New("failed to delete cover")
+	}
+
+	s.deleteObjects(oldKeys)
+
+	return nil
+}
+
+// deleteObjects menghapus objek storage yang sudah tidak dirujuk database.
+// Kegagalan hanya dicatat karena data di database sudah konsisten.
+func (s *CoverService) deleteObjects(keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(key); err != nil {
+			log.Printf("Failed to delete stored object %s: %v", key, err)
+		}
+	}
+}
+
+func coverKeys(images []models.CoverImage) []string {
+	keys := make([]string, len(images))
+	for i, image := range images {
+		keys[i] = image.StorageKey
+	}
+	return keys
+}
+
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"book-management/internal/utils"
+)
+
+// Jenis kesalahan domain. Middleware ErrorHandler memetakan jenis ini ke
+// status HTTP dengan errors.Is, sehingga controller tidak perlu mencocokkan
+// teks kesalahan.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrInvalid      = errors.New("invalid request")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrTooLarge     = errors.New("too large")
+)
+
+// Error adalah kesalahan domain yang dikembalikan service. Code adalah kode
+// kesalahan yang stabil untuk klien, Message pesan respons (sekaligus kunci
+// katalog i18n, dengan Args sebagai argumen format) dan Detail isi field
+// error pada respons.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Args    []interface{}
+	Detail  interface{}
+	Err     error
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if len(e.Args) > 0 {
+		message = fmt.Sprintf(message, e.Args...)
+	}
+	if detail, ok := e.Detail.(string); ok && detail != "" {
+		message += ": " + detail
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Is membuat errors.Is(err, ErrNotFound) dan sejenisnya bernilai true untuk
+// setiap Error dengan jenis tersebut
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func notFound(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+func conflict(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+func forbidden(code, message string) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+// invalid membuat kesalahan 400; detail menjelaskan bagian request yang
+// ditolak dan boleh kosong
+func invalid(code, message, detail string) *Error {
+	err := &Error{Kind: ErrInvalid, Code: code, Message: message}
+	if detail != "" {
+		err.Detail = detail
+	}
+	return err
+}
+
+func tooLarge(code, message string, args ...interface{}) *Error {
+	return &Error{Kind: ErrTooLarge, Code: code, Message: message, Args: args}
+}
+
+// validationFailed membungkus kesalahan validator; daftar field yang tidak
+// valid dikirim di field error pada respons
+func validationFailed(err error) *Error {
+	return &Error{
+		Kind:    ErrValidation,
+		Code:    "validation_failed",
+		Message: "Validation failed",
+		Detail:  utils.FormatValidationErrors(err),
+		Err:     err,
+	}
+}
+
+// Kesalahan "tidak ditemukan" (404)
+var (
+	ErrBookNotFound                 = notFound("book_not_found", "Book not found")
+	ErrSourceBookNotFound           = notFound("source_book_not_found", "Source book not found")
+	ErrRelatedBookNotFound          = notFound("related_book_not_found", "Related book not found")
+	ErrCategoryNotFound             = notFound("category_not_found", "Category not found")
+	ErrTagNotFound                  = notFound("tag_not_found", "Tag not found")
+	ErrUserNotFound                 = notFound("user_not_found", "User not found")
+	ErrPatronNotFound               = notFound("patron_not_found", "Patron not found")
+	ErrItemNotFound                 = notFound("item_not_found", "Item not found")
+	ErrLoanNotFound                 = notFound("loan_not_found", "Loan not found")
+	ErrLoanPolicyNotFound           = notFound("loan_policy_not_found", "Loan policy not found")
+	ErrHoldNotFound                 = notFound("hold_not_found", "Hold not found")
+	ErrCoverNotFound                = notFound("cover_not_found", "Cover not found")
+	ErrFileNotFound                 = notFound("file_not_found", "File not found")
+	ErrExchangeRateNotFound         = notFound("exchange_rate_not_found", "Exchange rate not found")
+	ErrScheduledPriceChangeNotFound = notFound("scheduled_price_change_not_found", "Pending scheduled price change not found")
+	ErrDiscountNotFound             = notFound("discount_not_found", "Discount not found")
+	ErrReviewNotFound               = notFound("review_not_found", "Review not found")
+	ErrReadingListNotFound          = notFound("reading_list_not_found", "Reading list not found")
+	ErrReadingProgressNotFound      = notFound("reading_progress_not_found", "Reading progress not found")
+	ErrBookNotInList                = notFound("book_not_in_list", "Book is not in the reading list")
+	ErrWorkNotFound                 = notFound("work_not_found", "Work not found")
+	ErrNotAnEdition                 = notFound("not_an_edition", "Book is not an edition of this work")
+	ErrRelationNotFound             = notFound("relation_not_found", "Book relation not found")
+	ErrSeriesNotFound               = notFound("series_not_found", "Series not found")
+	ErrBookNotInSeries              = notFound("book_not_in_series", "Book is not in the series")
+	ErrTranslationNotFound          = notFound("translation_not_found", "Translation not found")
+)
+
+// Kesalahan konflik dengan data yang sudah ada (409)
+var (
+	ErrBookHasLoanHistory   = conflict("book_has_loan_history", "Book has loan history")
+	ErrCategoryNameExists   = conflict("category_name_exists", "Category name already exists")
+	ErrTagExists            = conflict("tag_exists", "Tag already exists")
+	ErrCardNumberExists     = conflict("card_number_exists", "Card number already exists")
+	ErrPatronHasLoanHistory = conflict("patron_has_loan_history", "Patron has loan history")
+	ErrBarcodeExists        = conflict("barcode_exists", "Barcode already exists")
+	ErrItemHasLoanHistory   = conflict("item_has_loan_history", "Item has loan history")
+	ErrItemInCirculation    = conflict("item_in_circulation", "Item is on loan or on hold")
+	ErrItemStatusChanged    = conflict("item_status_changed", "Item status has changed")
+	ErrItemNotAvailable     = conflict("item_not_available", "Item is not available for loan")
+	ErrItemOnHold           = conflict("item_on_hold", "Item is on hold for another patron")
+	ErrPatronSuspended      = conflict("patron_suspended", "Patron is suspended")
+	ErrLoanLimitReached     = conflict("loan_limit_reached", "Patron has reached the active loan limit")
+	ErrFinesOutstanding     = conflict("fines_outstanding", "Patron has outstanding fines above the allowed balance")
+	ErrBookAlreadyBorrowed  = conflict("book_already_borrowed", "Patron already has this book on loan")
+	ErrLoanAlreadyReturned  = conflict("loan_already_returned", "Loan already returned")
+	ErrLoanOverdue          = conflict("loan_overdue", "Overdue loans cannot be renewed")
+	ErrRenewalLimitReached  = conflict("renewal_limit_reached", "Renewal limit reached")
+	ErrHoldsPending         = conflict("holds_pending", "Other patrons are waiting for this book")
+	ErrHoldExists           = conflict("hold_exists", "Patron already has an open hold for this book")
+	ErrHoldAlreadyClosed    = conflict("hold_already_closed", "Hold already closed")
+	ErrReviewExists         = conflict("review_exists", "You have already reviewed this book")
+	ErrBookAlreadyInList    = conflict("book_already_in_list", "Book is already in the reading list")
+	ErrCannotDeleteShelf    = conflict("cannot_delete_shelf", "Shelves cannot be deleted")
+	ErrRelationExists       = conflict("relation_exists", "Book relation already exists")
+	ErrSeriesNameExists     = conflict("series_name_exists", "Series name already exists")
+	ErrBookAlreadyInSeries  = conflict("book_already_in_series", "Book is already in the series")
+	ErrVolumeNumberUsed     = conflict("volume_number_used", "Volume number is already used in the series")
+)
+
+// Kesalahan hak akses (401 dan 403)
+var (
+	ErrInvalidCredentials = &Error{Kind: ErrUnauthorized, Code: "invalid_credentials", Message: "Invalid credentials"}
+	ErrNotReviewOwner     = forbidden("not_review_owner", "You can only change your own review")
+	ErrNotModerator       = forbidden("not_moderator", "Only moderators can moderate reviews")
+	ErrNotListOwner       = forbidden("not_list_owner", "You can only change your own reading lists")
+)
+
+// Kesalahan request yang tidak dapat diproses (400). Kategori yang dirujuk
+// dari body request memakai kode yang sama dengan ErrCategoryNotFound tetapi
+// berstatus 400, karena yang salah adalah isi request, bukan URL-nya.
+var (
+	ErrReferencedCategoryNotFound = invalid("category_not_found", "Category not found", "")
+	ErrParentCategoryNotFound     = invalid("parent_category_not_found", "Parent category not found", "")
+	ErrTargetCategoryNotFound     = invalid("target_category_not_found", "Target category not found", "")
+	ErrCategoryMoveIntoSelf       = invalid("category_move_into_self", "Category cannot be moved under itself or its descendants", "")
+	ErrCategoryReassignToSelf     = invalid("category_reassign_to_self", "Category cannot be reassigned to itself", "")
+	ErrCategoryMergeIntoSelf      = invalid("category_merge_into_self", "Category cannot be merged into itself", "")
+	ErrCategoryMergeIntoChild     = invalid("category_merge_into_descendant", "Category cannot be merged into its own descendant", "")
+	ErrUnsupportedCurrency        = invalid("unsupported_currency", "Unsupported currency", "")
+	ErrSameCurrency               = invalid("same_currency", "Base and quote currency must differ", "")
+	ErrNonPositiveRate            = invalid("non_positive_rate", "Rate must be a positive number", "rates round to 12 decimal places, so they must be at least 0.000000000001")
+	ErrRateTooLarge               = invalid("rate_too_large", "Rate is too large", "rates must be below 10^18")
+	ErrUnsupportedCoverType       = invalid("unsupported_cover_type", "Unsupported cover image type, use JPEG, PNG or GIF", "")
+	ErrUnsupportedFileFormat      = invalid("unsupported_file_format", "Unsupported file format, use PDF, EPUB or MOBI", "")
+	ErrUnsupportedMetadataFormat  = invalid("unsupported_metadata_format", "Metadata can only be read from EPUB or PDF files", "")
+	ErrMetadataUnreadable         = invalid("metadata_unreadable", "Failed to read metadata from file", "")
+)
+
+// categoryInUse dikembalikan ketika kategori yang akan dihapus masih menjadi
+// kategori utama sejumlah buku
+func categoryInUse(bookCount int) *Error {
+	return &Error{
+		Kind:    ErrConflict,
+		Code:    "category_in_use",
+		Message: "Category is the primary category of %d books; move them with ?reassign_to=<category_id> or merge the category",
+		Args:    []interface{}{bookCount},
+		Detail:  map[string]interface{}{"book_count": bookCount},
+	}
+}
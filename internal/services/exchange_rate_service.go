@@ -0,0 +1,257 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"book-management/internal/currency"
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+// maxImportedRates membatasi jumlah baris dalam satu file impor kurs
+const maxImportedRates = 10000
+
+type ExchangeRateService struct {
+	rateRepo     *repositories.ExchangeRateRepository
+	baseCurrency string
+}
+
+func NewExchangeRateService(rateRepo *repositories.ExchangeRateRepository, baseCurrency string) *ExchangeRateService {
+	return &ExchangeRateService{
+		rateRepo:     rateRepo,
+		baseCurrency: baseCurrency,
+	}
+}
+
+// BaseCurrency adalah mata uang default harga buku dan perantara konversi
+// bila kurs langsung antara dua mata uang tidak tersedia
+func (s *ExchangeRateService) BaseCurrency() string {
+	return s.baseCurrency
+}
+
+func (s *ExchangeRateService) GetRates() ([]models.ExchangeRate, error) {
+	rates, err := s.rateRepo.GetAll()
+	if err != nil {
+		return nil, errors.New("failed to get exchange rates")
+	}
+
+	for i := range rates {
+		rates[i].Rate = trimRate(rates[i].Rate)
+	}
+
+	return rates, nil
+}
+
+func (s *ExchangeRateService) GetRate(base, quote string) (*models.ExchangeRate, error) {
+	base, quote = currency.Normalize(base), currency.Normalize(quote)
+
+	rate, err := s.rateRepo.Get(base, quote)
+	if err != nil {
+		return nil, errors.New("failed to get exchange rate")
+	}
+
+	if rate == nil {
+		return nil, ErrExchangeRateNotFound
+	}
+
+	rate.Rate = trimRate(rate.Rate)
+
+	return rate, nil
+}
+
+// SetRate menyimpan kurs 1 base = rate quote
+func (s *ExchangeRateService) SetRate(base, quote string, req *models.SetExchangeRateRequest, username string) (*models.ExchangeRate, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	rate, err := newExchangeRate(base, quote, req.Rate.String(), "api", username)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := []models.ExchangeRate{*rate}
+	if err := s.rateRepo.Save(rates); err != nil {
+		return nil, errors.New("failed to save exchange rate")
+	}
+
+	return &rates[0], nil
+}
+
+// ImportCSV menyimpan kurs dari file CSV berkolom base_currency,
+// quote_currency,rate (baris judul opsional). Semua baris disimpan dalam
+// satu transaksi; satu baris yang salah membatalkan seluruh impor.
+func (s *ExchangeRateService) ImportCSV(r io.Reader, username string) (*models.ExchangeRateImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	var rates []models.ExchangeRate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, invalid("invalid_exchange_rate_file", "Invalid exchange rate file", err.Error())
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "base_currency") {
+			continue
+		}
+
+		if len(rates) == maxImportedRates {
+			return nil, invalid("invalid_exchange_rate_file", "Invalid exchange rate file", fmt.Sprintf("more than %d rates", maxImportedRates))
+		}
+
+		rate, err := newExchangeRate(record[0], record[1], record[2], "import", username)
+		if err != nil {
+			return nil, invalid("invalid_exchange_rate_file", "Invalid exchange rate file", fmt.Sprintf("line %d: %v", line, err))
+		}
+		rates = append(rates, *rate)
+	}
+
+	if len(rates) == 0 {
+		return nil, invalid("invalid_exchange_rate_file", "Invalid exchange rate file", "no rates found")
+	}
+
+	if err := s.rateRepo.Save(rates); err != nil {
+		return nil, errors.New("failed to save exchange rates")
+	}
+
+	return &models.ExchangeRateImportResult{Imported: len(rates)}, nil
+}
+
+func (s *ExchangeRateService) DeleteRate(base, quote string) error {
+	err := s.rateRepo.Delete(currency.Normalize(base), currency.Normalize(quote))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrExchangeRateNotFound
+		}
+		return errors.New("failed to delete exchange rate")
+	}
+
+	return nil
+}
+
+// NewConverter memuat semua kurs sekali sehingga banyak harga bisa
+// dikonversi tanpa query tambahan
+func (s *ExchangeRateService) NewConverter() (*PriceConverter, error) {
+	rates, err := s.rateRepo.GetAll()
+	if err != nil {
+		return nil, errors.New("failed to get exchange rates")
+	}
+
+	converter := &PriceConverter{
+		baseCurrency: s.baseCurrency,
+		rates:        make(map[[2]string]*big.Rat, len(rates)),
+	}
+
+	for _, rate := range rates {
+		value, err := currency.ParseRate(rate.Rate)
+		if err != nil {
+			continue
+		}
+		converter.rates[[2]string{rate.BaseCurrency, rate.QuoteCurrency}] = value
+	}
+
+	return converter, nil
+}
+
+// PriceConverter mengonversi harga memakai kurs yang sudah dimuat
+type PriceConverter struct {
+	baseCurrency string
+	rates        map[[2]string]*big.Rat
+}
+
+// Convert mengubah amount (minor unit mata uang from) ke mata uang to
+func (c *PriceConverter) Convert(amount int64, from, to string) (*models.ConvertedPrice, error) {
+	rate := c.rate(from, to)
+	if rate == nil {
+		return nil, invalid("exchange_rate_unavailable", "Exchange rate not available", fmt.Sprintf("no exchange rate from %s to %s", from, to))
+	}
+
+	converted, err := currency.Convert(amount, from, to, rate)
+	if err != nil {
+		return nil, invalid("converted_amount_too_large", "Converted amount is too large", fmt.Sprintf("%d %s does not fit in %s", amount, from, to))
+	}
+
+	return &models.ConvertedPrice{
+		Currency: to,
+		Amount:   converted,
+		Display:  currency.Format(converted, to),
+		Rate:     currency.FormatRate(rate),
+	}, nil
+}
+
+// rate mencari kurs langsung, kebalikan kurs, lalu kurs silang lewat mata
+// uang dasar
+func (c *PriceConverter) rate(from, to string) *big.Rat {
+	if from == to {
+		return big.NewRat(1, 1)
+	}
+
+	if rate, ok := c.rates[[2]string{from, to}]; ok {
+		return rate
+	}
+
+	if rate, ok := c.rates[[2]string{to, from}]; ok {
+		return new(big.Rat).Inv(rate)
+	}
+
+	if from == c.baseCurrency || to == c.baseCurrency {
+		return nil
+	}
+
+	toBase := c.rate(from, c.baseCurrency)
+	fromBase := c.rate(c.baseCurrency, to)
+	if toBase == nil || fromBase == nil {
+		return nil
+	}
+
+	return new(big.Rat).Mul(toBase, fromBase)
+}
+
+func newExchangeRate(base, quote, value, source, username string) (*models.ExchangeRate, error) {
+	base, quote = currency.Normalize(base), currency.Normalize(quote)
+
+	if !currency.IsSupported(base) || !currency.IsSupported(quote) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	if base == quote {
+		return nil, ErrSameCurrency
+	}
+
+	rate, err := currency.ParseRate(value)
+	if err != nil {
+		if err == currency.ErrRateTooLarge {
+			return nil, ErrRateTooLarge
+		}
+		return nil, ErrNonPositiveRate
+	}
+
+	return &models.ExchangeRate{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          currency.FormatRate(rate),
+		Source:        source,
+		UpdatedBy:     username,
+	}, nil
+}
+
+// trimRate membuang nol di belakang koma dari nilai NUMERIC
+func trimRate(value string) string {
+	if rate, err := currency.ParseRate(value); err == nil {
+		return currency.FormatRate(rate)
+	}
+	return value
+}
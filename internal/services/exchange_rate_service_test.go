@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func testConverter() *PriceConverter {
+	return &PriceConverter{
+		baseCurrency: "IDR",
+		rates: map[[2]string]*big.Rat{
+			{"USD", "IDR"}: big.NewRat(16000, 1),
+			{"EUR", "IDR"}: big.NewRat(17500, 1),
+			{"JPY", "IDR"}: big.NewRat(100, 1),
+			{"IDR", "KWD"}: big.NewRat(1, 50000),
+		},
+	}
+}
+
+func TestPriceConverterConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		from, to string
+		want     int64
+		wantRate string
+	}{
+		{name: "direct", amount: 1999, from: "USD", to: "IDR", want: 319840, wantRate: "16000"},
+		{name: "inverse", amount: 100000, from: "IDR", to: "USD", want: 625, wantRate: "0.0000625"},
+		{name: "inverse negative", amount: -100000, from: "IDR", to: "USD", want: -625, wantRate: "0.0000625"},
+		{name: "cross rate", amount: 1000, from: "USD", to: "EUR", want: 914, wantRate: "0.914285714286"},
+		{name: "cross rate rounds half up", amount: 4, from: "JPY", to: "USD", want: 3, wantRate: "0.00625"},
+		{name: "cross rate rounds negative half down", amount: -4, from: "JPY", to: "USD", want: -3, wantRate: "0.00625"},
+		{name: "cross rate below half", amount: 3, from: "JPY", to: "USD", want: 2, wantRate: "0.00625"},
+		{name: "cross rate with inverse leg", amount: 1000, from: "KWD", to: "USD", want: 313, wantRate: "3.125"},
+		{name: "same currency", amount: 1999, from: "USD", to: "USD", want: 1999, wantRate: "1"},
+	}
+
+	converter := testConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := converter.Convert(tt.amount, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if got.Amount != tt.want || got.Rate != tt.wantRate || got.Currency != tt.to {
+				t.Errorf("Convert(%d, %s, %s) = %+v, want amount %d rate %s", tt.amount, tt.from, tt.to, got, tt.want, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestPriceConverterMissingRate(t *testing.T) {
+	converter := testConverter()
+	for _, pair := range [][2]string{{"IDR", "GBP"}, {"GBP", "USD"}, {"USD", "GBP"}} {
+		_, err := converter.Convert(100, pair[0], pair[1])
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("Convert(%s, %s) error = %v, want %v", pair[0], pair[1], err, ErrInvalid)
+		}
+	}
+}
+
+func TestPriceConverterOverflow(t *testing.T) {
+	_, err := testConverter().Convert(math.MaxInt64, "USD", "IDR")
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("Convert() error = %v, want %v", err, ErrInvalid)
+	}
+}
+
+func TestNewExchangeRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		want     string
+		wantCode string
+	}{
+		{name: "valid", value: "16000.5", want: "16000.5"},
+		{name: "smallest stored rate", value: "0.000000000001", want: "0.000000000001"},
+		{name: "rounds to zero", value: "0.00000000000004", wantCode: "non_positive_rate"},
+		{name: "zero", value: "0", wantCode: "non_positive_rate"},
+		{name: "not a number", value: "abc", wantCode: "non_positive_rate"},
+		{name: "too large", value: "1e18", wantCode: "rate_too_large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := newExchangeRate("idr", "usd", tt.value, "api", "admin")
+			if tt.wantCode != "" {
+				var serviceErr *Error
+				if !errors.As(err, &serviceErr) || serviceErr.Code != tt.wantCode {
+					t.Fatalf("newExchangeRate(%q) error = %v, want code %s", tt.value, err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newExchangeRate(%q) error = %v", tt.value, err)
+			}
+			if rate.Rate != tt.want || rate.BaseCurrency != "IDR" || rate.QuoteCurrency != "USD" {
+				t.Errorf("newExchangeRate(%q) = %+v, want rate %s IDR/USD", tt.value, rate, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type FineService struct {
+	ledgerRepo *repositories.LedgerRepository
+	patronRepo *repositories.PatronRepository
+	currency   string
+}
+
+func NewFineService(ledgerRepo *repositories.LedgerRepository, patronRepo *repositories.PatronRepository, currency string) *FineService {
+	return &FineService{
+		ledgerRepo: ledgerRepo,
+		patronRepo: patronRepo,
+		currency:   currency,
+	}
+}
+
+func (s *FineService) GetLedger(patronID int) ([]models.LedgerEntry, error) {
+	if err := s.ensurePatron(patronID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.ledgerRepo.GetEntries(patronID)
+	if err != nil {
+		return nil, errors.New("failed to get ledger")
+	}
+
+	return entries, nil
+}
+
+func (s *FineService) GetBalance(patronID int) (*models.PatronBalance, error) {
+	if err := s.ensurePatron(patronID); err != nil {
+		return nil, err
+	}
+
+	balance, err := s.ledgerRepo.GetBalance(patronID)
+	if err != nil {
+		return nil, errors.New("failed to get balance")
+	}
+
+	balance.Currency = s.currency
+	return balance, nil
+}
+
+// RecordPayment mencatat pembayaran denda anggota
+func (s *FineService) RecordPayment(patronID int, req *models.LedgerEntryRequest, username string) (*models.LedgerEntry, error) {
+	return s.addEntry(patronID, "payment", req, username)
+}
+
+// RecordWaiver mencatat penghapusan denda anggota
+func (s *FineService) RecordWaiver(patronID int, req *models.LedgerEntryRequest, username string) (*models.LedgerEntry, error) {
+	return s.addEntry(patronID, "waiver", req, username)
+}
+
+// AccrueFines menambah denda pinjaman yang terlambat; dipanggil berkala oleh
+// background job
+func (s *FineService) AccrueFines() error {
+	accrued, err := s.ledgerRepo.AccrueFines(time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if accrued > 0 {
+		log.Printf("Accrued overdue fines on %d loans", accrued)
+	}
+
+	return nil
+}
+
+func (s *FineService) addEntry(patronID int, entryType string, req *models.LedgerEntryRequest, username string) (*models.LedgerEntry, error) {
+	req.Note = strings.TrimSpace(req.Note)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if err := s.ensurePatron(patronID); err != nil {
+		return nil, err
+	}
+
+	entry := &models.LedgerEntry{
+		PatronID:  patronID,
+		LoanID:    req.LoanID,
+		EntryType: entryType,
+		Amount:    req.Amount,
+		Currency:  s.currency,
+		Note:      req.Note,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: username,
+	}
+
+	if err := s.ledgerRepo.AddEntry(entry); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrPatronNotFound
+		case repositories.ErrAmountExceedsBalance:
+			return nil, invalid("invalid_ledger_entry", "Invalid ledger entry", "amount exceeds outstanding balance")
+		case repositories.ErrLoanPatronMismatch:
+			return nil, invalid("invalid_ledger_entry", "Invalid ledger entry", "loan does not belong to patron")
+		}
+		return nil, errors.New("failed to record " + entryType)
+	}
+
+	return entry, nil
+}
+
+func (s *FineService) ensurePatron(patronID int) error {
+	patron, err := s.patronRepo.GetByID(patronID)
+	if err != nil {
+		return errors.New("failed to get patron")
+	}
+
+	if patron == nil {
+		return ErrPatronNotFound
+	}
+
+	return nil
+}
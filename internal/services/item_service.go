@@ -0,0 +1,200 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type ItemService struct {
+	itemRepo *repositories.ItemRepository
+	bookRepo *repositories.BookRepository
+}
+
+func NewItemService(itemRepo *repositories.ItemRepository, bookRepo *repositories.BookRepository) *ItemService {
+	return &ItemService{
+		itemRepo: itemRepo,
+		bookRepo: bookRepo,
+	}
+}
+
+func (s *ItemService) GetItems(filter models.ItemFilter) ([]models.Item, error) {
+	items, err := s.itemRepo.GetAll(filter)
+	if err != nil {
+		return nil, errors.New("failed to get items")
+	}
+
+	return items, nil
+}
+
+func (s *ItemService) GetBookItems(bookID int) ([]models.Item, error) {
+	if err := s.ensureBook(bookID); err != nil {
+		return nil, err
+	}
+
+	return s.GetItems(models.ItemFilter{BookID: bookID})
+}
+
+func (s *ItemService) GetItemByID(id int) (*models.Item, error) {
+	item, err := s.itemRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get item")
+	}
+
+	if item == nil {
+		return nil, ErrItemNotFound
+	}
+
+	return item, nil
+}
+
+func (s *ItemService) GetItemByBarcode(barcode string) (*models.Item, error) {
+	item, err := s.itemRepo.GetByBarcode(strings.TrimSpace(barcode))
+	if err != nil {
+		return nil, errors.New("failed to get item")
+	}
+
+	if item == nil {
+		return nil, ErrItemNotFound
+	}
+
+	return item, nil
+}
+
+func (s *ItemService) CreateItem(bookID int, req *models.CreateItemRequest, username string) (*models.Item, error) {
+	req.Barcode = strings.TrimSpace(req.Barcode)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if err := s.ensureBook(bookID); err != nil {
+		return nil, err
+	}
+
+	item := &models.Item{
+		BookID:          bookID,
+		Barcode:         req.Barcode,
+		AcquisitionDate: optionalDate(req.AcquisitionDate),
+		Condition:       req.Condition,
+		ShelfLocation:   strings.TrimSpace(req.ShelfLocation),
+		Status:          req.Status,
+		Notes:           req.Notes,
+		CreatedBy:       username,
+		ModifiedBy:      username,
+	}
+
+	if item.Condition == "" {
+		item.Condition = "good"
+	}
+	if item.Status == "" {
+		item.Status = "available"
+	}
+
+	if err := s.itemRepo.Create(item); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateBarcode) {
+			return nil, ErrBarcodeExists
+		}
+		return nil, errors.New("failed to create item")
+	}
+
+	return s.GetItemByID(item.ID)
+}
+
+func (s *ItemService) UpdateItem(id int, req *models.UpdateItemRequest, username string) (*models.Item, error) {
+	req.Barcode = strings.TrimSpace(req.Barcode)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	item, err := s.GetItemByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Circulation statuses are owned by loans and holds
+	if req.Status != item.Status && (isCirculationStatus(req.Status) || isCirculationStatus(item.Status)) {
+		return nil, invalid("invalid_item_status", "Invalid item status", "on_loan and on_hold are only changed by loans and holds")
+	}
+
+	oldStatus := item.Status
+	item.Barcode = req.Barcode
+	item.AcquisitionDate = optionalDate(req.AcquisitionDate)
+	item.Condition = req.Condition
+	item.ShelfLocation = strings.TrimSpace(req.ShelfLocation)
+	item.Status = req.Status
+	item.Notes = req.Notes
+	item.ModifiedBy = username
+
+	if err := s.itemRepo.Update(item, oldStatus); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateBarcode) {
+			return nil, ErrBarcodeExists
+		}
+		if err == repositories.ErrItemStatusChanged {
+			return nil, ErrItemStatusChanged
+		}
+		if err == sql.ErrNoRows {
+			return nil, ErrItemNotFound
+		}
+		return nil, errors.New("failed to update item")
+	}
+
+	return s.GetItemByID(id)
+}
+
+func (s *ItemService) DeleteItem(id int) error {
+	item, err := s.GetItemByID(id)
+	if err != nil {
+		return err
+	}
+
+	if isCirculationStatus(item.Status) {
+		return ErrItemInCirculation
+	}
+
+	if err := s.itemRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrItemNotFound
+		}
+		if err == repositories.ErrItemHasLoans {
+			return ErrItemHasLoanHistory
+		}
+		return errors.New("failed to delete item")
+	}
+
+	return nil
+}
+
+func (s *ItemService) ensureBook(bookID int) error {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return ErrBookNotFound
+	}
+
+	return nil
+}
+
+// isCirculationStatus bernilai true untuk status yang dikelola sirkulasi
+func isCirculationStatus(status string) bool {
+	return status == "on_loan" || status == "on_hold"
+}
+
+// optionalDate mengubah tanggal kosong menjadi NULL
+func optionalDate(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"book-management/internal/metadata"
+	"book-management/internal/models"
+	"book-management/internal/utils"
+)
+
+type MetadataService struct {
+	bookService  *BookService
+	fileService  *BookFileService
+	coverService *CoverService
+}
+
+func NewMetadataService(bookService *BookService, fileService *BookFileService, coverService *CoverService) *MetadataService {
+	return &MetadataService{
+		bookService:  bookService,
+		fileService:  fileService,
+		coverService: coverService,
+	}
+}
+
+func (s *MetadataService) MaxSize() int64 {
+	return s.fileService.MaxSize()
+}
+
+// ExtractFromUpload membaca metadata file yang diunggah tanpa menyimpannya
+// dan mengembalikan draft CreateBookRequest
+func (s *MetadataService) ExtractFromUpload(r io.ReadSeeker, size int64) (*models.BookMetadataDraft, error) {
+	if size > s.fileService.MaxSize() {
+		return nil, tooLarge("file_too_large", "File must not exceed %d MB", s.fileService.MaxSize()>>20)
+	}
+
+	head := make([]byte, 1024)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, errors.New("failed to read file")
+	}
+
+	format, _ := detectBookFileFormat(head[:n])
+	meta, err := s.extract(format, r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &models.BookMetadataDraft{
+		Format:             format,
+		PageCountEstimated: meta.PageCount > 0 && meta.PageCountEstimated,
+		HasCover:           meta.Cover != nil,
+	}
+	applyMetadata(&draft.Draft, meta)
+
+	return draft, nil
+}
+
+// ExtractFromFile membaca metadata file yang sudah terlampir pada buku. Draft
+// berisi data buku saat ini yang ditimpa oleh nilai yang ditemukan di file,
+// yaitu data yang akan disimpan oleh ApplyFromFile.
+func (s *MetadataService) ExtractFromFile(bookID, fileID int) (*models.BookMetadataDraft, error) {
+	draft, _, err := s.extractFromFile(bookID, fileID)
+	return draft, err
+}
+
+// ApplyFromFile memperbarui buku dengan metadata file yang terlampir. Sampul
+// dari file hanya dipakai bila buku belum memiliki sampul atau replaceCover
+// bernilai true.
+func (s *MetadataService) ApplyFromFile(bookID, fileID int, replaceCover bool, username string) (*models.BookMetadataResult, error) {
+	draft, meta, err := s.extractFromFile(bookID, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nil category IDs and tags keep the book's current values
+	req := &models.UpdateBookRequest{
+		Title:       draft.Draft.Title,
+		Description: draft.Draft.Description,
+		ImageURL:    draft.Draft.ImageURL,
+		ReleaseYear: draft.Draft.ReleaseYear,
+		Price:       draft.Draft.Price,
+		Currency:    draft.Draft.Currency,
+		TotalPage:   draft.Draft.TotalPage,
+		CategoryID:  draft.Draft.CategoryID,
+	}
+
+	book, err := s.bookService.UpdateBook(bookID, req, username)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BookMetadataResult{Book: book}
+	if meta.Cover == nil || int64(len(meta.Cover)) > s.coverService.MaxSize() {
+		return result, nil
+	}
+
+	if !replaceCover {
+		if _, err := s.coverService.GetCover(bookID); err == nil || !errors.Is(err, ErrCoverNotFound) {
+			return result, nil
+		}
+	}
+
+	// The book is already updated, so a broken embedded cover is only logged
+	cover, err := s.coverService.storeCover(bookID, meta.Cover, username)
+	if err != nil {
+		log.Printf("Failed to store embedded cover of book %d: %v", bookID, err)
+		return result, nil
+	}
+
+	result.Cover = cover
+	result.Book.ImageURL = cover.ImageURL
+
+	return result, nil
+}
+
+func (s *MetadataService) extractFromFile(bookID, fileID int) (*models.BookMetadataDraft, *metadata.Metadata, error) {
+	book, err := s.bookService.GetBookByID(bookID, models.BookViewOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, content, err := s.fileService.OpenFile(bookID, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer content.Close()
+
+	meta, err := s.extract(file.Format, content, file.SizeBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	draft := &models.BookMetadataDraft{
+		Format: file.Format,
+		Draft: models.CreateBookRequest{
+			Title:       book.Title,
+			Description: book.Description,
+			ImageURL:    book.ImageURL,
+			ReleaseYear: book.ReleaseYear,
+			Price:       &book.Price,
+			Currency:    book.Currency,
+			TotalPage:   book.TotalPage,
+			CategoryID:  book.CategoryID,
+			CategoryIDs: book.CategoryIDs,
+			Tags:        book.Tags,
+		},
+	}
+	applyMetadata(&draft.Draft, meta)
+	draft.PageCountEstimated = meta.PageCount > 0 && meta.PageCountEstimated
+	draft.HasCover = meta.Cover != nil
+
+	return draft, meta, nil
+}
+
+func (s *MetadataService) extract(format string, r io.ReadSeeker, size int64) (*metadata.Metadata, error) {
+	meta, err := metadata.Extract(format, r, size)
+	if err != nil {
+		if err == metadata.ErrUnsupportedFormat {
+			return nil, ErrUnsupportedMetadataFormat
+		}
+		log.Printf("Failed to read %s metadata: %v", format, err)
+		return nil, ErrMetadataUnreadable
+	}
+
+	return meta, nil
+}
+
+// applyMetadata mengisi request dengan nilai metadata yang tidak kosong
+func applyMetadata(req *models.CreateBookRequest, meta *metadata.Metadata) {
+	if title := truncateRunes(strings.TrimSpace(meta.Title), utils.TitleMaxLength()); title != "" {
+		req.Title = title
+	}
+
+	if meta.Description != "" {
+		req.Description = meta.Description
+	}
+
+	if meta.PublicationYear > 0 {
+		req.ReleaseYear = meta.PublicationYear
+	}
+
+	if meta.PageCount > 0 {
+		req.TotalPage = meta.PageCount
+	}
+}
+
+func truncateRunes(value string, max int) string {
+	if utf8.RuneCountInString(value) <= max {
+		return value
+	}
+
+	return string([]rune(value)[:max])
+}
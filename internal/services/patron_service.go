@@ -0,0 +1,131 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type PatronService struct {
+	patronRepo *repositories.PatronRepository
+}
+
+func NewPatronService(patronRepo *repositories.PatronRepository) *PatronService {
+	return &PatronService{
+		patronRepo: patronRepo,
+	}
+}
+
+func (s *PatronService) GetPatrons(search string) ([]models.Patron, error) {
+	patrons, err := s.patronRepo.GetAll(strings.TrimSpace(search))
+	if err != nil {
+		return nil, errors.New("failed to get patrons")
+	}
+
+	return patrons, nil
+}
+
+func (s *PatronService) GetPatronByID(id int) (*models.Patron, error) {
+	patron, err := s.patronRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get patron")
+	}
+
+	if patron == nil {
+		return nil, ErrPatronNotFound
+	}
+
+	return patron, nil
+}
+
+func (s *PatronService) CreatePatron(req *models.PatronRequest, username string) (*models.Patron, error) {
+	normalizePatronRequest(req)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	patron := &models.Patron{
+		CardNumber: req.CardNumber,
+		Name:       req.Name,
+		Email:      req.Email,
+		Phone:      req.Phone,
+		Status:     req.Status,
+		CreatedBy:  username,
+		ModifiedBy: username,
+	}
+
+	if patron.Status == "" {
+		patron.Status = "active"
+	}
+
+	if err := s.patronRepo.Create(patron); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateCardNumber) {
+			return nil, ErrCardNumberExists
+		}
+		return nil, errors.New("failed to create patron")
+	}
+
+	return s.GetPatronByID(patron.ID)
+}
+
+func (s *PatronService) UpdatePatron(id int, req *models.PatronRequest, username string) (*models.Patron, error) {
+	normalizePatronRequest(req)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	patron, err := s.GetPatronByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	patron.CardNumber = req.CardNumber
+	patron.Name = req.Name
+	patron.Email = req.Email
+	patron.Phone = req.Phone
+	if req.Status != "" {
+		patron.Status = req.Status
+	}
+	patron.ModifiedBy = username
+
+	if err := s.patronRepo.Update(patron); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateCardNumber) {
+			return nil, ErrCardNumberExists
+		}
+		if err == sql.ErrNoRows {
+			return nil, ErrPatronNotFound
+		}
+		return nil, errors.New("failed to update patron")
+	}
+
+	return s.GetPatronByID(id)
+}
+
+func (s *PatronService) DeletePatron(id int) error {
+	if err := s.patronRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrPatronNotFound
+		}
+		if err == repositories.ErrPatronHasLoans {
+			return ErrPatronHasLoanHistory
+		}
+		return errors.New("failed to delete patron")
+	}
+
+	return nil
+}
+
+func normalizePatronRequest(req *models.PatronRequest) {
+	req.CardNumber = strings.TrimSpace(req.CardNumber)
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.TrimSpace(req.Email)
+	req.Phone = strings.TrimSpace(req.Phone)
+}
@@ -0,0 +1,400 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"book-management/internal/currency"
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type PricingService struct {
+	bookRepo     *repositories.BookRepository
+	categoryRepo *repositories.CategoryRepository
+	priceRepo    *repositories.PriceRepository
+	discountRepo *repositories.DiscountRepository
+	rateService  *ExchangeRateService
+}
+
+func NewPricingService(bookRepo *repositories.BookRepository, categoryRepo *repositories.CategoryRepository, priceRepo *repositories.PriceRepository, discountRepo *repositories.DiscountRepository, rateService *ExchangeRateService) *PricingService {
+	return &PricingService{
+		bookRepo:     bookRepo,
+		categoryRepo: categoryRepo,
+		priceRepo:    priceRepo,
+		discountRepo: discountRepo,
+		rateService:  rateService,
+	}
+}
+
+func (s *PricingService) GetPriceHistory(bookID int) ([]models.PriceHistory, error) {
+	if _, err := s.getBook(bookID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.priceRepo.GetHistory(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get price history")
+	}
+
+	return history, nil
+}
+
+func (s *PricingService) GetScheduledPriceChanges(bookID int) ([]models.ScheduledPriceChange, error) {
+	if _, err := s.getBook(bookID); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.priceRepo.GetScheduledChanges(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get scheduled price changes")
+	}
+
+	return changes, nil
+}
+
+// SchedulePriceChange menjadwalkan harga baru yang diterapkan otomatis oleh
+// background job saat EffectiveAt tercapai
+func (s *PricingService) SchedulePriceChange(bookID int, req *models.CreateScheduledPriceChangeRequest, username string) (*models.ScheduledPriceChange, error) {
+	req.Currency = currency.Normalize(req.Currency)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	book, err := s.getBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.EffectiveAt.After(time.Now()) {
+		return nil, invalid("invalid_scheduled_price_change", "Invalid scheduled price change", "effective_at must be in the future")
+	}
+
+	// Without a currency the book keeps its current one
+	if req.Currency == "" {
+		req.Currency = book.Currency
+	}
+
+	change := &models.ScheduledPriceChange{
+		BookID:      bookID,
+		Price:       *req.Price,
+		Currency:    req.Currency,
+		EffectiveAt: req.EffectiveAt.UTC(),
+		CreatedBy:   username,
+	}
+
+	if err := s.priceRepo.CreateScheduledChange(change); err != nil {
+		return nil, errors.New("failed to schedule price change")
+	}
+
+	return change, nil
+}
+
+func (s *PricingService) CancelScheduledPriceChange(bookID, changeID int) error {
+	err := s.priceRepo.CancelScheduledChange(bookID, changeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrScheduledPriceChangeNotFound
+		}
+		return errors.New("failed to cancel scheduled price change")
+	}
+
+	return nil
+}
+
+// ApplyScheduledPriceChanges menerapkan semua perubahan harga yang sudah
+// jatuh tempo; dipanggil berkala oleh background job
+func (s *PricingService) ApplyScheduledPriceChanges() error {
+	total := 0
+	for {
+		applied, err := s.priceRepo.ApplyDueChanges(time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		total += applied
+		if applied == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Applied %d scheduled price changes", total)
+	}
+
+	return nil
+}
+
+func (s *PricingService) GetDiscounts(filter models.DiscountFilter) ([]models.Discount, error) {
+	discounts, err := s.discountRepo.GetAll(filter)
+	if err != nil {
+		return nil, errors.New("failed to get discounts")
+	}
+
+	return discounts, nil
+}
+
+func (s *PricingService) GetDiscountByID(id int) (*models.Discount, error) {
+	discount, err := s.discountRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get discount")
+	}
+
+	if discount == nil {
+		return nil, ErrDiscountNotFound
+	}
+
+	return discount, nil
+}
+
+func (s *PricingService) CreateDiscount(req *models.DiscountRequest, username string) (*models.Discount, error) {
+	discount, err := s.buildDiscount(req)
+	if err != nil {
+		return nil, err
+	}
+
+	discount.CreatedBy = username
+	discount.ModifiedBy = username
+
+	if err := s.discountRepo.Create(discount); err != nil {
+		return nil, errors.New("failed to create discount")
+	}
+
+	return discount, nil
+}
+
+func (s *PricingService) UpdateDiscount(id int, req *models.DiscountRequest, username string) (*models.Discount, error) {
+	existing, err := s.GetDiscountByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	discount, err := s.buildDiscount(req)
+	if err != nil {
+		return nil, err
+	}
+
+	discount.ID = id
+	discount.CreatedAt = existing.CreatedAt
+	discount.CreatedBy = existing.CreatedBy
+	discount.ModifiedBy = username
+
+	if err := s.discountRepo.Update(discount); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDiscountNotFound
+		}
+		return nil, errors.New("failed to update discount")
+	}
+
+	return discount, nil
+}
+
+func (s *PricingService) DeleteDiscount(id int) error {
+	err := s.discountRepo.Delete(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrDiscountNotFound
+		}
+		return errors.New("failed to delete discount")
+	}
+
+	return nil
+}
+
+// ApplyEffectivePrices mengisi EffectivePrice setiap buku dengan diskon
+// aktif yang menghasilkan harga terendah. Diskon tidak digabungkan.
+func (s *PricingService) ApplyEffectivePrices(books []models.BookWithCategory) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(books))
+	for i := range books {
+		ids[i] = books[i].ID
+	}
+
+	discounts, err := s.discountRepo.GetActiveForBooks(ids, time.Now().UTC())
+	if err != nil {
+		return errors.New("failed to get discounts")
+	}
+
+	// Exchange rates are only loaded when a fixed discount uses another currency
+	var converter *PriceConverter
+
+	for i := range books {
+		book := &books[i]
+
+		var usable []models.Discount
+		fixedAmounts := make(map[int]int64)
+		for _, discount := range discounts[book.ID] {
+			if discount.DiscountType == "fixed" && discount.Amount != nil {
+				amount := int64(*discount.Amount)
+				if discount.Currency != book.Currency {
+					if converter == nil {
+						if converter, err = s.rateService.NewConverter(); err != nil {
+							return err
+						}
+					}
+					converted, err := converter.Convert(amount, discount.Currency, book.Currency)
+					if err != nil {
+						continue
+					}
+					amount = converted.Amount
+				}
+				fixedAmounts[discount.ID] = amount
+			}
+			usable = append(usable, discount)
+		}
+
+		book.EffectivePrice = effectivePrice(book.Price, book.Currency, usable, fixedAmounts)
+	}
+
+	return nil
+}
+
+// effectivePrice memilih diskon yang menghasilkan harga terendah; bila
+// harganya sama, diskon dengan ID terkecil yang dipakai. fixedAmounts berisi
+// potongan diskon tetap yang sudah dalam mata uang buku, per ID diskon.
+func effectivePrice(price int, code string, discounts []models.Discount, fixedAmounts map[int]int64) *models.EffectivePrice {
+	best := &models.EffectivePrice{Amount: price, Currency: code}
+
+	for _, discount := range discounts {
+		discounted := discountedPrice(price, discount, fixedAmounts[discount.ID])
+		if discounted < best.Amount || (discounted == best.Amount && best.DiscountID != nil && discount.ID < *best.DiscountID) {
+			discount := discount
+			best = &models.EffectivePrice{
+				Amount:       discounted,
+				Currency:     code,
+				DiscountID:   &discount.ID,
+				DiscountName: discount.Name,
+				DiscountEnds: &discount.EndsAt,
+			}
+		}
+	}
+
+	return best
+}
+
+// buildDiscount memvalidasi request dan mengubahnya menjadi diskon
+func (s *PricingService) buildDiscount(req *models.DiscountRequest) (*models.Discount, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Currency = currency.Normalize(req.Currency)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if (req.BookID == nil) == (req.CategoryID == nil) {
+		return nil, invalid("invalid_discount", "Invalid discount", "set either book_id or category_id")
+	}
+
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, invalid("invalid_discount", "Invalid discount", "ends_at must be after starts_at")
+	}
+
+	discount := &models.Discount{
+		Name:         req.Name,
+		BookID:       req.BookID,
+		CategoryID:   req.CategoryID,
+		DiscountType: req.DiscountType,
+		StartsAt:     req.StartsAt.UTC(),
+		EndsAt:       req.EndsAt.UTC(),
+	}
+
+	// Fixed discounts default to the currency of the discounted book
+	defaultCurrency := s.rateService.BaseCurrency()
+	if req.BookID != nil {
+		book, err := s.bookRepo.GetByID(*req.BookID)
+		if err != nil {
+			return nil, errors.New("failed to get book")
+		}
+		if book == nil {
+			return nil, invalid("invalid_discount", "Invalid discount", "book not found")
+		}
+		defaultCurrency = book.Currency
+	} else {
+		category, err := s.categoryRepo.GetByID(*req.CategoryID)
+		if err != nil {
+			return nil, errors.New("failed to get category")
+		}
+		if category == nil {
+			return nil, invalid("invalid_discount", "Invalid discount", "category not found")
+		}
+	}
+
+	switch req.DiscountType {
+	case "percentage":
+		if req.Percentage == nil || req.Amount != nil {
+			return nil, invalid("invalid_discount", "Invalid discount", "percentage discounts need percentage and no amount")
+		}
+		percentage := math.Round(*req.Percentage*100) / 100
+		if percentage <= 0 {
+			return nil, invalid("invalid_discount", "Invalid discount", "percentage must be greater than 0")
+		}
+		discount.Percentage = &percentage
+	case "fixed":
+		if req.Amount == nil || req.Percentage != nil {
+			return nil, invalid("invalid_discount", "Invalid discount", "fixed discounts need amount and no percentage")
+		}
+		discount.Amount = req.Amount
+		discount.Currency = req.Currency
+		if discount.Currency == "" {
+			discount.Currency = defaultCurrency
+		}
+	}
+
+	return discount, nil
+}
+
+func (s *PricingService) getBook(bookID int) (*models.BookWithCategory, error) {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+
+	return book, nil
+}
+
+// discountedPrice menghitung harga setelah satu diskon. fixedAmount adalah
+// potongan diskon tetap yang sudah dalam mata uang buku. Harga tidak pernah
+// kurang dari nol.
+func discountedPrice(price int, discount models.Discount, fixedAmount int64) int {
+	var result int64
+	switch discount.DiscountType {
+	case "percentage":
+		if discount.Percentage == nil {
+			return price
+		}
+		percentage, ok := new(big.Rat).SetString(strconv.FormatFloat(*discount.Percentage, 'f', -1, 64))
+		if !ok {
+			return price
+		}
+		remaining := new(big.Rat).Sub(big.NewRat(100, 1), percentage)
+		value := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(price)), remaining)
+		result = currency.Round(value.Quo(value, big.NewRat(100, 1)))
+	case "fixed":
+		result = int64(price) - fixedAmount
+	default:
+		return price
+	}
+
+	if result < 0 {
+		return 0
+	}
+
+	return int(result)
+}
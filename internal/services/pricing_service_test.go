@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"book-management/internal/models"
+)
+
+func percentageDiscount(id int, percentage float64) models.Discount {
+	return models.Discount{ID: id, Name: "Diskon", DiscountType: "percentage", Percentage: &percentage}
+}
+
+func fixedDiscount(id, amount int, currency string) models.Discount {
+	return models.Discount{ID: id, Name: "Potongan", DiscountType: "fixed", Amount: &amount, Currency: currency}
+}
+
+func TestDiscountedPrice(t *testing.T) {
+	tests := []struct {
+		name        string
+		price       int
+		discount    models.Discount
+		fixedAmount int64
+		want        int
+	}{
+		{name: "percentage", price: 100000, discount: percentageDiscount(1, 10), want: 90000},
+		{name: "percentage rounds down", price: 1999, discount: percentageDiscount(1, 12.5), want: 1749},
+		{name: "percentage rounds half up", price: 1990, discount: percentageDiscount(1, 15), want: 1692},
+		{name: "fractional percentage is exact", price: 100, discount: percentageDiscount(1, 33.33), want: 67},
+		{name: "decimal percentage is exact", price: 1000, discount: percentageDiscount(1, 0.35), want: 997},
+		{name: "full discount", price: 150000, discount: percentageDiscount(1, 100), want: 0},
+		{name: "percentage above 100", price: 150000, discount: percentageDiscount(1, 150), want: 0},
+		{name: "percentage without value", price: 5000, discount: models.Discount{DiscountType: "percentage"}, want: 5000},
+		{name: "fixed", price: 3000, discount: fixedDiscount(1, 500, "IDR"), fixedAmount: 500, want: 2500},
+		{name: "fixed equal to price", price: 3000, discount: fixedDiscount(1, 3000, "IDR"), fixedAmount: 3000, want: 0},
+		{name: "fixed larger than price", price: 3000, discount: fixedDiscount(1, 5000, "IDR"), fixedAmount: 5000, want: 0},
+		{name: "unknown type", price: 3000, discount: models.Discount{DiscountType: "bogus"}, want: 3000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := discountedPrice(tt.price, tt.discount, tt.fixedAmount); got != tt.want {
+				t.Errorf("discountedPrice() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectivePrice(t *testing.T) {
+	tests := []struct {
+		name         string
+		discounts    []models.Discount
+		fixedAmounts map[int]int64
+		wantAmount   int
+		wantDiscount int
+	}{
+		{name: "no discounts", wantAmount: 10000},
+		{
+			name:         "lowest price wins",
+			discounts:    []models.Discount{percentageDiscount(1, 10), fixedDiscount(2, 2000, "IDR"), percentageDiscount(3, 5)},
+			fixedAmounts: map[int]int64{2: 2000},
+			wantAmount:   8000,
+			wantDiscount: 2,
+		},
+		{
+			name:         "equal discounts pick lowest ID",
+			discounts:    []models.Discount{percentageDiscount(7, 10), fixedDiscount(3, 1000, "IDR"), percentageDiscount(5, 10)},
+			fixedAmounts: map[int]int64{3: 1000},
+			wantAmount:   9000,
+			wantDiscount: 3,
+		},
+		{
+			name:         "equal discounts in ID order",
+			discounts:    []models.Discount{percentageDiscount(3, 10), percentageDiscount(7, 10)},
+			wantAmount:   9000,
+			wantDiscount: 3,
+		},
+		{
+			name:         "converted fixed amount",
+			discounts:    []models.Discount{fixedDiscount(4, 100, "USD"), percentageDiscount(5, 10)},
+			fixedAmounts: map[int]int64{4: 1600},
+			wantAmount:   8400,
+			wantDiscount: 4,
+		},
+		{
+			name:       "discount that does not lower the price is ignored",
+			discounts:  []models.Discount{percentageDiscount(1, 0.001), fixedDiscount(2, 0, "IDR")},
+			wantAmount: 10000,
+		},
+		{
+			name:         "full discount",
+			discounts:    []models.Discount{percentageDiscount(9, 100), fixedDiscount(2, 50000, "IDR")},
+			fixedAmounts: map[int]int64{2: 50000},
+			wantAmount:   0,
+			wantDiscount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.discounts {
+				tt.discounts[i].EndsAt = time.Date(2030, 1, tt.discounts[i].ID, 0, 0, 0, 0, time.UTC)
+			}
+
+			got := effectivePrice(10000, "IDR", tt.discounts, tt.fixedAmounts)
+			if got.Amount != tt.wantAmount || got.Currency != "IDR" {
+				t.Errorf("effectivePrice() = %d %s, want %d IDR", got.Amount, got.Currency, tt.wantAmount)
+			}
+
+			if tt.wantDiscount == 0 {
+				if got.DiscountID != nil {
+					t.Errorf("discount = %d, want none", *got.DiscountID)
+				}
+				return
+			}
+			if got.DiscountID == nil || *got.DiscountID != tt.wantDiscount {
+				t.Fatalf("discount = %v, want %d", got.DiscountID, tt.wantDiscount)
+			}
+			if got.DiscountEnds == nil || got.DiscountEnds.Day() != tt.wantDiscount || got.DiscountName == "" {
+				t.Errorf("discount details = %q %v, want those of discount %d", got.DiscountName, got.DiscountEnds, tt.wantDiscount)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type ReadingService struct {
+	listRepo     *repositories.ReadingListRepository
+	progressRepo *repositories.ReadingProgressRepository
+	bookRepo     *repositories.BookRepository
+}
+
+func NewReadingService(listRepo *repositories.ReadingListRepository, progressRepo *repositories.ReadingProgressRepository, bookRepo *repositories.BookRepository) *ReadingService {
+	return &ReadingService{
+		listRepo:     listRepo,
+		progressRepo: progressRepo,
+		bookRepo:     bookRepo,
+	}
+}
+
+// GetLists mengembalikan daftar bacaan milik userID. Pemiliknya melihat semua
+// daftar (rak bawaan dibuat bila belum ada); pengguna lain hanya melihat
+// daftar publik.
+func (s *ReadingService) GetLists(userID, viewerID int) ([]models.ReadingList, error) {
+	owner := userID == viewerID
+	if owner {
+		if err := s.listRepo.EnsureShelves(userID); err != nil {
+			return nil, errors.New("failed to create shelves")
+		}
+	}
+
+	lists, err := s.listRepo.GetByUser(userID, !owner)
+	if err != nil {
+		return nil, errors.New("failed to get reading lists")
+	}
+
+	if !owner {
+		for i := range lists {
+			lists[i].ShareToken = ""
+		}
+	}
+
+	return lists, nil
+}
+
+// GetList mengembalikan daftar bacaan beserta bukunya. Daftar privat hanya
+// dapat dilihat pemiliknya.
+func (s *ReadingService) GetList(id, viewerID int) (*models.ReadingList, error) {
+	list, err := s.getList(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.UserID != viewerID {
+		if list.Visibility != "public" {
+			return nil, ErrReadingListNotFound
+		}
+		list.ShareToken = ""
+	}
+
+	return s.withBooks(list)
+}
+
+// GetSharedList mengembalikan daftar bacaan lewat token tautan berbagi
+func (s *ReadingService) GetSharedList(token string) (*models.ReadingList, error) {
+	list, err := s.listRepo.GetByShareToken(token)
+	if err != nil {
+		return nil, errors.New("failed to get reading list")
+	}
+
+	if list == nil {
+		return nil, ErrReadingListNotFound
+	}
+
+	list.ShareToken = ""
+	return s.withBooks(list)
+}
+
+func (s *ReadingService) CreateList(userID int, req *models.ReadingListRequest) (*models.ReadingList, error) {
+	if err := s.validateListRequest(req); err != nil {
+		return nil, err
+	}
+
+	list := &models.ReadingList{
+		UserID:      userID,
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	}
+
+	if err := s.listRepo.Create(list); err != nil {
+		return nil, errors.New("failed to create reading list")
+	}
+
+	return s.GetList(list.ID, userID)
+}
+
+// UpdateList mengubah daftar bacaan; nama rak bawaan tetap
+func (s *ReadingService) UpdateList(id, userID int, req *models.ReadingListRequest) (*models.ReadingList, error) {
+	if err := s.validateListRequest(req); err != nil {
+		return nil, err
+	}
+
+	list, err := s.getOwnList(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Shelf == "" {
+		list.Name = req.Name
+	}
+	list.Description = req.Description
+	list.Visibility = req.Visibility
+
+	if err := s.listRepo.Update(list); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReadingListNotFound
+		}
+		return nil, errors.New("failed to update reading list")
+	}
+
+	return s.GetList(id, userID)
+}
+
+func (s *ReadingService) DeleteList(id, userID int) error {
+	list, err := s.getOwnList(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if list.Shelf != "" {
+		return ErrCannotDeleteShelf
+	}
+
+	if err := s.listRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrReadingListNotFound
+		}
+		return errors.New("failed to delete reading list")
+	}
+
+	return nil
+}
+
+func (s *ReadingService) AddBook(id, userID int, req *models.AddReadingListBookRequest) (*models.ReadingList, error) {
+	req.Note = strings.TrimSpace(req.Note)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	list, err := s.getOwnList(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureBook(req.BookID); err != nil {
+		return nil, err
+	}
+
+	if err := s.listRepo.AddBook(list, req.BookID, req.Note); err != nil {
+		if err == repositories.ErrBookAlreadyInList {
+			return nil, ErrBookAlreadyInList
+		}
+		return nil, errors.New("failed to add book to reading list")
+	}
+
+	return s.GetList(id, userID)
+}
+
+func (s *ReadingService) RemoveBook(id, bookID, userID int) error {
+	if _, err := s.getOwnList(id, userID); err != nil {
+		return err
+	}
+
+	if err := s.listRepo.RemoveBook(id, bookID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBookNotInList
+		}
+		return errors.New("failed to remove book from reading list")
+	}
+
+	return nil
+}
+
+// ReorderBooks menyusun ulang buku di daftar bacaan sesuai urutan yang dikirim
+func (s *ReadingService) ReorderBooks(id, userID int, req *models.ReorderReadingListRequest) (*models.ReadingList, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	seen := make(map[int]bool, len(req.BookIDs))
+	for _, bookID := range req.BookIDs {
+		if seen[bookID] {
+			return nil, invalid("invalid_order", "Invalid order", "duplicate book ID")
+		}
+		seen[bookID] = true
+	}
+
+	if _, err := s.getOwnList(id, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.listRepo.Reorder(id, req.BookIDs); err != nil {
+		if err == repositories.ErrReorderMismatch {
+			return nil, invalid("invalid_order", "Invalid order", "book_ids must contain every book in the list exactly once")
+		}
+		return nil, errors.New("failed to reorder reading list")
+	}
+
+	return s.GetList(id, userID)
+}
+
+// ShareList membuat tautan berbagi baru; tautan lama tidak berlaku lagi
+func (s *ReadingService) ShareList(id, userID int) (*models.ReadingList, error) {
+	if _, err := s.getOwnList(id, userID); err != nil {
+		return nil, err
+	}
+
+	token, err := randomToken(16)
+	if err != nil {
+		return nil, errors.New("failed to generate share token")
+	}
+
+	if err := s.listRepo.SetShareToken(id, token); err != nil {
+		return nil, errors.New("failed to share reading list")
+	}
+
+	return s.GetList(id, userID)
+}
+
+func (s *ReadingService) UnshareList(id, userID int) error {
+	if _, err := s.getOwnList(id, userID); err != nil {
+		return err
+	}
+
+	if err := s.listRepo.SetShareToken(id, ""); err != nil {
+		return errors.New("failed to unshare reading list")
+	}
+
+	return nil
+}
+
+func (s *ReadingService) GetAllProgress(userID int) ([]models.ReadingProgress, error) {
+	progress, err := s.progressRepo.GetByUser(userID)
+	if err != nil {
+		return nil, errors.New("failed to get reading progress")
+	}
+
+	for i := range progress {
+		setProgressPercent(&progress[i])
+	}
+
+	return progress, nil
+}
+
+func (s *ReadingService) GetProgress(userID, bookID int) (*models.ReadingProgress, error) {
+	if err := s.ensureBook(bookID); err != nil {
+		return nil, err
+	}
+
+	progress, err := s.progressRepo.Get(userID, bookID)
+	if err != nil {
+		return nil, errors.New("failed to get reading progress")
+	}
+
+	if progress == nil {
+		return nil, ErrReadingProgressNotFound
+	}
+
+	setProgressPercent(progress)
+	return progress, nil
+}
+
+// UpdateProgress mencatat halaman terakhir yang dibaca. Tanggal mulai dan
+// selesai diisi otomatis bila tidak dikirim, dan tanggal selesai dihapus saat
+// halaman kembali di bawah halaman terakhir (membaca ulang).
+func (s *ReadingService) UpdateProgress(userID, bookID int, req *models.ReadingProgressRequest) (*models.ReadingProgress, error) {
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+
+	if req.CurrentPage > book.TotalPage {
+		return nil, invalid("invalid_progress", "Invalid reading progress", "current page exceeds total pages")
+	}
+
+	existing, err := s.progressRepo.Get(userID, bookID)
+	if err != nil {
+		return nil, errors.New("failed to get reading progress")
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	progress := &models.ReadingProgress{
+		UserID:      userID,
+		BookID:      bookID,
+		CurrentPage: req.CurrentPage,
+		StartedAt:   optionalDate(req.StartedAt),
+		FinishedAt:  optionalDate(req.FinishedAt),
+	}
+
+	if progress.StartedAt == nil && existing != nil {
+		progress.StartedAt = existing.StartedAt
+	}
+	if progress.StartedAt == nil && req.CurrentPage > 0 {
+		progress.StartedAt = &today
+	}
+
+	finished := book.TotalPage > 0 && req.CurrentPage == book.TotalPage
+	if progress.FinishedAt == nil && finished {
+		if existing != nil && existing.FinishedAt != nil {
+			progress.FinishedAt = existing.FinishedAt
+		} else {
+			progress.FinishedAt = &today
+		}
+	}
+	if progress.FinishedAt != nil && !finished {
+		return nil, invalid("invalid_progress", "Invalid reading progress", "finished_at requires the last page")
+	}
+
+	if progress.StartedAt != nil && progress.FinishedAt != nil && *progress.FinishedAt < *progress.StartedAt {
+		return nil, invalid("invalid_progress", "Invalid reading progress", "finished_at must not be before started_at")
+	}
+
+	if err := s.progressRepo.Save(progress); err != nil {
+		return nil, errors.New("failed to save reading progress")
+	}
+
+	return s.GetProgress(userID, bookID)
+}
+
+func (s *ReadingService) DeleteProgress(userID, bookID int) error {
+	if err := s.progressRepo.Delete(userID, bookID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrReadingProgressNotFound
+		}
+		return errors.New("failed to delete reading progress")
+	}
+
+	return nil
+}
+
+func (s *ReadingService) validateListRequest(req *models.ReadingListRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+	if req.Visibility == "" {
+		req.Visibility = "private"
+	}
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return validationFailed(err)
+	}
+
+	return nil
+}
+
+func (s *ReadingService) withBooks(list *models.ReadingList) (*models.ReadingList, error) {
+	books, err := s.listRepo.GetBooks(list.ID)
+	if err != nil {
+		return nil, errors.New("failed to get reading list books")
+	}
+
+	list.Books = books
+	return list, nil
+}
+
+func (s *ReadingService) getList(id int) (*models.ReadingList, error) {
+	list, err := s.listRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get reading list")
+	}
+
+	if list == nil {
+		return nil, ErrReadingListNotFound
+	}
+
+	return list, nil
+}
+
+// getOwnList mengembalikan daftar bacaan yang boleh diubah userID. Daftar
+// publik milik orang lain menghasilkan "not list owner"; daftar privat
+// milik orang lain diperlakukan seperti tidak ada.
+func (s *ReadingService) getOwnList(id, userID int) (*models.ReadingList, error) {
+	list, err := s.getList(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.UserID != userID {
+		if list.Visibility != "public" {
+			return nil, ErrReadingListNotFound
+		}
+		return nil, ErrNotListOwner
+	}
+
+	return list, nil
+}
+
+func (s *ReadingService) ensureBook(bookID int) error {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return ErrBookNotFound
+	}
+
+	return nil
+}
+
+// setProgressPercent menghitung persentase halaman yang sudah dibaca dengan
+// satu desimal
+func setProgressPercent(progress *models.ReadingProgress) {
+	if progress.TotalPage <= 0 {
+		progress.Percent = 0
+		return
+	}
+
+	percent := float64(progress.CurrentPage) * 100 / float64(progress.TotalPage)
+	progress.Percent = math.Round(percent*10) / 10
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type ReviewService struct {
+	reviewRepo *repositories.ReviewRepository
+	bookRepo   *repositories.BookRepository
+	moderators map[string]bool
+}
+
+func NewReviewService(reviewRepo *repositories.ReviewRepository, bookRepo *repositories.BookRepository, moderators []string) *ReviewService {
+	moderatorSet := make(map[string]bool, len(moderators))
+	for _, username := range moderators {
+		moderatorSet[username] = true
+	}
+
+	return &ReviewService{
+		reviewRepo: reviewRepo,
+		bookRepo:   bookRepo,
+		moderators: moderatorSet,
+	}
+}
+
+// IsModerator bernilai true jika pengguna boleh memoderasi ulasan
+func (s *ReviewService) IsModerator(username string) bool {
+	return s.moderators[username]
+}
+
+// GetReviews mengembalikan daftar ulasan. Selain moderator hanya dapat
+// melihat ulasan yang terlihat.
+func (s *ReviewService) GetReviews(filter models.ReviewFilter, username string) ([]models.Review, error) {
+	if filter.Status != "" && filter.Status != "visible" && filter.Status != "hidden" {
+		return nil, invalid("invalid_status", "Invalid status", "must be visible or hidden")
+	}
+
+	if !s.IsModerator(username) {
+		if filter.Status == "hidden" {
+			return nil, ErrNotModerator
+		}
+		filter.Status = "visible"
+	}
+
+	reviews, err := s.reviewRepo.GetAll(filter)
+	if err != nil {
+		return nil, errors.New("failed to get reviews")
+	}
+
+	return reviews, nil
+}
+
+func (s *ReviewService) GetBookReviews(bookID int, includeHidden bool, username string) ([]models.Review, error) {
+	if err := s.ensureBook(bookID); err != nil {
+		return nil, err
+	}
+
+	filter := models.ReviewFilter{BookID: bookID, Status: "visible"}
+	if includeHidden {
+		filter.Status = ""
+	}
+
+	return s.GetReviews(filter, username)
+}
+
+// GetReviewByID mengembalikan ulasan; ulasan tersembunyi hanya terlihat oleh
+// penulisnya dan moderator
+func (s *ReviewService) GetReviewByID(id, userID int, username string) (*models.Review, error) {
+	review, err := s.getReview(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.Status == "hidden" && review.UserID != userID && !s.IsModerator(username) {
+		return nil, ErrReviewNotFound
+	}
+
+	return review, nil
+}
+
+func (s *ReviewService) CreateReview(bookID, userID int, req *models.ReviewRequest) (*models.Review, error) {
+	if err := s.validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureBook(bookID); err != nil {
+		return nil, err
+	}
+
+	review := &models.Review{
+		BookID: bookID,
+		UserID: userID,
+		Rating: req.Rating,
+		Title:  req.Title,
+		Body:   req.Body,
+	}
+
+	if err := s.reviewRepo.Create(review); err != nil {
+		if err == repositories.ErrDuplicateReview {
+			return nil, ErrReviewExists
+		}
+		return nil, errors.New("failed to create review")
+	}
+
+	return s.getReview(review.ID)
+}
+
+// UpdateReview mengubah ulasan milik pengguna. Status moderasi tidak berubah.
+func (s *ReviewService) UpdateReview(id, userID int, req *models.ReviewRequest) (*models.Review, error) {
+	if err := s.validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	review, err := s.getReview(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.UserID != userID {
+		return nil, ErrNotReviewOwner
+	}
+
+	review.Rating = req.Rating
+	review.Title = req.Title
+	review.Body = req.Body
+
+	if err := s.reviewRepo.Update(review); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReviewNotFound
+		}
+		return nil, errors.New("failed to update review")
+	}
+
+	return s.getReview(id)
+}
+
+// DeleteReview menghapus ulasan; hanya penulisnya atau moderator yang boleh
+func (s *ReviewService) DeleteReview(id, userID int, username string) error {
+	review, err := s.getReview(id)
+	if err != nil {
+		return err
+	}
+
+	if review.UserID != userID && !s.IsModerator(username) {
+		return ErrNotReviewOwner
+	}
+
+	if err := s.reviewRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrReviewNotFound
+		}
+		return errors.New("failed to delete review")
+	}
+
+	return nil
+}
+
+// HideReview menyembunyikan ulasan yang melanggar aturan dari publik dan dari
+// rating buku
+func (s *ReviewService) HideReview(id int, req *models.ModerateReviewRequest, username string) (*models.Review, error) {
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	return s.setStatus(id, "hidden", req.Reason, username)
+}
+
+func (s *ReviewService) UnhideReview(id int, username string) (*models.Review, error) {
+	return s.setStatus(id, "visible", "", username)
+}
+
+func (s *ReviewService) setStatus(id int, status, reason, username string) (*models.Review, error) {
+	if !s.IsModerator(username) {
+		return nil, ErrNotModerator
+	}
+
+	if err := s.reviewRepo.SetStatus(id, status, reason, username); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReviewNotFound
+		}
+		return nil, errors.New("failed to moderate review")
+	}
+
+	return s.getReview(id)
+}
+
+func (s *ReviewService) validateRequest(req *models.ReviewRequest) error {
+	req.Title = strings.TrimSpace(req.Title)
+	req.Body = strings.TrimSpace(req.Body)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return validationFailed(err)
+	}
+
+	return nil
+}
+
+func (s *ReviewService) getReview(id int) (*models.Review, error) {
+	review, err := s.reviewRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get review")
+	}
+
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+
+	return review, nil
+}
+
+func (s *ReviewService) ensureBook(bookID int) error {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return ErrBookNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type SeriesService struct {
+	seriesRepo *repositories.SeriesRepository
+	bookRepo   *repositories.BookRepository
+}
+
+func NewSeriesService(seriesRepo *repositories.SeriesRepository, bookRepo *repositories.BookRepository) *SeriesService {
+	return &SeriesService{
+		seriesRepo: seriesRepo,
+		bookRepo:   bookRepo,
+	}
+}
+
+func (s *SeriesService) GetAllSeries(search string) ([]models.Series, error) {
+	list, err := s.seriesRepo.GetAll(strings.TrimSpace(search))
+	if err != nil {
+		return nil, errors.New("failed to get series")
+	}
+
+	return list, nil
+}
+
+// GetSeries mengembalikan seri beserta volumenya sesuai urutan
+func (s *SeriesService) GetSeries(id int) (*models.Series, error) {
+	series, err := s.getSeries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := s.seriesRepo.GetVolumes(id)
+	if err != nil {
+		return nil, errors.New("failed to get series volumes")
+	}
+
+	series.Volumes = volumes
+	return series, nil
+}
+
+func (s *SeriesService) CreateSeries(req *models.SeriesRequest, username string) (*models.Series, error) {
+	if err := s.validateSeriesRequest(req); err != nil {
+		return nil, err
+	}
+
+	series := &models.Series{
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedBy:   username,
+		ModifiedBy:  username,
+	}
+
+	if err := s.seriesRepo.Create(series); err != nil {
+		if err == repositories.ErrDuplicateSeriesName {
+			return nil, ErrSeriesNameExists
+		}
+		return nil, errors.New("failed to create series")
+	}
+
+	return s.GetSeries(series.ID)
+}
+
+func (s *SeriesService) UpdateSeries(id int, req *models.SeriesRequest, username string) (*models.Series, error) {
+	if err := s.validateSeriesRequest(req); err != nil {
+		return nil, err
+	}
+
+	series, err := s.getSeries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	series.Name = req.Name
+	series.Description = req.Description
+	series.ModifiedBy = username
+
+	if err := s.seriesRepo.Update(series); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrSeriesNotFound
+		case repositories.ErrDuplicateSeriesName:
+			return nil, ErrSeriesNameExists
+		}
+		return nil, errors.New("failed to update series")
+	}
+
+	return s.GetSeries(id)
+}
+
+func (s *SeriesService) DeleteSeries(id int) error {
+	if err := s.seriesRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrSeriesNotFound
+		}
+		return errors.New("failed to delete series")
+	}
+
+	return nil
+}
+
+func (s *SeriesService) AddBook(seriesID int, req *models.SeriesBookRequest, username string) (*models.Series, error) {
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if err := validateVolumeNumber(req.VolumeNumber); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getSeries(seriesID); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureBook(req.BookID); err != nil {
+		return nil, err
+	}
+
+	if err := s.seriesRepo.AddBook(seriesID, req.BookID, req.VolumeNumber, username); err != nil {
+		return nil, seriesBookError(err, "failed to add book to series")
+	}
+
+	return s.GetSeries(seriesID)
+}
+
+func (s *SeriesService) UpdateVolume(seriesID, bookID int, req *models.SeriesVolumeRequest) (*models.Series, error) {
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if err := validateVolumeNumber(req.VolumeNumber); err != nil {
+		return nil, err
+	}
+
+	if err := s.seriesRepo.UpdateVolume(seriesID, bookID, req.VolumeNumber); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBookNotInSeries
+		}
+		return nil, seriesBookError(err, "failed to update volume number")
+	}
+
+	return s.GetSeries(seriesID)
+}
+
+func (s *SeriesService) RemoveBook(seriesID, bookID int) error {
+	if err := s.seriesRepo.RemoveBook(seriesID, bookID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBookNotInSeries
+		}
+		return errors.New("failed to remove book from series")
+	}
+
+	return nil
+}
+
+// GetBookSeries mengembalikan seri yang memuat buku beserta volume sebelum
+// dan sesudahnya untuk navigasi
+func (s *SeriesService) GetBookSeries(bookID int) ([]models.BookSeriesEntry, error) {
+	if err := s.ensureBook(bookID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.seriesRepo.GetBookSeries(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book series")
+	}
+
+	return entries, nil
+}
+
+func (s *SeriesService) validateSeriesRequest(req *models.SeriesRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return validationFailed(err)
+	}
+
+	return nil
+}
+
+func (s *SeriesService) getSeries(id int) (*models.Series, error) {
+	series, err := s.seriesRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get series")
+	}
+
+	if series == nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	return series, nil
+}
+
+func (s *SeriesService) ensureBook(bookID int) error {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return ErrBookNotFound
+	}
+
+	return nil
+}
+
+// validateVolumeNumber menolak nomor volume dengan lebih dari dua desimal
+// karena kolom volume_number hanya menyimpan dua desimal
+func validateVolumeNumber(volume float64) error {
+	if math.Abs(volume*100-math.Round(volume*100)) > 1e-6 {
+		return invalid("invalid_volume_number", "Invalid volume number", "at most two decimal places are allowed")
+	}
+
+	return nil
+}
+
+func seriesBookError(err error, fallback string) error {
+	switch err {
+	case repositories.ErrBookAlreadyInSeries:
+		return ErrBookAlreadyInSeries
+	case repositories.ErrDuplicateVolume:
+		return ErrVolumeNumberUsed
+	}
+
+	return errors.New(fallback)
+}
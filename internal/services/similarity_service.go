@@ -0,0 +1,330 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+)
+
+// Bobot setiap sinyal dalam skor kemiripan. Bila kedua buku belum memiliki
+// aktivitas pembaca, skor dihitung dari tiga sinyal lainnya saja.
+const (
+	categoryWeight = 0.35
+	textWeight     = 0.30
+	yearWeight     = 0.10
+	activityWeight = 0.25
+
+	// similarYearWindow adalah selisih tahun terbit yang membuat skor tahun 0
+	similarYearWindow = 20
+
+	// maxSimilarityTokenBooks membatasi kata yang terlalu umum agar tidak
+	// menjadikan hampir semua buku kandidat satu sama lain; kata tersebut
+	// tetap dihitung dalam skor teks
+	maxSimilarityTokenBooks = 500
+
+	// maxSimilarityCategoryBooks adalah jumlah kandidat yang diambil dari
+	// satu kategori; pada kategori yang lebih besar hanya buku dengan tahun
+	// terbit terdekat yang menjadi kandidat
+	maxSimilarityCategoryBooks = 500
+)
+
+// similarityStopwords adalah kata umum (Indonesia dan Inggris) yang tidak
+// dipakai untuk kemiripan teks
+var similarityStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "this": true, "that": true,
+	"are": true, "was": true, "into": true, "about": true, "book": true, "your": true,
+	"dan": true, "yang": true, "untuk": true, "dengan": true, "dari": true, "dalam": true,
+	"ini": true, "itu": true, "pada": true, "adalah": true, "atau": true, "akan": true,
+	"juga": true, "tidak": true, "buku": true, "oleh": true, "para": true, "kita": true,
+}
+
+type SimilarityService struct {
+	similarityRepo *repositories.SimilarityRepository
+	bookRepo       *repositories.BookRepository
+	perBook        int
+}
+
+func NewSimilarityService(similarityRepo *repositories.SimilarityRepository, bookRepo *repositories.BookRepository, perBook int) *SimilarityService {
+	return &SimilarityService{
+		similarityRepo: similarityRepo,
+		bookRepo:       bookRepo,
+		perBook:        perBook,
+	}
+}
+
+// GetSimilarBooks mengembalikan buku yang paling mirip dari hasil perhitungan
+// terakhir; limit 0 berarti semua yang tersimpan
+func (s *SimilarityService) GetSimilarBooks(bookID, limit int) ([]models.SimilarBook, error) {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+
+	if limit <= 0 || limit > s.perBook {
+		limit = s.perBook
+	}
+
+	books, err := s.similarityRepo.GetSimilar(bookID, limit)
+	if err != nil {
+		return nil, errors.New("failed to get similar books")
+	}
+
+	return books, nil
+}
+
+// RefreshSimilarBooks menghitung ulang buku yang mirip untuk semua buku;
+// dipanggil berkala oleh background job
+func (s *SimilarityService) RefreshSimilarBooks() error {
+	inputs, err := s.similarityRepo.GetInputs()
+	if err != nil {
+		return err
+	}
+
+	readers, shared, err := s.similarityRepo.GetReaderCounts()
+	if err != nil {
+		return err
+	}
+
+	scores := computeSimilarities(inputs, readers, shared, s.perBook)
+	if err := s.similarityRepo.Replace(scores, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	log.Printf("Computed similar books for %d books (%d pairs)", len(inputs), len(scores))
+	return nil
+}
+
+// computeSimilarities mengembalikan paling banyak perBook buku termirip untuk
+// setiap buku. Hanya pasangan yang berbagi kategori, kata atau pembaca yang
+// dibandingkan; kedekatan tahun terbit saja tidak cukup, kata yang muncul di
+// lebih dari maxSimilarityTokenBooks buku tidak menjadikan buku kandidat, dan
+// setiap kategori menyumbang paling banyak maxSimilarityCategoryBooks kandidat.
+func computeSimilarities(inputs []models.SimilarityInput, readers map[int]int, shared map[[2]int]int, perBook int) []models.SimilarityScore {
+	vectors := textVectors(inputs)
+
+	categorySets := make([]map[int]bool, len(inputs))
+	byCategory := make(map[int][]int)
+	byToken := make(map[string][]int)
+	index := make(map[int]int, len(inputs))
+	for i, input := range inputs {
+		index[input.BookID] = i
+		categorySets[i] = make(map[int]bool, len(input.CategoryIDs))
+		for _, id := range input.CategoryIDs {
+			categorySets[i][id] = true
+			byCategory[id] = append(byCategory[id], i)
+		}
+		for token := range vectors[i] {
+			byToken[token] = append(byToken[token], i)
+		}
+	}
+
+	// Category members ordered by release year, so a large category only
+	// contributes the books published closest to each book
+	categoryPosition := make(map[[2]int]int)
+	for id, members := range byCategory {
+		sort.Slice(members, func(a, b int) bool {
+			if inputs[members[a]].ReleaseYear != inputs[members[b]].ReleaseYear {
+				return inputs[members[a]].ReleaseYear < inputs[members[b]].ReleaseYear
+			}
+			return inputs[members[a]].BookID < inputs[members[b]].BookID
+		})
+		if len(members) > maxSimilarityCategoryBooks {
+			for position, i := range members {
+				categoryPosition[[2]int{id, i}] = position
+			}
+		}
+	}
+
+	coReaders := make(map[int][]int)
+	for pair := range shared {
+		first, ok1 := index[pair[0]]
+		second, ok2 := index[pair[1]]
+		if ok1 && ok2 {
+			coReaders[first] = append(coReaders[first], second)
+			coReaders[second] = append(coReaders[second], first)
+		}
+	}
+
+	var result []models.SimilarityScore
+	for i, input := range inputs {
+		candidates := make(map[int]bool)
+		for id := range categorySets[i] {
+			for _, j := range categoryWindow(byCategory[id], categoryPosition[[2]int{id, i}]) {
+				candidates[j] = true
+			}
+		}
+		for token := range vectors[i] {
+			if len(byToken[token]) > maxSimilarityTokenBooks {
+				continue
+			}
+			for _, j := range byToken[token] {
+				candidates[j] = true
+			}
+		}
+		for _, j := range coReaders[i] {
+			candidates[j] = true
+		}
+		delete(candidates, i)
+
+		var scores []models.SimilarityScore
+		for j := range candidates {
+			other := inputs[j]
+			score := models.SimilarityScore{
+				BookID:        input.BookID,
+				SimilarBookID: other.BookID,
+				CategoryScore: jaccard(categorySets[i], categorySets[j]),
+				TextScore:     cosine(vectors[i], vectors[j]),
+				YearScore:     yearProximity(input.ReleaseYear, other.ReleaseYear),
+			}
+
+			weighted := categoryWeight*score.CategoryScore + textWeight*score.TextScore + yearWeight*score.YearScore
+			total := categoryWeight + textWeight + yearWeight
+
+			if readers[input.BookID] > 0 && readers[other.BookID] > 0 {
+				pair := [2]int{input.BookID, other.BookID}
+				if pair[0] > pair[1] {
+					pair[0], pair[1] = pair[1], pair[0]
+				}
+				activity := roundScore(float64(shared[pair]) / math.Sqrt(float64(readers[input.BookID]*readers[other.BookID])))
+				score.ActivityScore = &activity
+				weighted += activityWeight * activity
+				total += activityWeight
+			}
+
+			if score.CategoryScore == 0 && score.TextScore == 0 && (score.ActivityScore == nil || *score.ActivityScore == 0) {
+				continue
+			}
+
+			score.Score = roundScore(weighted / total)
+			scores = append(scores, score)
+		}
+
+		sort.Slice(scores, func(a, b int) bool {
+			if scores[a].Score != scores[b].Score {
+				return scores[a].Score > scores[b].Score
+			}
+			return scores[a].SimilarBookID < scores[b].SimilarBookID
+		})
+		if len(scores) > perBook {
+			scores = scores[:perBook]
+		}
+
+		result = append(result, scores...)
+	}
+
+	return result
+}
+
+// categoryWindow mengembalikan paling banyak maxSimilarityCategoryBooks
+// anggota kategori di sekitar posisi position
+func categoryWindow(members []int, position int) []int {
+	if len(members) <= maxSimilarityCategoryBooks {
+		return members
+	}
+
+	start := min(max(position-maxSimilarityCategoryBooks/2, 0), len(members)-maxSimilarityCategoryBooks)
+	return members[start : start+maxSimilarityCategoryBooks]
+}
+
+// textVectors membuat vektor TF-IDF ternormalisasi dari judul (bobot ganda),
+// deskripsi dan tag setiap buku
+func textVectors(inputs []models.SimilarityInput) []map[string]float64 {
+	counts := make([]map[string]float64, len(inputs))
+	documentFrequency := make(map[string]int)
+	for i, input := range inputs {
+		counts[i] = make(map[string]float64)
+		for _, token := range tokenize(input.Title) {
+			counts[i][token] += 2
+		}
+		for _, token := range tokenize(input.Description) {
+			counts[i][token]++
+		}
+		for _, tag := range input.Tags {
+			counts[i]["#"+strings.ToLower(tag)] += 2
+		}
+		for token := range counts[i] {
+			documentFrequency[token]++
+		}
+	}
+
+	total := float64(len(inputs))
+	for i := range counts {
+		var norm float64
+		for token, count := range counts[i] {
+			weight := count * (math.Log((1+total)/(1+float64(documentFrequency[token]))) + 1)
+			counts[i][token] = weight
+			norm += weight * weight
+		}
+
+		norm = math.Sqrt(norm)
+		for token := range counts[i] {
+			counts[i][token] /= norm
+		}
+	}
+
+	return counts
+}
+
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) >= 3 && !similarityStopwords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+
+	return tokens
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	var dot float64
+	for token, weight := range a {
+		dot += weight * b[token]
+	}
+
+	return roundScore(math.Min(dot, 1))
+}
+
+func jaccard(a, b map[int]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for id := range a {
+		if b[id] {
+			shared++
+		}
+	}
+
+	return roundScore(float64(shared) / float64(len(a)+len(b)-shared))
+}
+
+func yearProximity(a, b int) float64 {
+	diff := math.Abs(float64(a - b))
+	return roundScore(math.Max(0, 1-diff/similarYearWindow))
+}
+
+// roundScore membulatkan skor ke empat desimal sesuai kolom NUMERIC(5, 4)
+func roundScore(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
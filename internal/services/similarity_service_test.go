@@ -0,0 +1,153 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"book-management/internal/models"
+)
+
+// similarIDs mengembalikan buku mirip milik bookID sesuai urutan hasil
+func similarIDs(scores []models.SimilarityScore, bookID int) []int {
+	var ids []int
+	for _, score := range scores {
+		if score.BookID == bookID {
+			ids = append(ids, score.SimilarBookID)
+		}
+	}
+	return ids
+}
+
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestComputeSimilaritiesRanking(t *testing.T) {
+	inputs := []models.SimilarityInput{
+		{BookID: 1, Title: "Sejarah Nusantara Kuno", ReleaseYear: 2000, CategoryIDs: []int{1, 2}, Tags: []string{"Sejarah"}},
+		{BookID: 2, Title: "Sejarah Nusantara Modern", ReleaseYear: 2004, CategoryIDs: []int{1, 2}, Tags: []string{"sejarah"}},
+		{BookID: 3, Title: "Resep Masakan Padang", ReleaseYear: 1960, CategoryIDs: []int{1}},
+		{BookID: 4, Title: "Fisika Kuantum", ReleaseYear: 2000, CategoryIDs: []int{9}},
+		{BookID: 5, Title: "Kumpulan Puisi", ReleaseYear: 2001, CategoryIDs: []int{8}},
+	}
+	readers := map[int]int{4: 10, 5: 10}
+	shared := map[[2]int]int{{4, 5}: 5}
+
+	scores := computeSimilarities(inputs, readers, shared, 10)
+
+	if got, want := similarIDs(scores, 1), []int{2, 3}; !equalIDs(got, want) {
+		t.Errorf("similar books of 1 = %v, want %v", got, want)
+	}
+	if got, want := similarIDs(scores, 3), []int{1, 2}; !equalIDs(got, want) {
+		t.Errorf("similar books of 3 = %v, want %v", got, want)
+	}
+
+	// Books 4 and 5 only share readers
+	if got, want := similarIDs(scores, 4), []int{5}; !equalIDs(got, want) {
+		t.Fatalf("similar books of 4 = %v, want %v", got, want)
+	}
+	for _, score := range scores {
+		switch {
+		case score.BookID == 4:
+			if score.ActivityScore == nil || *score.ActivityScore != 0.5 {
+				t.Errorf("activity score of 4 → 5 = %v, want 0.5", score.ActivityScore)
+			}
+		case score.BookID == 1 && score.SimilarBookID == 2:
+			if score.CategoryScore != 1 || score.TextScore <= 0 || score.YearScore != 0.8 || score.ActivityScore != nil {
+				t.Errorf("score of 1 → 2 = %+v", score)
+			}
+		}
+		if score.Score < 0 || score.Score > 1 {
+			t.Errorf("score of %d → %d = %v, want within [0, 1]", score.BookID, score.SimilarBookID, score.Score)
+		}
+	}
+}
+
+func TestComputeSimilaritiesPerBook(t *testing.T) {
+	var inputs []models.SimilarityInput
+	for id := 1; id <= 6; id++ {
+		inputs = append(inputs, models.SimilarityInput{BookID: id, Title: "Ensiklopedia", ReleaseYear: 2000, CategoryIDs: []int{1}})
+	}
+
+	scores := computeSimilarities(inputs, nil, nil, 2)
+
+	if len(scores) != 12 {
+		t.Errorf("got %d scores, want 12", len(scores))
+	}
+	// Identical books tie on score and are ordered by ID
+	if got, want := similarIDs(scores, 1), []int{2, 3}; !equalIDs(got, want) {
+		t.Errorf("similar books of 1 = %v, want %v", got, want)
+	}
+	if got, want := similarIDs(scores, 6), []int{1, 2}; !equalIDs(got, want) {
+		t.Errorf("similar books of 6 = %v, want %v", got, want)
+	}
+}
+
+func TestComputeSimilaritiesCommonTokens(t *testing.T) {
+	books := func(n int) []models.SimilarityInput {
+		inputs := make([]models.SimilarityInput, n)
+		for i := range inputs {
+			inputs[i] = models.SimilarityInput{BookID: i + 1, Title: fmt.Sprintf("Novel %d", i), ReleaseYear: 2000}
+		}
+		return inputs
+	}
+
+	if scores := computeSimilarities(books(maxSimilarityTokenBooks), nil, nil, 1); len(scores) != maxSimilarityTokenBooks {
+		t.Errorf("got %d scores for a shared token within the limit, want %d", len(scores), maxSimilarityTokenBooks)
+	}
+	if scores := computeSimilarities(books(maxSimilarityTokenBooks+1), nil, nil, 1); len(scores) != 0 {
+		t.Errorf("got %d scores for a token shared by too many books, want 0", len(scores))
+	}
+}
+
+func TestComputeSimilaritiesLargeCategory(t *testing.T) {
+	n := 2 * maxSimilarityCategoryBooks
+	inputs := make([]models.SimilarityInput, n)
+	for i := range inputs {
+		// Reverse the years so that book order and year order differ
+		inputs[i] = models.SimilarityInput{BookID: i + 1, ReleaseYear: 3000 - i, CategoryIDs: []int{1}}
+	}
+
+	scores := computeSimilarities(inputs, nil, nil, n)
+
+	counts := make(map[int]int)
+	for _, score := range scores {
+		counts[score.BookID]++
+	}
+	for _, input := range inputs {
+		if counts[input.BookID] != maxSimilarityCategoryBooks-1 {
+			t.Fatalf("book %d has %d candidates, want %d", input.BookID, counts[input.BookID], maxSimilarityCategoryBooks-1)
+		}
+	}
+
+	// The middle book gets the books published closest to it, nearest first
+	middle := n / 2
+	got := similarIDs(scores, middle)
+	if got[0] != middle-1 || got[1] != middle+1 {
+		t.Errorf("closest books of %d = %v, want %d and %d first", middle, got[:2], middle-1, middle+1)
+	}
+	for _, id := range got {
+		if diff := id - middle; diff < -maxSimilarityCategoryBooks/2 || diff > maxSimilarityCategoryBooks/2 {
+			t.Errorf("book %d is outside the year window of book %d", id, middle)
+		}
+	}
+}
+
+func TestComputeSimilaritiesYearAlone(t *testing.T) {
+	inputs := []models.SimilarityInput{
+		{BookID: 1, Title: "Laut Bercerita", ReleaseYear: 2017},
+		{BookID: 2, Title: "Pulang", ReleaseYear: 2017},
+	}
+
+	if scores := computeSimilarities(inputs, nil, nil, 5); len(scores) != 0 {
+		t.Errorf("got %d scores, want none for books that only share a year", len(scores))
+	}
+}
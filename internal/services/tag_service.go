@@ -0,0 +1,184 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type TagService struct {
+	tagRepo *repositories.TagRepository
+}
+
+func NewTagService(tagRepo *repositories.TagRepository) *TagService {
+	return &TagService{
+		tagRepo: tagRepo,
+	}
+}
+
+func (s *TagService) GetAllTags() ([]models.Tag, error) {
+	tags, err := s.tagRepo.GetAll()
+	if err != nil {
+		return nil, errors.New("failed to get tags")
+	}
+
+	return tags, nil
+}
+
+func (s *TagService) GetTagByID(id int) (*models.Tag, error) {
+	tag, err := s.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get tag")
+	}
+
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+
+	return tag, nil
+}
+
+func (s *TagService) CreateTag(req *models.CreateTagRequest, username string) (*models.Tag, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	// Tag names are unique regardless of case
+	existingTag, err := s.tagRepo.GetByName(req.Name)
+	if err != nil {
+		return nil, errors.New("failed to validate tag")
+	}
+
+	if existingTag != nil {
+		return nil, ErrTagExists
+	}
+
+	tag := &models.Tag{
+		Name:       req.Name,
+		CreatedBy:  username,
+		ModifiedBy: username,
+	}
+
+	err = s.tagRepo.Create(tag)
+	if err != nil {
+		return nil, errors.New("failed to create tag")
+	}
+
+	return tag, nil
+}
+
+func (s *TagService) UpdateTag(id int, req *models.UpdateTagRequest, username string) (*models.Tag, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	// Check if tag exists
+	existingTag, err := s.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get tag")
+	}
+
+	if existingTag == nil {
+		return nil, ErrTagNotFound
+	}
+
+	// Renaming must not collide with another tag
+	sameName, err := s.tagRepo.GetByName(req.Name)
+	if err != nil {
+		return nil, errors.New("failed to validate tag")
+	}
+
+	if sameName != nil && sameName.ID != id {
+		return nil, ErrTagExists
+	}
+
+	// Update tag
+	existingTag.Name = req.Name
+	existingTag.ModifiedBy = username
+
+	err = s.tagRepo.Update(existingTag)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTagNotFound
+		}
+		return nil, errors.New("failed to update tag")
+	}
+
+	return existingTag, nil
+}
+
+func (s *TagService) DeleteTag(id int) error {
+	err := s.tagRepo.Delete(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrTagNotFound
+		}
+		return errors.New("failed to delete tag")
+	}
+
+	return nil
+}
+
+func (s *TagService) GetTagCloud(limit int) ([]models.TagCloudEntry, error) {
+	entries, err := s.tagRepo.GetCloud(limit)
+	if err != nil {
+		return nil, errors.New("failed to get tag cloud")
+	}
+
+	if len(entries) == 0 {
+		return []models.TagCloudEntry{}, nil
+	}
+
+	// Scale counts linearly into weights 1..5
+	minCount, maxCount := entries[0].Count, entries[0].Count
+	for _, entry := range entries {
+		if entry.Count < minCount {
+			minCount = entry.Count
+		}
+		if entry.Count > maxCount {
+			maxCount = entry.Count
+		}
+	}
+
+	for i := range entries {
+		if maxCount == minCount {
+			entries[i].Weight = 3
+			continue
+		}
+		entries[i].Weight = 1 + (entries[i].Count-minCount)*4/(maxCount-minCount)
+	}
+
+	return entries, nil
+}
+
+// normalizeTags membuang spasi, tag kosong dan duplikat (tanpa membedakan
+// huruf besar dan kecil) dengan tetap mempertahankan urutan
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	result := []string{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
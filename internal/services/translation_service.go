@@ -0,0 +1,276 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"book-management/internal/i18n"
+	"book-management/internal/locale"
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+// TranslationService mengelola terjemahan judul, deskripsi dan nama kategori
+// serta menerapkannya pada data yang ditampilkan. Konten yang tersimpan di
+// buku dan kategori dianggap berbahasa defaultLocale.
+type TranslationService struct {
+	translationRepo *repositories.TranslationRepository
+	bookRepo        *repositories.BookRepository
+	categoryRepo    *repositories.CategoryRepository
+	defaultLocale   string
+	supported       []string
+}
+
+func NewTranslationService(translationRepo *repositories.TranslationRepository, bookRepo *repositories.BookRepository, categoryRepo *repositories.CategoryRepository, defaultLocale string, supported []string) *TranslationService {
+	return &TranslationService{
+		translationRepo: translationRepo,
+		bookRepo:        bookRepo,
+		categoryRepo:    categoryRepo,
+		defaultLocale:   defaultLocale,
+		supported:       supported,
+	}
+}
+
+func (s *TranslationService) GetBookTranslations(bookID int) ([]models.BookTranslation, error) {
+	if err := s.ensureBook(bookID); err != nil {
+		return nil, err
+	}
+
+	translations, err := s.translationRepo.GetBookTranslations(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book translations")
+	}
+
+	return translations, nil
+}
+
+// SaveBookTranslation menambah atau mengganti terjemahan buku dalam bahasa code
+func (s *TranslationService) SaveBookTranslation(bookID int, code string, req *models.BookTranslationRequest, username string) (*models.BookTranslation, error) {
+	code, err := s.translationLocale(code)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Title = strings.TrimSpace(req.Title)
+	req.Description = strings.TrimSpace(req.Description)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if err := s.ensureBook(bookID); err != nil {
+		return nil, err
+	}
+
+	translation := &models.BookTranslation{
+		BookID:      bookID,
+		Locale:      code,
+		Title:       req.Title,
+		Description: req.Description,
+		ModifiedBy:  username,
+	}
+
+	if err := s.translationRepo.SaveBookTranslation(translation); err != nil {
+		return nil, errors.New("failed to save book translation")
+	}
+
+	return translation, nil
+}
+
+func (s *TranslationService) DeleteBookTranslation(bookID int, code string) error {
+	if err := s.translationRepo.DeleteBookTranslation(bookID, locale.Normalize(code)); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrTranslationNotFound
+		}
+		return errors.New("failed to delete book translation")
+	}
+
+	return nil
+}
+
+func (s *TranslationService) GetCategoryTranslations(categoryID int) ([]models.CategoryTranslation, error) {
+	if err := s.ensureCategory(categoryID); err != nil {
+		return nil, err
+	}
+
+	translations, err := s.translationRepo.GetCategoryTranslations(categoryID)
+	if err != nil {
+		return nil, errors.New("failed to get category translations")
+	}
+
+	return translations, nil
+}
+
+// SaveCategoryTranslation menambah atau mengganti nama kategori dalam bahasa code
+func (s *TranslationService) SaveCategoryTranslation(categoryID int, code string, req *models.CategoryTranslationRequest, username string) (*models.CategoryTranslation, error) {
+	code, err := s.translationLocale(code)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if err := s.ensureCategory(categoryID); err != nil {
+		return nil, err
+	}
+
+	translation := &models.CategoryTranslation{
+		CategoryID: categoryID,
+		Locale:     code,
+		Name:       req.Name,
+		ModifiedBy: username,
+	}
+
+	if err := s.translationRepo.SaveCategoryTranslation(translation); err != nil {
+		return nil, errors.New("failed to save category translation")
+	}
+
+	return translation, nil
+}
+
+func (s *TranslationService) DeleteCategoryTranslation(categoryID int, code string) error {
+	if err := s.translationRepo.DeleteCategoryTranslation(categoryID, locale.Normalize(code)); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrTranslationNotFound
+		}
+		return errors.New("failed to delete category translation")
+	}
+
+	return nil
+}
+
+// TranslateBooks mengganti judul, deskripsi dan nama kategori buku dengan
+// terjemahan bahasa code dan mengisi label ketebalannya. Tiap field jatuh ke
+// bahasa default bila terjemahannya tidak ada; Locale buku menunjukkan
+// bahasa judul yang tampil.
+func (s *TranslationService) TranslateBooks(books []models.BookWithCategory, code string) error {
+	for i := range books {
+		books[i].Locale = s.defaultLocale
+		books[i].ThicknessLabel = i18n.ThicknessLabel(code, books[i].Thickness)
+	}
+
+	if code == "" || code == s.defaultLocale || len(books) == 0 {
+		return nil
+	}
+
+	bookIDs := make([]int, len(books))
+	for i := range books {
+		bookIDs[i] = books[i].ID
+	}
+
+	translations, err := s.translationRepo.GetBookTranslationsByLocale(bookIDs, code)
+	if err != nil {
+		return errors.New("failed to get book translations")
+	}
+
+	names, err := s.translationRepo.GetCategoryNames(code)
+	if err != nil {
+		return errors.New("failed to get category translations")
+	}
+
+	for i := range books {
+		book := &books[i]
+		if translation, ok := translations[book.ID]; ok {
+			book.Title = translation.Title
+			if translation.Description != "" {
+				book.Description = translation.Description
+			}
+			book.Locale = code
+		}
+
+		if name, ok := names[book.CategoryID]; ok {
+			book.CategoryName = name
+		}
+		for j := range book.Categories {
+			if name, ok := names[book.Categories[j].ID]; ok {
+				book.Categories[j].Name = name
+			}
+		}
+	}
+
+	return nil
+}
+
+// TranslateCategories mengganti nama kategori beserta breadcrumb-nya dengan
+// terjemahan bahasa code, jatuh ke nama bahasa default bila tidak ada
+func (s *TranslationService) TranslateCategories(categories []models.Category, code string) error {
+	for i := range categories {
+		categories[i].Locale = s.defaultLocale
+	}
+
+	if code == "" || code == s.defaultLocale || len(categories) == 0 {
+		return nil
+	}
+
+	names, err := s.translationRepo.GetCategoryNames(code)
+	if err != nil {
+		return errors.New("failed to get category translations")
+	}
+
+	for i := range categories {
+		category := &categories[i]
+		if name, ok := names[category.ID]; ok {
+			category.Name = name
+			category.Locale = code
+		}
+		for j := range category.Path {
+			if name, ok := names[category.Path[j].ID]; ok {
+				category.Path[j].Name = name
+			}
+		}
+	}
+
+	return nil
+}
+
+// translationLocale memastikan code adalah bahasa terjemahan yang didukung.
+// Konten bahasa default diubah langsung pada buku atau kategorinya.
+func (s *TranslationService) translationLocale(code string) (string, error) {
+	normalized := locale.Normalize(code)
+	if normalized == s.defaultLocale {
+		return "", invalid("invalid_locale", "Invalid locale", fmt.Sprintf("%s is the default locale, edit the book or category itself", normalized))
+	}
+
+	for _, supported := range s.supported {
+		if supported == normalized {
+			return normalized, nil
+		}
+	}
+
+	return "", invalid("invalid_locale", "Invalid locale", fmt.Sprintf("%q is not supported (supported: %s)", code, strings.Join(s.supported, ", ")))
+}
+
+func (s *TranslationService) ensureBook(bookID int) error {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return ErrBookNotFound
+	}
+
+	return nil
+}
+
+func (s *TranslationService) ensureCategory(categoryID int) error {
+	category, err := s.categoryRepo.GetByID(categoryID)
+	if err != nil {
+		return errors.New("failed to get category")
+	}
+
+	if category == nil {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"book-management/internal/models"
+	"book-management/internal/repositories"
+	"book-management/internal/utils"
+)
+
+type WorkService struct {
+	workRepo *repositories.WorkRepository
+	bookRepo *repositories.BookRepository
+}
+
+func NewWorkService(workRepo *repositories.WorkRepository, bookRepo *repositories.BookRepository) *WorkService {
+	return &WorkService{
+		workRepo: workRepo,
+		bookRepo: bookRepo,
+	}
+}
+
+func (s *WorkService) GetWorks(search string) ([]models.Work, error) {
+	works, err := s.workRepo.GetAll(strings.TrimSpace(search))
+	if err != nil {
+		return nil, errors.New("failed to get works")
+	}
+
+	return works, nil
+}
+
+// GetWork mengembalikan karya beserta seluruh edisinya
+func (s *WorkService) GetWork(id int) (*models.Work, error) {
+	work, err := s.getWork(id)
+	if err != nil {
+		return nil, err
+	}
+
+	editions, err := s.workRepo.GetEditions(id)
+	if err != nil {
+		return nil, errors.New("failed to get work editions")
+	}
+
+	work.Editions = editions
+	return work, nil
+}
+
+func (s *WorkService) CreateWork(req *models.WorkRequest, username string) (*models.Work, error) {
+	if err := s.validateWorkRequest(req); err != nil {
+		return nil, err
+	}
+
+	work := &models.Work{
+		Title:       req.Title,
+		Description: req.Description,
+		CreatedBy:   username,
+		ModifiedBy:  username,
+	}
+
+	if err := s.workRepo.Create(work); err != nil {
+		return nil, errors.New("failed to create work")
+	}
+
+	return s.GetWork(work.ID)
+}
+
+func (s *WorkService) UpdateWork(id int, req *models.WorkRequest, username string) (*models.Work, error) {
+	if err := s.validateWorkRequest(req); err != nil {
+		return nil, err
+	}
+
+	work, err := s.getWork(id)
+	if err != nil {
+		return nil, err
+	}
+
+	work.Title = req.Title
+	work.Description = req.Description
+	work.ModifiedBy = username
+
+	if err := s.workRepo.Update(work); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrWorkNotFound
+		}
+		return nil, errors.New("failed to update work")
+	}
+
+	return s.GetWork(id)
+}
+
+// DeleteWork menghapus karya; edisinya tetap ada tanpa karya
+func (s *WorkService) DeleteWork(id int) error {
+	if err := s.workRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkNotFound
+		}
+		return errors.New("failed to delete work")
+	}
+
+	return nil
+}
+
+// AddEdition menjadikan buku sebagai edisi karya. Buku yang sudah menjadi
+// edisi karya lain dipindahkan ke karya ini.
+func (s *WorkService) AddEdition(workID int, req *models.WorkBookRequest, username string) (*models.Work, error) {
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if _, err := s.getWork(workID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getBook(req.BookID); err != nil {
+		return nil, err
+	}
+
+	if err := s.workRepo.SetBookWork(req.BookID, &workID, username); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBookNotFound
+		}
+		return nil, errors.New("failed to add edition")
+	}
+
+	return s.GetWork(workID)
+}
+
+func (s *WorkService) RemoveEdition(workID, bookID int, username string) error {
+	book, err := s.getBook(bookID)
+	if err != nil {
+		return err
+	}
+
+	if book.Work == nil || book.Work.ID != workID {
+		return ErrNotAnEdition
+	}
+
+	if err := s.workRepo.SetBookWork(bookID, nil, username); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBookNotFound
+		}
+		return errors.New("failed to remove edition")
+	}
+
+	return nil
+}
+
+// GetBookEditions mengembalikan edisi lain dari karya buku bookID. Buku
+// tanpa karya tidak memiliki edisi lain.
+func (s *WorkService) GetBookEditions(bookID int) ([]models.WorkEdition, error) {
+	book, err := s.getBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	editions := []models.WorkEdition{}
+	if book.Work == nil {
+		return editions, nil
+	}
+
+	all, err := s.workRepo.GetEditions(book.Work.ID)
+	if err != nil {
+		return nil, errors.New("failed to get work editions")
+	}
+
+	for _, edition := range all {
+		if edition.BookID != bookID {
+			editions = append(editions, edition)
+		}
+	}
+
+	return editions, nil
+}
+
+func (s *WorkService) GetRelations(bookID int) ([]models.BookRelation, error) {
+	if _, err := s.getBook(bookID); err != nil {
+		return nil, err
+	}
+
+	relations, err := s.workRepo.GetRelations(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book relations")
+	}
+
+	return relations, nil
+}
+
+// CreateRelation mencatat bahwa bookID adalah RelationType dari
+// RelatedBookID. Buku tidak boleh berhubungan dengan dirinya sendiri dan
+// hubungan yang sama tidak boleh dicatat ke dua arah sekaligus.
+func (s *WorkService) CreateRelation(bookID int, req *models.BookRelationRequest, username string) ([]models.BookRelation, error) {
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, validationFailed(err)
+	}
+
+	if req.RelatedBookID == bookID {
+		return nil, invalid("invalid_relation", "Invalid book relation", "a book cannot be related to itself")
+	}
+
+	if _, err := s.getBook(bookID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getBook(req.RelatedBookID); err != nil {
+		return nil, ErrRelatedBookNotFound
+	}
+
+	reverse, err := s.workRepo.RelationExists(req.RelatedBookID, bookID, req.RelationType)
+	if err != nil {
+		return nil, errors.New("failed to check book relations")
+	}
+
+	if reverse {
+		return nil, invalid("invalid_relation", "Invalid book relation", "the related book already has this relation to the book")
+	}
+
+	if _, err := s.workRepo.CreateRelation(bookID, req, username); err != nil {
+		if err == repositories.ErrDuplicateRelation {
+			return nil, ErrRelationExists
+		}
+		return nil, errors.New("failed to create book relation")
+	}
+
+	return s.GetRelations(bookID)
+}
+
+// DeleteRelation menghapus hubungan dari sisi buku mana pun yang terlibat
+func (s *WorkService) DeleteRelation(bookID, relationID int) error {
+	if err := s.workRepo.DeleteRelation(bookID, relationID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrRelationNotFound
+		}
+		return errors.New("failed to delete book relation")
+	}
+
+	return nil
+}
+
+func (s *WorkService) validateWorkRequest(req *models.WorkRequest) error {
+	req.Title = strings.TrimSpace(req.Title)
+	req.Description = strings.TrimSpace(req.Description)
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return validationFailed(err)
+	}
+
+	return nil
+}
+
+func (s *WorkService) getWork(id int) (*models.Work, error) {
+	work, err := s.workRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("failed to get work")
+	}
+
+	if work == nil {
+		return nil, ErrWorkNotFound
+	}
+
+	return work, nil
+}
+
+func (s *WorkService) getBook(bookID int) (*models.BookWithCategory, error) {
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, errors.New("failed to get book")
+	}
+
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+
+	return book, nil
+}
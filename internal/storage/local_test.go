@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(filepath.Join(dir, "uploads"), "http://localhost:8080/uploads/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte("cover image")
+	key := "covers/1/abc-original.jpg"
+	if err := s.Put(key, bytes.NewReader(content), int64(len(content)), "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	object, err := s.Open(key)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := object.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	tail, err := io.ReadAll(object)
+	object.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tail) != "image" {
+		t.Errorf("read after seek = %q, want %q", tail, "image")
+	}
+
+	if got, want := s.URL(key), "http://localhost:8080/uploads/covers/1/abc-original.jpg"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+
+	// No temporary upload files are left behind
+	entries, err := os.ReadDir(filepath.Join(dir, "uploads", "covers", "1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want 1", len(entries))
+	}
+
+	if err := s.Delete(key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Open(key); err != ErrNotFound {
+		t.Errorf("Open() after Delete error = %v, want %v", err, ErrNotFound)
+	}
+	if err := s.Delete(key); err != nil {
+		t.Errorf("Delete() of missing key error = %v", err)
+	}
+}
+
+func TestLocalStorageFailedPut(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := "files/1/book.pdf"
+	if err := s.Put(key, io.MultiReader(strings.NewReader("partial"), failingReader{}), 100, "application/pdf"); err == nil {
+		t.Fatal("Put() error = nil, want error")
+	}
+	if _, err := s.Open(key); err != ErrNotFound {
+		t.Errorf("Open() after failed Put error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestLocalStoragePath(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{key: "covers/1/a.jpg", want: filepath.Join(dir, "covers", "1", "a.jpg")},
+		{key: "../../etc/passwd", want: filepath.Join(dir, "etc", "passwd")},
+		{key: "/files/../covers/a.jpg", want: filepath.Join(dir, "covers", "a.jpg")},
+		{key: "", wantErr: true},
+		{key: "..", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := s.path(tt.key)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("path(%q) = %q, want error", tt.key, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("path(%q) = %q, %v, want %q", tt.key, got, err, tt.want)
+		}
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
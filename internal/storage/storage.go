@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"book-management/internal/config"
+)
+
+// ErrNotFound dikembalikan ketika objek dengan key tersebut tidak ada
+var ErrNotFound = errors.New("object not found")
+
+// PublicPrefix adalah awalan key yang boleh dibaca tanpa login (sampul buku).
+// Objek lain, misalnya file buku digital, hanya disajikan lewat API agar
+// autentikasi dan pencatatan unduhan tidak bisa dilewati.
+const PublicPrefix = "covers"
+
+// Storage menyimpan file yang diunggah (misalnya sampul buku) berdasarkan
+// key berbentuk path seperti "covers/12/abc-original.jpg"
+type Storage interface {
+	// Put menyimpan isi r sebesar size byte di bawah key
+	Put(key string, r io.Reader, size int64, contentType string) error
+
+	// Open membuka objek untuk dibaca. Seek memungkinkan pembacaan sebagian
+	// (HTTP Range) tanpa membaca seluruh objek.
+	Open(key string) (io.ReadSeekCloser, error)
+
+	// Delete menghapus objek; menghapus key yang tidak ada bukan error
+	Delete(key string) error
+
+	// URL mengembalikan alamat publik objek; hanya dapat diakses untuk key
+	// di bawah PublicPrefix
+	URL(key string) string
+}
+
+// New membuat Storage sesuai driver pada konfigurasi
+func New(cfg config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "local":
+		return NewLocalStorage(cfg.LocalDir, cfg.PublicURL)
+	case "s3":
+		return NewS3Storage(S3Options{
+			Endpoint:     cfg.S3Endpoint,
+			Region:       cfg.S3Region,
+			Bucket:       cfg.S3Bucket,
+			AccessKey:    cfg.S3AccessKey,
+			SecretKey:    cfg.S3SecretKey,
+			UsePathStyle: cfg.S3UsePathStyle,
+			PublicURL:    cfg.PublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
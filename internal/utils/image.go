@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// ResizeToWidth mengecilkan gambar ke lebar tertentu dengan rasio yang sama.
+// Setiap piksel hasil adalah rata-rata area piksel sumber yang diwakilinya,
+// dan transparansi diratakan ke latar putih agar bisa disimpan sebagai JPEG.
+func ResizeToWidth(src image.Image, width int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	height := srcH * width / srcW
+	if height < 1 {
+		height = 1
+	}
+
+	flat := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(flat, flat.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * srcH / height
+		y1 := (y + 1) * srcH / height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := x * srcW / width
+			x1 := (x + 1) * srcW / width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, count int
+			for sy := y0; sy < y1; sy++ {
+				offset := flat.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(flat.Pix[offset])
+					g += int(flat.Pix[offset+1])
+					b += int(flat.Pix[offset+2])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = 0xff
+		}
+	}
+
+	return dst
+}
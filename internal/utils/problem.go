@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"book-management/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+const problemContentType = "application/problem+json"
+
+// errorConfig menentukan bentuk respons kesalahan; nilainya diganti lewat
+// SetErrorConfig
+var errorConfig = config.ErrorConfig{Format: "envelope"}
+
+// SetErrorConfig mengganti bentuk respons kesalahan; dipanggil sekali saat
+// aplikasi dimulai, sebelum request pertama dilayani
+func SetErrorConfig(cfg config.ErrorConfig) {
+	errorConfig = cfg
+}
+
+// Problem adalah respons kesalahan RFC 7807 (application/problem+json).
+// Title berisi pesan yang sama dengan field message pada Response, Code kode
+// kesalahannya, dan Errors daftar kesalahan validasi per field. Extensions
+// berisi anggota tambahan seperti book_count.
+type Problem struct {
+	Type       string                 `json:"type"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Code       string                 `json:"code,omitempty"`
+	Errors     []FieldError           `json:"errors,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// FieldError adalah satu kesalahan validasi pada Problem
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// MarshalJSON menulis Extensions sebagai anggota tingkat atas; anggota
+// standar tidak dapat ditimpa oleh extension dengan nama yang sama
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	data, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]interface{}, len(p.Extensions))
+	for name, value := range p.Extensions {
+		members[name] = value
+	}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(members)
+}
+
+// wantsProblem menentukan apakah kesalahan dikirim sebagai Problem Details:
+// selalu bila ERROR_FORMAT=problem, atau bila klien memintanya lewat Accept
+func wantsProblem(c *gin.Context) bool {
+	if errorConfig.Format == "problem" {
+		return true
+	}
+
+	// Bentuk respons bergantung pada header Accept
+	c.Writer.Header().Add("Vary", "Accept")
+	return acceptsProblem(c.GetHeader("Accept"))
+}
+
+// acceptsProblem memeriksa apakah header Accept memuat
+// application/problem+json dengan bobot q lebih dari 0
+func acceptsProblem(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+		if !strings.EqualFold(strings.TrimSpace(params[0]), problemContentType) {
+			continue
+		}
+
+		accepted := true
+		for _, param := range params[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(name, "q") {
+				q, err := strconv.ParseFloat(value, 64)
+				accepted = err == nil && q > 0
+			}
+		}
+		if accepted {
+			return true
+		}
+	}
+
+	return false
+}
+
+func problemType(code string) string {
+	if errorConfig.ProblemTypeBaseURL == "" || code == "" {
+		return "about:blank"
+	}
+	return errorConfig.ProblemTypeBaseURL + code
+}
+
+// problemResponse menulis kesalahan sebagai Problem Details. Detail berupa
+// teks menjadi field detail, kesalahan validasi menjadi errors, dan map
+// menjadi anggota tambahan.
+func problemResponse(c *gin.Context, statusCode int, code, title string, detail interface{}) {
+	problem := Problem{
+		Type:     problemType(code),
+		Title:    title,
+		Status:   statusCode,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+	}
+
+	switch detail := detail.(type) {
+	case nil:
+	case string:
+		problem.Detail = detail
+	case ValidationMessages:
+		problem.Errors = detail.Fields(c.GetString("locale"))
+	case map[string]interface{}:
+		problem.Extensions = detail
+	default:
+		problem.Extensions = map[string]interface{}{"error": detail}
+	}
+
+	c.Header("Content-Type", problemContentType)
+	c.JSON(statusCode, problem)
+}
@@ -3,28 +3,59 @@ package utils
 import (
 	"net/http"
 
+	"book-management/internal/i18n"
+
 	"github.com/gin-gonic/gin"
 )
 
 type Response struct {
 	Status  bool        `json:"status"`
 	Message string      `json:"message"`
+	Code    string      `json:"code,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   interface{} `json:"error,omitempty"`
 }
 
+// Kode kesalahan umum untuk respons yang tidak berasal dari kesalahan
+// domain service; kesalahan domain membawa kodenya sendiri
+const (
+	CodeBadRequest      = "bad_request"
+	CodeUnauthorized    = "unauthorized"
+	CodeForbidden       = "forbidden"
+	CodeNotFound        = "not_found"
+	CodeConflict        = "conflict"
+	CodePayloadTooLarge = "payload_too_large"
+	CodeInternalError   = "internal_error"
+)
+
+// SuccessResponse dan ErrorResponse menerjemahkan message (dan pesan
+// validasi) ke bahasa request yang ditentukan LocaleMiddleware. ErrorResponse
+// menulis Problem Details (lihat problemResponse) bila diminta klien atau
+// konfigurasi.
 func SuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
 	c.JSON(statusCode, Response{
 		Status:  true,
-		Message: message,
+		Message: i18n.T(c.GetString("locale"), message),
 		Data:    data,
 	})
 }
 
-func ErrorResponse(c *gin.Context, statusCode int, message string, error interface{}) {
+func ErrorResponse(c *gin.Context, statusCode int, code, message string, error interface{}) {
+	locale := c.GetString("locale")
+	message = i18n.T(locale, message)
+	if wantsProblem(c) {
+		problemResponse(c, statusCode, code, message, error)
+		return
+	}
+
+	if messages, ok := error.(ValidationMessages); ok {
+		error = messages.Localize(locale)
+	}
+
 	c.JSON(statusCode, Response{
 		Status:  false,
 		Message: message,
+		Code:    code,
 		Error:   error,
 	})
 }
@@ -40,21 +71,29 @@ func Created(c *gin.Context, message string, data interface{}) {
 
 // Common error responses
 func BadRequest(c *gin.Context, message string, error interface{}) {
-	ErrorResponse(c, http.StatusBadRequest, message, error)
+	ErrorResponse(c, http.StatusBadRequest, CodeBadRequest, message, error)
 }
 
 func Unauthorized(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusUnauthorized, message, nil)
+	ErrorResponse(c, http.StatusUnauthorized, CodeUnauthorized, message, nil)
 }
 
 func Forbidden(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusForbidden, message, nil)
+	ErrorResponse(c, http.StatusForbidden, CodeForbidden, message, nil)
 }
 
 func NotFound(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusNotFound, message, nil)
+	ErrorResponse(c, http.StatusNotFound, CodeNotFound, message, nil)
+}
+
+func Conflict(c *gin.Context, message string, error interface{}) {
+	ErrorResponse(c, http.StatusConflict, CodeConflict, message, error)
+}
+
+func PayloadTooLarge(c *gin.Context, message string) {
+	ErrorResponse(c, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, message, nil)
 }
 
 func InternalServerError(c *gin.Context, message string, error interface{}) {
-	ErrorResponse(c, http.StatusInternalServerError, message, error)
+	ErrorResponse(c, http.StatusInternalServerError, CodeInternalError, message, error)
 }
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations memetakan huruf yang tidak bisa diuraikan menjadi huruf
+// ASCII + tanda diakritik (misalnya ß atau ø) serta huruf Kiril dan Yunani
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d", 'ð': "d",
+	'þ': "th", 'ı': "i", 'ŋ': "ng", 'ħ': "h", 'ŧ': "t", 'ĸ': "k",
+
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Slugify mengubah teks menjadi slug URL berhuruf kecil ASCII yang dipisahkan
+// tanda hubung, misalnya "Ciência & Tecnologia" menjadi "ciencia-and-tecnologia"
+func Slugify(s string) string {
+	var b strings.Builder
+	pendingDash := false
+
+	write := func(part string) {
+		if part == "" {
+			return
+		}
+		if pendingDash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		pendingDash = false
+		b.WriteString(part)
+	}
+
+	// Letters with their own transliteration are looked up before NFD splits
+	// them (ё would otherwise become е); NFD splits other accented letters
+	// into the base letter and combining marks
+	for _, composed := range norm.NFC.String(strings.ToLower(s)) {
+		if part, ok := transliterations[composed]; ok {
+			write(part)
+			continue
+		}
+
+		for _, r := range norm.NFD.String(string(composed)) {
+			switch {
+			case unicode.Is(unicode.Mn, r):
+				continue
+			case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+				write(string(r))
+			case r == '&':
+				pendingDash = true
+				write("and")
+				pendingDash = true
+			default:
+				if part, ok := transliterations[r]; ok {
+					write(part)
+				} else {
+					pendingDash = true
+				}
+			}
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > 200 {
+		slug = strings.TrimRight(slug[:200], "-")
+	}
+
+	return slug
+}
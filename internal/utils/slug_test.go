@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Fiksi Ilmiah", "fiksi-ilmiah"},
+		{"  Sejarah   Dunia  ", "sejarah-dunia"},
+		{"Ciência & Tecnologia", "ciencia-and-tecnologia"},
+		{"Rock&Roll", "rock-and-roll"},
+		{"& Lainnya", "and-lainnya"},
+		{"Crème Brûlée", "creme-brulee"},
+		{"Straße", "strasse"},
+		{"Ærø Øst", "aero-ost"},
+		{"Łódź", "lodz"},
+		{"Литература", "literatura"},
+		{"Ёжик", "yozhik"},
+		{"Київ", "kiyiv"},
+		{"Мой край", "moy-kray"},
+		{"Φιλοσοφία", "filosofia"},
+		{"Sci-Fi: 2024 Edition!", "sci-fi-2024-edition"},
+		{"--Anak--Anak--", "anak-anak"},
+		{"三体", ""},
+		{"كتب", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.input); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSlugifyLength(t *testing.T) {
+	got := Slugify(strings.Repeat("a", 199) + " " + strings.Repeat("b", 50))
+	if got != strings.Repeat("a", 199) {
+		t.Errorf("Slugify() = %q (%d bytes), want 199 a's without a trailing dash", got, len(got))
+	}
+
+	if got := Slugify(strings.Repeat("x", 300)); len(got) != 200 {
+		t.Errorf("len(Slugify()) = %d, want 200", len(got))
+	}
+}
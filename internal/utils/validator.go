@@ -1,48 +1,182 @@
 package utils
 
 import (
-	"fmt"
+	"errors"
+	"reflect"
 	"strings"
+	"time"
+	"unicode/utf8"
+
+	"book-management/internal/config"
+	"book-management/internal/currency"
+	"book-management/internal/i18n"
 
 	"github.com/go-playground/validator/v10"
 )
 
 var validate *validator.Validate
 
+// bookRules dipakai oleh tag validasi buku (book_title, release_year,
+// book_price, total_page); nilainya diganti lewat SetValidationRules
+var bookRules = config.ValidationConfig{
+	ReleaseYearMin: config.YearBound{Year: 1980},
+	ReleaseYearMax: config.YearBound{Year: 1, Relative: true},
+	PriceMin:       0,
+	TotalPageMin:   1,
+	TitleMaxLength: 255,
+}
+
 func init() {
 	validate = validator.New()
+	validate.RegisterValidation("book_title", validateBookTitle)
+	validate.RegisterValidation("release_year", validateReleaseYear)
+	validate.RegisterValidation("book_price", validateBookPrice)
+	validate.RegisterValidation("total_page", validateTotalPage)
+	validate.RegisterValidation("currency", validateCurrency)
+}
+
+// SetValidationRules mengganti batas validasi buku; dipanggil sekali saat
+// aplikasi dimulai, sebelum request pertama dilayani
+func SetValidationRules(rules config.ValidationConfig) {
+	bookRules = rules
+}
+
+// TitleMaxLength mengembalikan panjang judul buku maksimum yang diizinkan
+func TitleMaxLength() int {
+	return bookRules.TitleMaxLength
 }
 
 func ValidateStruct(s interface{}) error {
 	return validate.Struct(s)
 }
 
-func FormatValidationErrors(err error) []string {
-	var errors []string
+// ValidationMessage adalah satu kesalahan validasi field. Pesannya baru
+// disusun saat respons dikirim agar mengikuti bahasa request.
+type ValidationMessage struct {
+	field string
+	tag   string
+	param string
+	kind  reflect.Kind
+}
+
+// ValidationMessages adalah hasil FormatValidationErrors
+type ValidationMessages []ValidationMessage
+
+// FormatValidationErrors mengambil kesalahan validasi dari err, termasuk yang
+// dibungkus dengan fmt.Errorf("...: %w", err)
+func FormatValidationErrors(err error) ValidationMessages {
+	var messages ValidationMessages
 
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
 		for _, fieldError := range validationErrors {
-			errors = append(errors, formatFieldError(fieldError))
+			messages = append(messages, ValidationMessage{
+				field: strings.ToLower(fieldError.Field()),
+				tag:   fieldError.Tag(),
+				param: fieldError.Param(),
+				kind:  fieldError.Kind(),
+			})
 		}
 	}
 
-	return errors
+	return messages
 }
 
-func formatFieldError(fieldError validator.FieldError) string {
-	field := strings.ToLower(fieldError.Field())
-	tag := fieldError.Tag()
+// Localize menyusun pesan validasi dalam bahasa locale
+func (m ValidationMessages) Localize(locale string) []string {
+	var result []string
+	for _, message := range m {
+		result = append(result, message.format(locale))
+	}
+
+	return result
+}
+
+// Fields menyusun pesan validasi per field untuk Problem Details
+func (m ValidationMessages) Fields(locale string) []FieldError {
+	var result []FieldError
+	for _, message := range m {
+		result = append(result, FieldError{Field: message.field, Message: message.format(locale)})
+	}
+
+	return result
+}
+
+func (m ValidationMessage) format(locale string) string {
+	switch m.tag {
+	case "required", "email", "url", "currency":
+		return i18n.T(locale, "validation."+m.tag, m.field)
+	case "min", "max", "len":
+		return i18n.T(locale, "validation."+m.tag+"."+kindGroup(m.kind), m.field, m.param)
+	case "gt", "gte", "lt", "lte", "datetime":
+		return i18n.T(locale, "validation."+m.tag, m.field, m.param)
+	case "required_without":
+		return i18n.T(locale, "validation.required_without", m.field, strings.ToLower(m.param))
+	case "oneof":
+		return i18n.T(locale, "validation.oneof", m.field, strings.ReplaceAll(m.param, " ", ", "))
+	case "book_title":
+		return i18n.T(locale, "validation.book_title", m.field, bookRules.TitleMaxLength)
+	case "release_year":
+		min, max := releaseYearRange()
+		return i18n.T(locale, "validation.between", m.field, min, max)
+	case "book_price":
+		return formatRangeError(locale, m.field, bookRules.PriceMin, bookRules.PriceMax)
+	case "total_page":
+		return formatRangeError(locale, m.field, bookRules.TotalPageMin, bookRules.TotalPageMax)
+	default:
+		return i18n.T(locale, "validation.invalid", m.field)
+	}
+}
 
-	switch tag {
-	case "required":
-		return fmt.Sprintf("%s is required", field)
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters/value", field, fieldError.Param())
-	case "max":
-		return fmt.Sprintf("%s must be at most %s characters/value", field, fieldError.Param())
-	case "email":
-		return fmt.Sprintf("%s must be a valid email address", field)
+// kindGroup menentukan arti parameter min/max/len: panjang teks, jumlah
+// item, atau nilai angka
+func kindGroup(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
 	default:
-		return fmt.Sprintf("%s is invalid", field)
+		return "number"
+	}
+}
+
+func formatRangeError(locale, field string, min, max int) string {
+	if max > 0 {
+		return i18n.T(locale, "validation.between", field, min, max)
 	}
+	return i18n.T(locale, "validation.at_least", field, min)
+}
+
+// releaseYearRange menghitung batas tahun terbit untuk tahun berjalan
+func releaseYearRange() (int, int) {
+	currentYear := time.Now().Year()
+	return bookRules.ReleaseYearMin.Resolve(currentYear), bookRules.ReleaseYearMax.Resolve(currentYear)
+}
+
+func validateBookTitle(fl validator.FieldLevel) bool {
+	length := utf8.RuneCountInString(fl.Field().String())
+	return length >= 1 && length <= bookRules.TitleMaxLength
+}
+
+func validateReleaseYear(fl validator.FieldLevel) bool {
+	year := int(fl.Field().Int())
+	min, max := releaseYearRange()
+	return year >= min && year <= max
+}
+
+func validateBookPrice(fl validator.FieldLevel) bool {
+	return inRange(int(fl.Field().Int()), bookRules.PriceMin, bookRules.PriceMax)
+}
+
+func validateTotalPage(fl validator.FieldLevel) bool {
+	return inRange(int(fl.Field().Int()), bookRules.TotalPageMin, bookRules.TotalPageMax)
+}
+
+func validateCurrency(fl validator.FieldLevel) bool {
+	return currency.IsSupported(fl.Field().String())
+}
+
+func inRange(value, min, max int) bool {
+	return value >= min && (max <= 0 || value <= max)
 }
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE book_categories (
+                                 book_id INTEGER NOT NULL,
+                                 category_id INTEGER NOT NULL,
+                                 created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                 created_by VARCHAR(255) DEFAULT 'system',
+                                 PRIMARY KEY (book_id, category_id),
+                                 FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+                                 FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_book_categories_category_id ON book_categories(category_id);
+
+-- Every existing book is linked to its primary category
+INSERT INTO book_categories (book_id, category_id, created_by)
+SELECT id, category_id, created_by FROM books;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_book_categories_category_id;
+DROP TABLE book_categories;
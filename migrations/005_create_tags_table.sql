@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE tags (
+                      id SERIAL PRIMARY KEY,
+                      name VARCHAR(50) NOT NULL,
+                      created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                      created_by VARCHAR(255) DEFAULT 'system',
+                      modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                      modified_by VARCHAR(255) DEFAULT 'system'
+);
+
+-- Tag names are unique regardless of case
+CREATE UNIQUE INDEX idx_tags_name ON tags (LOWER(name));
+
+CREATE TABLE book_tags (
+                           book_id INTEGER NOT NULL,
+                           tag_id INTEGER NOT NULL,
+                           created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                           created_by VARCHAR(255) DEFAULT 'system',
+                           PRIMARY KEY (book_id, tag_id),
+                           FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+                           FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_book_tags_tag_id ON book_tags(tag_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_book_tags_tag_id;
+DROP TABLE book_tags;
+DROP INDEX IF EXISTS idx_tags_name;
+DROP TABLE tags;
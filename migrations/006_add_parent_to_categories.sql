@@ -0,0 +1,14 @@
+-- +migrate Up
+ALTER TABLE categories ADD COLUMN parent_id INTEGER;
+ALTER TABLE categories
+    ADD CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE RESTRICT;
+ALTER TABLE categories
+    ADD CONSTRAINT chk_categories_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_categories_parent_id;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS chk_categories_parent_not_self;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS fk_categories_parent;
+ALTER TABLE categories DROP COLUMN parent_id;
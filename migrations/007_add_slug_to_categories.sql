@@ -0,0 +1,22 @@
+-- +migrate Up
+-- Rename existing duplicates (ignoring case) so the unique index can be created
+UPDATE categories c
+SET name = c.name || ' (' || c.id || ')'
+WHERE EXISTS (
+    SELECT 1 FROM categories o
+    WHERE LOWER(o.name) = LOWER(c.name) AND o.id < c.id
+);
+
+CREATE UNIQUE INDEX idx_categories_name_lower ON categories (LOWER(name));
+
+ALTER TABLE categories ADD COLUMN slug VARCHAR(255);
+
+-- Existing rows are left NULL here and get their slug from utils.Slugify
+-- when the application starts (CategoryService.BackfillSlugs), so they match
+-- the slugs the application generates for new and renamed categories
+CREATE UNIQUE INDEX idx_categories_slug ON categories (slug);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_categories_slug;
+ALTER TABLE categories DROP COLUMN slug;
+DROP INDEX IF EXISTS idx_categories_name_lower;
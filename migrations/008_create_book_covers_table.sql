@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE book_covers (
+                             book_id INTEGER NOT NULL,
+                             variant VARCHAR(20) NOT NULL,
+                             storage_key VARCHAR(500) NOT NULL,
+                             url VARCHAR(1000) NOT NULL,
+                             content_type VARCHAR(100) NOT NULL,
+                             width INTEGER NOT NULL,
+                             height INTEGER NOT NULL,
+                             size_bytes BIGINT NOT NULL,
+                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                             created_by VARCHAR(255) DEFAULT 'system',
+                             PRIMARY KEY (book_id, variant),
+                             FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+-- Storage URLs can be longer than the original column allowed
+ALTER TABLE books ALTER COLUMN image_url TYPE VARCHAR(1000);
+
+-- +migrate Down
+-- A truncated URL would point nowhere, so long URLs are cleared instead;
+-- image_url is scanned into a string, so it is emptied rather than set to NULL
+UPDATE books SET image_url = '' WHERE LENGTH(image_url) > 255;
+ALTER TABLE books ALTER COLUMN image_url TYPE VARCHAR(255);
+DROP TABLE book_covers;
@@ -0,0 +1,43 @@
+-- +migrate Up
+CREATE TABLE book_files (
+                            id SERIAL PRIMARY KEY,
+                            book_id INTEGER NOT NULL,
+                            format VARCHAR(10) NOT NULL CHECK (format IN ('pdf', 'epub', 'mobi')),
+                            file_name VARCHAR(255) NOT NULL,
+                            storage_key VARCHAR(500) NOT NULL,
+                            content_type VARCHAR(100) NOT NULL,
+                            size_bytes BIGINT NOT NULL,
+                            checksum_sha256 CHAR(64) NOT NULL,
+                            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                            created_by VARCHAR(255) DEFAULT 'system',
+                            FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+-- One file per format; uploading the same format again replaces it
+CREATE UNIQUE INDEX idx_book_files_book_format ON book_files(book_id, format);
+
+-- Download audit keeps a copy of the book and file name so records survive file deletion
+CREATE TABLE book_file_downloads (
+                                     id SERIAL PRIMARY KEY,
+                                     file_id INTEGER,
+                                     book_id INTEGER NOT NULL,
+                                     file_name VARCHAR(255) NOT NULL,
+                                     user_id INTEGER,
+                                     username VARCHAR(255) NOT NULL,
+                                     range_header VARCHAR(255),
+                                     status_code INTEGER NOT NULL,
+                                     bytes_sent BIGINT NOT NULL,
+                                     ip_address VARCHAR(45),
+                                     user_agent TEXT,
+                                     downloaded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                     FOREIGN KEY (file_id) REFERENCES book_files(id) ON DELETE SET NULL,
+                                     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_book_file_downloads_file_id ON book_file_downloads(file_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_book_file_downloads_file_id;
+DROP TABLE book_file_downloads;
+DROP INDEX IF EXISTS idx_book_files_book_format;
+DROP TABLE book_files;
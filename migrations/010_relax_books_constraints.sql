@@ -0,0 +1,10 @@
+-- +migrate Up
+-- Year, price, page and title limits are enforced by the application from configuration
+ALTER TABLE books DROP CONSTRAINT IF EXISTS books_release_year_check;
+ALTER TABLE books ADD CONSTRAINT chk_books_release_year_positive CHECK (release_year > 0);
+ALTER TABLE books ALTER COLUMN title TYPE VARCHAR(1000);
+
+-- +migrate Down
+ALTER TABLE books DROP CONSTRAINT IF EXISTS chk_books_release_year_positive;
+ALTER TABLE books ADD CONSTRAINT books_release_year_check CHECK (release_year >= 1980 AND release_year <= 2024) NOT VALID;
+ALTER TABLE books ALTER COLUMN title TYPE VARCHAR(255) USING LEFT(title, 255);
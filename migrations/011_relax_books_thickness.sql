@@ -0,0 +1,10 @@
+-- +migrate Up
+-- Thickness labels come from the configurable classification scheme
+ALTER TABLE books DROP CONSTRAINT IF EXISTS books_thickness_check;
+ALTER TABLE books ALTER COLUMN thickness TYPE VARCHAR(30);
+
+-- +migrate Down
+UPDATE books SET thickness = CASE WHEN total_page >= 100 THEN 'tebal' ELSE 'tipis' END
+WHERE thickness IS NULL OR thickness NOT IN ('tipis', 'tebal');
+ALTER TABLE books ALTER COLUMN thickness TYPE VARCHAR(10);
+ALTER TABLE books ADD CONSTRAINT books_thickness_check CHECK (thickness IN ('tipis', 'tebal'));
@@ -0,0 +1,20 @@
+-- +migrate Up
+-- Prices are stored in the minor unit of their currency (whole rupiah for IDR)
+ALTER TABLE books ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE books ADD CONSTRAINT chk_books_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+CREATE TABLE exchange_rates (
+                                base_currency CHAR(3) NOT NULL,
+                                quote_currency CHAR(3) NOT NULL,
+                                rate NUMERIC(30, 12) NOT NULL CHECK (rate > 0),
+                                source VARCHAR(50) NOT NULL DEFAULT 'api',
+                                updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                updated_by VARCHAR(255) DEFAULT 'system',
+                                PRIMARY KEY (base_currency, quote_currency),
+                                CHECK (base_currency <> quote_currency)
+);
+
+-- +migrate Down
+DROP TABLE exchange_rates;
+ALTER TABLE books DROP CONSTRAINT IF EXISTS chk_books_currency;
+ALTER TABLE books DROP COLUMN currency;
@@ -0,0 +1,65 @@
+-- +migrate Up
+CREATE TABLE price_history (
+                               id SERIAL PRIMARY KEY,
+                               book_id INTEGER NOT NULL,
+                               old_price INTEGER,
+                               old_currency CHAR(3),
+                               new_price INTEGER NOT NULL,
+                               new_currency CHAR(3) NOT NULL,
+                               source VARCHAR(20) NOT NULL CHECK (source IN ('manual', 'scheduled')),
+                               changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                               changed_by VARCHAR(255) DEFAULT 'system',
+                               FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_price_history_book_id ON price_history(book_id, changed_at);
+
+CREATE TABLE discounts (
+                           id SERIAL PRIMARY KEY,
+                           name VARCHAR(100) NOT NULL,
+                           book_id INTEGER,
+                           category_id INTEGER,
+                           discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+                           percentage NUMERIC(5, 2) CHECK (percentage > 0 AND percentage <= 100),
+                           amount INTEGER CHECK (amount > 0),
+                           currency CHAR(3),
+                           starts_at TIMESTAMP NOT NULL,
+                           ends_at TIMESTAMP NOT NULL,
+                           created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                           created_by VARCHAR(255) DEFAULT 'system',
+                           modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                           modified_by VARCHAR(255) DEFAULT 'system',
+                           FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+                           FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE,
+                           CONSTRAINT chk_discounts_target CHECK ((book_id IS NULL) <> (category_id IS NULL)),
+                           CONSTRAINT chk_discounts_value CHECK (
+                               (discount_type = 'percentage' AND percentage IS NOT NULL AND amount IS NULL)
+                               OR (discount_type = 'fixed' AND amount IS NOT NULL AND currency IS NOT NULL AND percentage IS NULL)
+                           ),
+                           CONSTRAINT chk_discounts_period CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_discounts_book_id ON discounts(book_id);
+CREATE INDEX idx_discounts_category_id ON discounts(category_id);
+CREATE INDEX idx_discounts_period ON discounts(starts_at, ends_at);
+
+CREATE TABLE scheduled_price_changes (
+                                         id SERIAL PRIMARY KEY,
+                                         book_id INTEGER NOT NULL,
+                                         price INTEGER NOT NULL,
+                                         currency CHAR(3) NOT NULL,
+                                         effective_at TIMESTAMP NOT NULL,
+                                         status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'applied', 'cancelled')),
+                                         applied_at TIMESTAMP,
+                                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                         created_by VARCHAR(255) DEFAULT 'system',
+                                         FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_scheduled_price_changes_due ON scheduled_price_changes(effective_at) WHERE status = 'pending';
+CREATE INDEX idx_scheduled_price_changes_book_id ON scheduled_price_changes(book_id);
+
+-- +migrate Down
+DROP TABLE scheduled_price_changes;
+DROP TABLE discounts;
+DROP TABLE price_history;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE items (
+                       id SERIAL PRIMARY KEY,
+                       book_id INTEGER NOT NULL,
+                       barcode VARCHAR(50) NOT NULL,
+                       acquisition_date DATE,
+                       condition VARCHAR(20) NOT NULL DEFAULT 'good' CHECK (condition IN ('new', 'good', 'fair', 'poor')),
+                       shelf_location VARCHAR(100),
+                       status VARCHAR(20) NOT NULL DEFAULT 'available' CHECK (status IN ('available', 'on_loan', 'lost', 'damaged')),
+                       notes TEXT,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       created_by VARCHAR(255) DEFAULT 'system',
+                       modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       modified_by VARCHAR(255) DEFAULT 'system',
+                       FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_items_barcode ON items(barcode);
+CREATE INDEX idx_items_book_id_status ON items(book_id, status);
+
+-- +migrate Down
+DROP TABLE items;
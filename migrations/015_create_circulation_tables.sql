@@ -0,0 +1,52 @@
+-- +migrate Up
+CREATE TABLE patrons (
+                         id SERIAL PRIMARY KEY,
+                         card_number VARCHAR(50) NOT NULL,
+                         name VARCHAR(255) NOT NULL,
+                         email VARCHAR(255),
+                         phone VARCHAR(50),
+                         status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended')),
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         created_by VARCHAR(255) DEFAULT 'system',
+                         modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         modified_by VARCHAR(255) DEFAULT 'system'
+);
+
+CREATE UNIQUE INDEX idx_patrons_card_number ON patrons(card_number);
+
+CREATE TABLE loan_policies (
+                               category_id INTEGER PRIMARY KEY,
+                               loan_days INTEGER NOT NULL CHECK (loan_days > 0),
+                               max_renewals INTEGER NOT NULL CHECK (max_renewals >= 0),
+                               modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                               modified_by VARCHAR(255) DEFAULT 'system',
+                               FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+CREATE TABLE loans (
+                       id SERIAL PRIMARY KEY,
+                       item_id INTEGER NOT NULL,
+                       patron_id INTEGER NOT NULL,
+                       checked_out_at TIMESTAMP NOT NULL,
+                       due_at TIMESTAMP NOT NULL,
+                       returned_at TIMESTAMP,
+                       loan_days INTEGER NOT NULL,
+                       renewals INTEGER NOT NULL DEFAULT 0,
+                       max_renewals INTEGER NOT NULL,
+                       checked_out_by VARCHAR(255) DEFAULT 'system',
+                       returned_by VARCHAR(255),
+                       FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE RESTRICT,
+                       FOREIGN KEY (patron_id) REFERENCES patrons(id) ON DELETE RESTRICT,
+                       CONSTRAINT chk_loans_due CHECK (due_at > checked_out_at),
+                       CONSTRAINT chk_loans_renewals CHECK (renewals >= 0 AND renewals <= max_renewals)
+);
+
+-- At most one open loan per item, even under concurrent checkouts
+CREATE UNIQUE INDEX idx_loans_active_item ON loans(item_id) WHERE returned_at IS NULL;
+CREATE INDEX idx_loans_patron_id ON loans(patron_id);
+CREATE INDEX idx_loans_due_at ON loans(due_at) WHERE returned_at IS NULL;
+
+-- +migrate Down
+DROP TABLE loans;
+DROP TABLE loan_policies;
+DROP TABLE patrons;
@@ -0,0 +1,35 @@
+-- +migrate Up
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_status_check;
+ALTER TABLE items ADD CONSTRAINT items_status_check CHECK (status IN ('available', 'on_loan', 'on_hold', 'lost', 'damaged'));
+
+CREATE TABLE holds (
+                       id SERIAL PRIMARY KEY,
+                       book_id INTEGER NOT NULL,
+                       patron_id INTEGER NOT NULL,
+                       item_id INTEGER,
+                       status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'ready', 'fulfilled', 'cancelled', 'expired')),
+                       placed_at TIMESTAMP NOT NULL,
+                       ready_at TIMESTAMP,
+                       expires_at TIMESTAMP,
+                       closed_at TIMESTAMP,
+                       created_by VARCHAR(255) DEFAULT 'system',
+                       closed_by VARCHAR(255),
+                       FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+                       FOREIGN KEY (patron_id) REFERENCES patrons(id) ON DELETE CASCADE,
+                       FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE SET NULL,
+                       CONSTRAINT chk_holds_ready CHECK (status <> 'ready' OR (item_id IS NOT NULL AND expires_at IS NOT NULL))
+);
+
+-- A patron queues at most once per title, and a copy is held for one patron
+CREATE UNIQUE INDEX idx_holds_open_patron ON holds(book_id, patron_id) WHERE status IN ('waiting', 'ready');
+CREATE UNIQUE INDEX idx_holds_ready_item ON holds(item_id) WHERE status = 'ready';
+CREATE INDEX idx_holds_queue ON holds(book_id, placed_at, id) WHERE status = 'waiting';
+CREATE INDEX idx_holds_expires_at ON holds(expires_at) WHERE status = 'ready';
+CREATE INDEX idx_holds_patron_id ON holds(patron_id);
+
+-- +migrate Down
+DROP TABLE holds;
+
+UPDATE items SET status = 'available' WHERE status = 'on_hold';
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_status_check;
+ALTER TABLE items ADD CONSTRAINT items_status_check CHECK (status IN ('available', 'on_loan', 'lost', 'damaged'));
@@ -0,0 +1,40 @@
+-- +migrate Up
+ALTER TABLE loan_policies ADD COLUMN fine_per_day INTEGER CHECK (fine_per_day >= 0);
+ALTER TABLE loan_policies ADD COLUMN fine_grace_days INTEGER CHECK (fine_grace_days >= 0);
+ALTER TABLE loan_policies ADD COLUMN fine_cap INTEGER CHECK (fine_cap >= 0);
+
+ALTER TABLE loans ADD COLUMN fine_per_day INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loans ADD COLUMN fine_grace_days INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loans ADD COLUMN fine_cap INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loans ADD COLUMN fine_currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+CREATE TABLE patron_ledger_entries (
+                                       id SERIAL PRIMARY KEY,
+                                       patron_id INTEGER NOT NULL,
+                                       loan_id INTEGER,
+                                       entry_type VARCHAR(20) NOT NULL CHECK (entry_type IN ('charge', 'payment', 'waiver')),
+                                       amount INTEGER NOT NULL CHECK (amount > 0),
+                                       currency CHAR(3) NOT NULL,
+                                       note VARCHAR(255),
+                                       created_at TIMESTAMP NOT NULL,
+                                       created_by VARCHAR(255) DEFAULT 'system',
+                                       modified_at TIMESTAMP NOT NULL,
+                                       FOREIGN KEY (patron_id) REFERENCES patrons(id) ON DELETE RESTRICT,
+                                       FOREIGN KEY (loan_id) REFERENCES loans(id) ON DELETE RESTRICT
+);
+
+-- The overdue fine of a loan is a single charge that grows while it accrues
+CREATE UNIQUE INDEX idx_patron_ledger_loan_charge ON patron_ledger_entries(loan_id) WHERE entry_type = 'charge';
+CREATE INDEX idx_patron_ledger_patron_id ON patron_ledger_entries(patron_id, created_at);
+
+-- +migrate Down
+DROP TABLE patron_ledger_entries;
+
+ALTER TABLE loans DROP COLUMN fine_currency;
+ALTER TABLE loans DROP COLUMN fine_cap;
+ALTER TABLE loans DROP COLUMN fine_grace_days;
+ALTER TABLE loans DROP COLUMN fine_per_day;
+
+ALTER TABLE loan_policies DROP COLUMN fine_cap;
+ALTER TABLE loan_policies DROP COLUMN fine_grace_days;
+ALTER TABLE loan_policies DROP COLUMN fine_per_day;
@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE reviews (
+                         id SERIAL PRIMARY KEY,
+                         book_id INTEGER NOT NULL,
+                         user_id INTEGER NOT NULL,
+                         rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+                         title VARCHAR(255),
+                         body TEXT,
+                         status VARCHAR(20) NOT NULL DEFAULT 'visible' CHECK (status IN ('visible', 'hidden')),
+                         moderation_reason VARCHAR(255),
+                         moderated_at TIMESTAMP,
+                         moderated_by VARCHAR(255),
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+                         FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_reviews_book_user ON reviews(book_id, user_id);
+CREATE INDEX idx_reviews_user_id ON reviews(user_id);
+CREATE INDEX idx_reviews_status ON reviews(status);
+
+-- Aggregates of visible reviews, kept up to date by the review repository
+ALTER TABLE books ADD COLUMN rating_average NUMERIC(3, 2) NOT NULL DEFAULT 0;
+ALTER TABLE books ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_books_rating ON books(rating_average DESC, rating_count DESC);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_books_rating;
+ALTER TABLE books DROP COLUMN rating_count;
+ALTER TABLE books DROP COLUMN rating_average;
+
+DROP TABLE reviews;
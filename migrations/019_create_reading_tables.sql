@@ -0,0 +1,51 @@
+-- +migrate Up
+-- Shelves (want_to_read, reading, read) are created for each user on first use;
+-- lists with an empty shelf column are custom lists
+CREATE TABLE reading_lists (
+                               id SERIAL PRIMARY KEY,
+                               user_id INTEGER NOT NULL,
+                               shelf VARCHAR(20) CHECK (shelf IN ('want_to_read', 'reading', 'read')),
+                               name VARCHAR(255) NOT NULL,
+                               description TEXT,
+                               visibility VARCHAR(20) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'public')),
+                               share_token VARCHAR(64),
+                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                               modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                               FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_reading_lists_user_shelf ON reading_lists(user_id, shelf) WHERE shelf IS NOT NULL;
+CREATE UNIQUE INDEX idx_reading_lists_share_token ON reading_lists(share_token) WHERE share_token IS NOT NULL;
+CREATE INDEX idx_reading_lists_user_id ON reading_lists(user_id);
+
+CREATE TABLE reading_list_books (
+                                    list_id INTEGER NOT NULL,
+                                    book_id INTEGER NOT NULL,
+                                    position INTEGER NOT NULL,
+                                    note TEXT,
+                                    added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                    PRIMARY KEY (list_id, book_id),
+                                    FOREIGN KEY (list_id) REFERENCES reading_lists(id) ON DELETE CASCADE,
+                                    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_reading_list_books_book_id ON reading_list_books(book_id);
+
+CREATE TABLE reading_progress (
+                                  user_id INTEGER NOT NULL,
+                                  book_id INTEGER NOT NULL,
+                                  current_page INTEGER NOT NULL DEFAULT 0 CHECK (current_page >= 0),
+                                  started_at DATE,
+                                  finished_at DATE,
+                                  modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                  PRIMARY KEY (user_id, book_id),
+                                  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                                  FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_reading_progress_book_id ON reading_progress(book_id);
+
+-- +migrate Down
+DROP TABLE reading_progress;
+DROP TABLE reading_list_books;
+DROP TABLE reading_lists;
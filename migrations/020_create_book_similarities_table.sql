@@ -0,0 +1,21 @@
+-- +migrate Up
+-- Precomputed by the similar-books background job; the table is rebuilt on every run
+CREATE TABLE book_similarities (
+                                   book_id INTEGER NOT NULL,
+                                   similar_book_id INTEGER NOT NULL,
+                                   score NUMERIC(5, 4) NOT NULL,
+                                   category_score NUMERIC(5, 4) NOT NULL DEFAULT 0,
+                                   text_score NUMERIC(5, 4) NOT NULL DEFAULT 0,
+                                   year_score NUMERIC(5, 4) NOT NULL DEFAULT 0,
+                                   activity_score NUMERIC(5, 4),
+                                   computed_at TIMESTAMP NOT NULL,
+                                   PRIMARY KEY (book_id, similar_book_id),
+                                   FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+                                   FOREIGN KEY (similar_book_id) REFERENCES books(id) ON DELETE CASCADE,
+                                   CHECK (book_id <> similar_book_id)
+);
+
+CREATE INDEX idx_book_similarities_score ON book_similarities(book_id, score DESC);
+
+-- +migrate Down
+DROP TABLE book_similarities;
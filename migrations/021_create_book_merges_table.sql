@@ -0,0 +1,19 @@
+-- +migrate Up
+-- Audit of merged duplicates. The source book is deleted by the merge, so its
+-- data is kept as a snapshot and neither book ID is a foreign key.
+CREATE TABLE book_merges (
+                             id SERIAL PRIMARY KEY,
+                             target_book_id INTEGER NOT NULL,
+                             source_book_id INTEGER NOT NULL,
+                             source_title VARCHAR(1000) NOT NULL,
+                             source_snapshot JSONB NOT NULL,
+                             moved JSONB NOT NULL,
+                             merged_at TIMESTAMP NOT NULL,
+                             merged_by VARCHAR(255) NOT NULL
+);
+
+CREATE INDEX idx_book_merges_target_book_id ON book_merges(target_book_id);
+CREATE INDEX idx_book_merges_source_book_id ON book_merges(source_book_id);
+
+-- +migrate Down
+DROP TABLE book_merges;
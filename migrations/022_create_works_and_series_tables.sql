@@ -0,0 +1,67 @@
+-- +migrate Up
+-- A work groups the editions and translations of the same title
+CREATE TABLE works (
+                       id SERIAL PRIMARY KEY,
+                       title VARCHAR(1000) NOT NULL,
+                       description TEXT,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       created_by VARCHAR(255) DEFAULT 'system',
+                       modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       modified_by VARCHAR(255) DEFAULT 'system'
+);
+
+ALTER TABLE books ADD COLUMN work_id INTEGER REFERENCES works(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_books_work_id ON books(work_id);
+
+CREATE TABLE series (
+                        id SERIAL PRIMARY KEY,
+                        name VARCHAR(255) NOT NULL,
+                        description TEXT,
+                        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                        created_by VARCHAR(255) DEFAULT 'system',
+                        modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                        modified_by VARCHAR(255) DEFAULT 'system'
+);
+
+CREATE UNIQUE INDEX idx_series_name ON series (LOWER(name));
+
+-- Volume numbers may be fractional (e.g. 2.5 for a novella between volumes)
+CREATE TABLE series_books (
+                              series_id INTEGER NOT NULL,
+                              book_id INTEGER NOT NULL,
+                              volume_number NUMERIC(6, 2) NOT NULL CHECK (volume_number > 0),
+                              created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                              created_by VARCHAR(255) DEFAULT 'system',
+                              PRIMARY KEY (series_id, book_id),
+                              FOREIGN KEY (series_id) REFERENCES series(id) ON DELETE CASCADE,
+                              FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_series_books_volume ON series_books(series_id, volume_number);
+CREATE INDEX idx_series_books_book_id ON series_books(book_id);
+
+-- Relations are stored in one direction (book is a translation of / sequel to /
+-- revised edition of related_book) and read from both sides
+CREATE TABLE book_relations (
+                                id SERIAL PRIMARY KEY,
+                                book_id INTEGER NOT NULL,
+                                related_book_id INTEGER NOT NULL,
+                                relation_type VARCHAR(30) NOT NULL CHECK (relation_type IN ('translation_of', 'sequel_to', 'revised_edition_of')),
+                                created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                created_by VARCHAR(255) DEFAULT 'system',
+                                FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+                                FOREIGN KEY (related_book_id) REFERENCES books(id) ON DELETE CASCADE,
+                                CONSTRAINT chk_book_relations_self CHECK (book_id <> related_book_id)
+);
+
+CREATE UNIQUE INDEX idx_book_relations_unique ON book_relations(book_id, related_book_id, relation_type);
+CREATE INDEX idx_book_relations_related_book_id ON book_relations(related_book_id);
+
+-- +migrate Down
+DROP TABLE book_relations;
+DROP TABLE series_books;
+DROP TABLE series;
+DROP INDEX IF EXISTS idx_books_work_id;
+ALTER TABLE books DROP COLUMN work_id;
+DROP TABLE works;
@@ -0,0 +1,31 @@
+-- +migrate Up
+-- Translations of book and category content; the columns on books and
+-- categories hold the default locale
+CREATE TABLE book_translations (
+                                   book_id INTEGER NOT NULL,
+                                   locale VARCHAR(3) NOT NULL,
+                                   title VARCHAR(1000) NOT NULL,
+                                   description TEXT,
+                                   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                   created_by VARCHAR(255) DEFAULT 'system',
+                                   modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                   modified_by VARCHAR(255) DEFAULT 'system',
+                                   PRIMARY KEY (book_id, locale),
+                                   FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+
+CREATE TABLE category_translations (
+                                       category_id INTEGER NOT NULL,
+                                       locale VARCHAR(3) NOT NULL,
+                                       name VARCHAR(255) NOT NULL,
+                                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                       created_by VARCHAR(255) DEFAULT 'system',
+                                       modified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                       modified_by VARCHAR(255) DEFAULT 'system',
+                                       PRIMARY KEY (category_id, locale),
+                                       FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE category_translations;
+DROP TABLE book_translations;